@@ -25,6 +25,7 @@ import (
 
 	"kurator.dev/kurator/cmd/fleet-manager/options"
 	"kurator.dev/kurator/pkg/fleet-manager/backup"
+	"kurator.dev/kurator/pkg/webhooks"
 )
 
 var log = ctrl.Log.WithName("backup")
@@ -54,5 +55,34 @@ func InitControllers(ctx context.Context, opts *options.Options, mgr ctrl.Manage
 		return err
 	}
 
+	if err := (&backup.BackupVerificationManager{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: opts.Concurrency, RecoverPanic: ptr.Of[bool](true)}); err != nil {
+		log.Error(err, "unable to create controller", "controller", "BackupVerification")
+		return err
+	}
+
+	if err := (&webhooks.BackupWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		log.Error(err, "unable to create Backup webhook", "Webhook", "Backup")
+		return err
+	}
+
+	if err := (&webhooks.RestoreWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		log.Error(err, "unable to create Restore webhook", "Webhook", "Restore")
+		return err
+	}
+
+	if err := (&webhooks.MigrateWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		log.Error(err, "unable to create Migrate webhook", "Webhook", "Migrate")
+		return err
+	}
+
 	return nil
 }
@@ -22,9 +22,12 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"kurator.dev/kurator/cmd/kurator/app/backup"
 	"kurator.dev/kurator/cmd/kurator/app/install"
 	"kurator.dev/kurator/cmd/kurator/app/join"
+	"kurator.dev/kurator/cmd/kurator/app/migrate"
 	"kurator.dev/kurator/cmd/kurator/app/pipeline"
+	"kurator.dev/kurator/cmd/kurator/app/restore"
 	"kurator.dev/kurator/cmd/kurator/app/tool"
 	"kurator.dev/kurator/cmd/kurator/app/version"
 	"kurator.dev/kurator/pkg/generic"
@@ -55,6 +58,9 @@ func NewKuratorCommand() *cobra.Command {
 	cmd.AddCommand(join.NewCmd(o))
 	cmd.AddCommand(tool.NewCmd(o))
 	cmd.AddCommand(pipeline.NewCmd(o))
+	cmd.AddCommand(backup.NewCmd(o))
+	cmd.AddCommand(restore.NewCmd(o))
+	cmd.AddCommand(migrate.NewCmd(o))
 
 	return cmd
 }
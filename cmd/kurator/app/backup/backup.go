@@ -0,0 +1,164 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"kurator.dev/kurator/pkg/backup"
+	"kurator.dev/kurator/pkg/generic"
+)
+
+func NewCmd(opts *generic.Options) *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:                   "backup",
+		Short:                 "manage kurator fleet backup",
+		DisableFlagsInUseLine: true,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{
+			UnknownFlags: true,
+		},
+	}
+
+	backupCmd.AddCommand(newCreateCmd(opts))
+	backupCmd.AddCommand(newListCmd(opts))
+	backupCmd.AddCommand(newDescribeCmd(opts))
+	backupCmd.AddCommand(newDeleteCmd(opts))
+
+	return backupCmd
+}
+
+func newCreateCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	var backupArgs = backup.BackupArgs{}
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "create a backup of the fleet clusters",
+		Example: `  # Back up all clusters of the fleet quickstart once
+  kurator backup create minimal --fleet quickstart
+
+  # Back up the namespace kurator-backup of a cluster every 5 minutes
+  kurator backup create schedule --fleet quickstart --clusters AttachedCluster/kurator-member1 --include-namespaces kurator-backup --schedule "*/5 * * * *"
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("backup init error: %v", err)
+				return fmt.Errorf("backup init error: %v", err)
+			}
+
+			return operator.CreateBackup(names[0], &backupArgs)
+		},
+	}
+
+	backup.AddNamespaceFlag(createCmd, &args, false)
+	backup.AddFilterFlags(createCmd, &backupArgs.FilterArgs)
+	f := createCmd.PersistentFlags()
+	f.StringVar(&backupArgs.Fleet, "fleet", "", "fleet to back up")
+	f.StringSliceVar(&backupArgs.Clusters, "clusters", nil, "clusters of the fleet to back up in the form of <kind>/<name>, all clusters of the fleet if not set")
+	f.StringVar(&backupArgs.Schedule, "schedule", "", "cron expression to back up periodically, back up once if not set")
+	f.DurationVar(&backupArgs.TTL, "ttl", 0, "how long the backups are kept, 720h by default")
+
+	return createCmd
+}
+
+func newListCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list the kurator fleet backups",
+		Example: `  # List the backups in the default namespace
+  kurator backup list
+
+  # List the backups across all namespaces with more information
+  kurator backup list -A -o wide
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("backup init error: %v", err)
+				return fmt.Errorf("backup init error: %v", err)
+			}
+
+			return operator.ListBackups()
+		},
+	}
+
+	backup.AddNamespaceFlag(listCmd, &args, true)
+	backup.AddOutputFlag(listCmd, &args)
+
+	return listCmd
+}
+
+func newDescribeCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	describeCmd := &cobra.Command{
+		Use:   "describe NAME",
+		Short: "describe the kurator fleet backup with the velero backup in each cluster",
+		Example: `  # Describe the backup schedule in the default namespace
+  kurator backup describe schedule
+
+  # Describe the backup schedule in YAML output format
+  kurator backup describe schedule -o yaml
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("backup init error: %v", err)
+				return fmt.Errorf("backup init error: %v", err)
+			}
+
+			return operator.DescribeBackup(names[0])
+		},
+	}
+
+	backup.AddNamespaceFlag(describeCmd, &args, false)
+	backup.AddOutputFlag(describeCmd, &args)
+
+	return describeCmd
+}
+
+func newDeleteCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	deleteCmd := &cobra.Command{
+		Use:   "delete NAME...",
+		Short: "delete the kurator fleet backups",
+		Example: `  # Delete the backup schedule in the default namespace
+  kurator backup delete schedule
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("backup init error: %v", err)
+				return fmt.Errorf("backup init error: %v", err)
+			}
+
+			return operator.DeleteBackups(names)
+		},
+	}
+
+	backup.AddNamespaceFlag(deleteCmd, &args, false)
+
+	return deleteCmd
+}
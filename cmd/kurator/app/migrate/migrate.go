@@ -0,0 +1,165 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"kurator.dev/kurator/pkg/backup"
+	"kurator.dev/kurator/pkg/generic"
+)
+
+func NewCmd(opts *generic.Options) *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:                   "migrate",
+		Short:                 "manage kurator fleet migrate",
+		DisableFlagsInUseLine: true,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{
+			UnknownFlags: true,
+		},
+	}
+
+	migrateCmd.AddCommand(newCreateCmd(opts))
+	migrateCmd.AddCommand(newListCmd(opts))
+	migrateCmd.AddCommand(newDescribeCmd(opts))
+	migrateCmd.AddCommand(newDeleteCmd(opts))
+
+	return migrateCmd
+}
+
+func newCreateCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	var migrateArgs = backup.MigrateArgs{}
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "migrate the resources from a cluster to other clusters",
+		Example: `  # Migrate the namespace kurator-backup from a cluster to another cluster of the fleet quickstart
+  kurator migrate create select-labels --source-fleet quickstart --source-cluster AttachedCluster/kurator-member1 --target-clusters AttachedCluster/kurator-member2 --include-namespaces kurator-backup
+
+  # Migrate to another fleet in another namespace with cutover
+  kurator migrate create cutover --source-fleet quickstart --source-cluster AttachedCluster/kurator-member1 --target-fleet production --target-namespace prod --include-namespaces kurator-backup --cutover
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("migrate init error: %v", err)
+				return fmt.Errorf("migrate init error: %v", err)
+			}
+
+			return operator.CreateMigrate(names[0], &migrateArgs)
+		},
+	}
+
+	backup.AddNamespaceFlag(createCmd, &args, false)
+	backup.AddFilterFlags(createCmd, &migrateArgs.FilterArgs)
+	f := createCmd.PersistentFlags()
+	f.StringVar(&migrateArgs.SourceFleet, "source-fleet", "", "fleet of the source cluster")
+	f.StringVar(&migrateArgs.SourceNamespace, "source-namespace", "", "namespace of the source fleet, the namespace of the migrate if not set")
+	f.StringVar(&migrateArgs.SourceCluster, "source-cluster", "", "source cluster in the form of <kind>/<name>")
+	f.StringVar(&migrateArgs.TargetFleet, "target-fleet", "", "fleet of the target clusters, the source fleet if not set")
+	f.StringVar(&migrateArgs.TargetNamespace, "target-namespace", "", "namespace of the target fleet, the namespace of the migrate if not set")
+	f.StringSliceVar(&migrateArgs.TargetClusters, "target-clusters", nil, "target clusters in the form of <kind>/<name>, all clusters of the target fleet if not set")
+	f.BoolVar(&migrateArgs.Cutover, "cutover", false, "quiesce the source workloads and switch to the target clusters, requires --include-namespaces")
+	f.BoolVar(&migrateArgs.DeleteSource, "delete-source", false, "delete the source workloads once the cutover succeeds")
+
+	return createCmd
+}
+
+func newListCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list the kurator fleet migrates",
+		Example: `  # List the migrates in the default namespace
+  kurator migrate list
+
+  # List the migrates across all namespaces in YAML output format
+  kurator migrate list -A -o yaml
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("migrate init error: %v", err)
+				return fmt.Errorf("migrate init error: %v", err)
+			}
+
+			return operator.ListMigrates()
+		},
+	}
+
+	backup.AddNamespaceFlag(listCmd, &args, true)
+	backup.AddOutputFlag(listCmd, &args)
+
+	return listCmd
+}
+
+func newDescribeCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	describeCmd := &cobra.Command{
+		Use:   "describe NAME",
+		Short: "describe the kurator fleet migrate with the velero backup and restores in each cluster",
+		Example: `  # Describe the migrate select-labels in the default namespace
+  kurator migrate describe select-labels
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("migrate init error: %v", err)
+				return fmt.Errorf("migrate init error: %v", err)
+			}
+
+			return operator.DescribeMigrate(names[0])
+		},
+	}
+
+	backup.AddNamespaceFlag(describeCmd, &args, false)
+	backup.AddOutputFlag(describeCmd, &args)
+
+	return describeCmd
+}
+
+func newDeleteCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	deleteCmd := &cobra.Command{
+		Use:   "delete NAME...",
+		Short: "delete the kurator fleet migrates",
+		Example: `  # Delete the migrate select-labels in the default namespace
+  kurator migrate delete select-labels
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("migrate init error: %v", err)
+				return fmt.Errorf("migrate init error: %v", err)
+			}
+
+			return operator.DeleteMigrates(names)
+		},
+	}
+
+	backup.AddNamespaceFlag(deleteCmd, &args, false)
+
+	return deleteCmd
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"kurator.dev/kurator/pkg/backup"
+	"kurator.dev/kurator/pkg/generic"
+)
+
+func NewCmd(opts *generic.Options) *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:                   "restore",
+		Short:                 "manage kurator fleet restore",
+		DisableFlagsInUseLine: true,
+		FParseErrWhitelist: cobra.FParseErrWhitelist{
+			UnknownFlags: true,
+		},
+	}
+
+	restoreCmd.AddCommand(newCreateCmd(opts))
+	restoreCmd.AddCommand(newListCmd(opts))
+	restoreCmd.AddCommand(newDescribeCmd(opts))
+	restoreCmd.AddCommand(newDeleteCmd(opts))
+
+	return restoreCmd
+}
+
+func newCreateCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	var restoreArgs = backup.RestoreArgs{}
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "create a restore from a kurator fleet backup",
+		Example: `  # Restore the backup minimal into all clusters of the backup
+  kurator restore create minimal --from-backup minimal
+
+  # Restore the namespace kurator-backup of the backup schedule into a cluster of the backup
+  kurator restore create schedule --from-backup schedule --fleet quickstart --clusters AttachedCluster/kurator-member2 --include-namespaces kurator-backup
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("restore init error: %v", err)
+				return fmt.Errorf("restore init error: %v", err)
+			}
+
+			return operator.CreateRestore(names[0], &restoreArgs)
+		},
+	}
+
+	backup.AddNamespaceFlag(createCmd, &args, false)
+	backup.AddFilterFlags(createCmd, &restoreArgs.FilterArgs)
+	f := createCmd.PersistentFlags()
+	f.StringVar(&restoreArgs.FromBackup, "from-backup", "", "backup to restore from")
+	f.StringVar(&restoreArgs.Fleet, "fleet", "", "fleet of the backup, only required with --clusters")
+	f.StringSliceVar(&restoreArgs.Clusters, "clusters", nil, "clusters of the backup to restore into in the form of <kind>/<name>, all clusters of the backup if not set")
+	f.StringToStringVar(&restoreArgs.NamespaceMapping, "namespace-mappings", nil, "namespaces to restore into other namespaces, e.g. src1=dst1,src2=dst2")
+
+	return createCmd
+}
+
+func newListCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list the kurator fleet restores",
+		Example: `  # List the restores in the default namespace
+  kurator restore list
+
+  # List the restores across all namespaces in JSON output format
+  kurator restore list -A -o json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("restore init error: %v", err)
+				return fmt.Errorf("restore init error: %v", err)
+			}
+
+			return operator.ListRestores()
+		},
+	}
+
+	backup.AddNamespaceFlag(listCmd, &args, true)
+	backup.AddOutputFlag(listCmd, &args)
+
+	return listCmd
+}
+
+func newDescribeCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	describeCmd := &cobra.Command{
+		Use:   "describe NAME",
+		Short: "describe the kurator fleet restore with the velero restore in each cluster",
+		Example: `  # Describe the restore minimal in the default namespace
+  kurator restore describe minimal
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("restore init error: %v", err)
+				return fmt.Errorf("restore init error: %v", err)
+			}
+
+			return operator.DescribeRestore(names[0])
+		},
+	}
+
+	backup.AddNamespaceFlag(describeCmd, &args, false)
+	backup.AddOutputFlag(describeCmd, &args)
+
+	return describeCmd
+}
+
+func newDeleteCmd(opts *generic.Options) *cobra.Command {
+	var args = backup.Args{}
+	deleteCmd := &cobra.Command{
+		Use:   "delete NAME...",
+		Short: "delete the kurator fleet restores",
+		Example: `  # Delete the restore minimal in the default namespace
+  kurator restore delete minimal
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, names []string) error {
+			operator, err := backup.NewOperator(opts, &args, os.Stdout)
+			if err != nil {
+				logrus.Errorf("restore init error: %v", err)
+				return fmt.Errorf("restore init error: %v", err)
+			}
+
+			return operator.DeleteRestores(names)
+		},
+	}
+
+	backup.AddNamespaceFlag(deleteCmd, &args, false)
+
+	return deleteCmd
+}
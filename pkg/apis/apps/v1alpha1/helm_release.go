@@ -119,6 +119,20 @@ type HelmRelease struct {
 	// Values holds the values for this Helm release.
 	// +optional
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// ValuesOverlays override the values for the destination clusters selected by labels.
+	// The overlays matching a cluster are merged into Values in order, so the later ones take precedence.
+	// +optional
+	ValuesOverlays []HelmValuesOverlay `json:"valuesOverlays,omitempty"`
+}
+
+// HelmValuesOverlay defines the values for the destination clusters matching the selector.
+type HelmValuesOverlay struct {
+	// ClusterSelector selects the destination clusters by labels.
+	ClusterSelector ClusterSelector `json:"clusterSelector"`
+
+	// Values are merged into the values of the Helm release in the selected clusters.
+	Values *apiextensionsv1.JSON `json:"values"`
 }
 
 // HelmChartTemplate defines the template from which the controller will
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	gatewayapiv1beta1 "github.com/fluxcd/flagger/pkg/apis/gatewayapi/v1beta1"
 	istiov1alpha3 "github.com/fluxcd/flagger/pkg/apis/istio/v1alpha3"
 	helmv2beta1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	kustomizev1beta2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
@@ -31,6 +32,10 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Namespaced,categories=kurator-dev
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the Application across all the clusters"
+// +kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=".status.summary.clusters",description="Number of the destination clusters"
+// +kubebuilder:printcolumn:name="In-Sync",type="integer",JSONPath=".status.summary.inSync",description="Number of the clusters in sync with the latest revision"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Application is the schema for the application's API.
 type Application struct {
@@ -51,8 +56,27 @@ type ApplicationSpec struct {
 	// And if both the current field and syncPolicies' destination are empty, the application will be deployed directly in the cluster where kurator resides.
 	// +optional
 	Destination *ApplicationDestination `json:"destination,omitempty"`
+	// OrphanPolicy defines what to do with the Kustomizations and HelmReleases that are not desired anymore,
+	// because the cluster leaves the destination or the sync policy is removed from the Application.
+	// Delete removes them together with the rollout resources created by Kurator in the cluster,
+	// while Orphan leaves them untouched.
+	// Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +kubebuilder:default=Delete
+	// +optional
+	OrphanPolicy OrphanPolicy `json:"orphanPolicy,omitempty"`
 }
 
+// OrphanPolicy defines what to do with the resources of the clusters that leave the Application's destination.
+type OrphanPolicy string
+
+const (
+	// OrphanPolicyDelete deletes the orphaned resources.
+	OrphanPolicyDelete OrphanPolicy = "Delete"
+	// OrphanPolicyOrphan keeps the orphaned resources, which are no longer updated by the Application.
+	OrphanPolicyOrphan OrphanPolicy = "Orphan"
+)
+
 // ApplicationSource defines the configuration to produce an artifact for git, helm or oci repository.
 // Note only one source can be specified.
 type ApplicationSource struct {
@@ -87,6 +111,8 @@ type ClusterSelector struct {
 type ApplicationSyncPolicy struct {
 	// Name defines the name of the sync policy.
 	// If unspecified, a name of format `<application name>-<index>` will be generated.
+	// It must be set if there are multiple sync policies and the orphan policy is not Orphan,
+	// otherwise removing or reordering the policies would recreate the resources of the following ones.
 	// +optional
 	Name string `json:"name,omitempty"`
 
@@ -107,6 +133,43 @@ type ApplicationSyncPolicy struct {
 	// If specified, a uniform rollout policy is configured for this installed object.
 	// +optional
 	Rollout *RolloutConfig `json:"rollout,omitempty"`
+
+	// RolloutStrategy defines how the artifact is deployed to the destination clusters progressively in ordered waves.
+	// If unspecified, the artifact is deployed to all the destination clusters at once.
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// RolloutStrategy deploys the artifact to the destination clusters wave by wave.
+// A wave starts once all the clusters of the previous wave are healthy,
+// that is the Kustomization or HelmRelease is ready and the rollout (if configured) has succeeded.
+// When a wave fails, the rollout halts and the clusters of the subsequent waves keep the previous version
+// until the Application is changed again.
+type RolloutStrategy struct {
+	// Waves are the ordered groups of the destination clusters.
+	// A cluster belongs to the first wave selecting it, and the destination clusters not selected by any wave
+	// are deployed in an implicit last wave.
+	// +kubebuilder:validation:MinItems=1
+	Waves []RolloutWave `json:"waves"`
+
+	// WaveTimeout is the maximum time for the clusters of a wave to become healthy, before the wave is considered to be failed.
+	// Defaults to 10m.
+	// +optional
+	WaveTimeout *metav1.Duration `json:"waveTimeout,omitempty"`
+}
+
+// RolloutWave selects the clusters deployed together, by labels or by names.
+type RolloutWave struct {
+	// Name is the name of the wave.
+	Name string `json:"name"`
+
+	// ClusterSelector selects the destination clusters of this wave by labels.
+	// +optional
+	ClusterSelector *ClusterSelector `json:"clusterSelector,omitempty"`
+
+	// Clusters are the names of the destination clusters of this wave.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
 }
 
 type RolloutConfig struct {
@@ -118,7 +181,7 @@ type RolloutConfig struct {
 	TestLoader *bool `json:"testLoader,omitempty"`
 
 	// TrafficRoutingProvider defines traffic routing provider.
-	// Kurator supports istio,kuma,nginx for now.
+	// Kurator supports istio,kuma,nginx,gatewayapi for now.
 	// Other provider will be added later.
 	TrafficRoutingProvider fleetapi.Provider `json:"trafficRoutingProvider"`
 
@@ -142,10 +205,51 @@ type RolloutConfig struct {
 	// +optional
 	Preview *CustomMetadata `json:"preview,omitempty"`
 
+	// Strategy defines the release strategy of the workload, one of Canary, BlueGreen and ABTesting.
+	// If not set, Kurator infers it from the trafficRouting of rolloutPolicy:
+	// ABTesting if analysisTimes and match are configured, BlueGreen if only analysisTimes is configured, otherwise Canary.
+	// Note: ABTesting is only supported by istio, nginx and gatewayapi.
+	// +kubebuilder:validation:Enum=Canary;BlueGreen;ABTesting
+	// +optional
+	Strategy ReleaseStrategy `json:"strategy,omitempty"`
+
 	// RolloutPolicy defines the release strategy of workload.
 	RolloutPolicy *RolloutPolicy `json:"rolloutPolicy"`
 }
 
+// ReleaseStrategy is the strategy to release the preview version of a workload.
+type ReleaseStrategy string
+
+const (
+	// CanaryReleaseStrategy shifts the traffic to the preview version gradually by weight.
+	CanaryReleaseStrategy ReleaseStrategy = "Canary"
+	// BlueGreenReleaseStrategy analyzes the preview version for a number of iterations,
+	// then switches all the traffic to it at once.
+	BlueGreenReleaseStrategy ReleaseStrategy = "BlueGreen"
+	// ABTestingReleaseStrategy routes the requests matching the HTTP headers or cookies to the preview version
+	// for a number of iterations, then switches all the traffic to it at once.
+	ABTestingReleaseStrategy ReleaseStrategy = "ABTesting"
+)
+
+// EffectiveStrategy returns the release strategy of the rollout,
+// which is inferred from the trafficRouting of rolloutPolicy if the strategy is not set.
+func (r *RolloutConfig) EffectiveStrategy() ReleaseStrategy {
+	if r.Strategy != "" {
+		return r.Strategy
+	}
+	if r.RolloutPolicy == nil || r.RolloutPolicy.TrafficRouting == nil {
+		return CanaryReleaseStrategy
+	}
+	trafficRouting := r.RolloutPolicy.TrafficRouting
+	if trafficRouting.CanaryStrategy != nil || trafficRouting.AnalysisTimes == 0 {
+		return CanaryReleaseStrategy
+	}
+	if len(trafficRouting.Match) != 0 {
+		return ABTestingReleaseStrategy
+	}
+	return BlueGreenReleaseStrategy
+}
+
 type RolloutPolicy struct {
 	// TrafficRouting defines the configuration of the gateway, traffic routing rules, and so on.
 	TrafficRouting *TrafficRoutingConfig `json:"trafficRouting,omitempty"`
@@ -190,7 +294,21 @@ type TrafficRoutingConfig struct {
 	// +optional
 	Gateways []string `json:"gateways,omitempty"`
 
+	// GatewayRefs are the parent Gateways which the generated HTTPRoute attaches to.
+	// Only for gatewayapi, and must be set when the traffic routing provider is gatewayapi.
+	// e.g.:
+	//
+	// ```yaml
+	// gatewayRefs:
+	//   - name: public-gateway
+	//     namespace: gateway-system
+	// ```
+	//
+	// +optional
+	GatewayRefs []gatewayapiv1beta1.ParentReference `json:"gatewayRefs,omitempty"`
+
 	// Defaults to the RolloutConfig.ServiceName
+	// For gatewayapi, they are the hostnames of the generated HTTPRoute.
 	// +optional
 	Hosts []string `json:"hosts,omitempty"`
 
@@ -276,9 +394,10 @@ type TrafficRoutingConfig struct {
 	Protocol string `json:"protocol,omitempty"`
 
 	// CanaryStrategy defines parameters for Canary Deployment.
-	// Note: Kurator determines A/B Testing, Blue/Green Deployment, or Canary Deployment
+	// Note: If rollout strategy is not set, Kurator determines A/B Testing, Blue/Green Deployment, or Canary Deployment
 	// based on the presence of content in the canaryStrategy field.
 	// So can't configure canaryStrategy and analysisTimes at the same time.
+	// Only for Canary strategy.
 	// +optional
 	CanaryStrategy *CanaryConfig `json:"canaryStrategy,omitempty"`
 
@@ -313,6 +432,13 @@ type TrafficRoutingConfig struct {
 	// Traffic that doesn't meet the match will go to the primary service and preview service proportionally.
 	// +optional
 	Match []istiov1alpha3.HTTPMatchRequest `json:"match,omitempty"`
+
+	// Mirror defines whether to copy the requests to the preview service during Blue/Green Deployment.
+	// The responses of the preview service are discarded, so the preview version is analyzed with live traffic
+	// before any user is served by it.
+	// Only for istio and gatewayapi with BlueGreen strategy.
+	// +optional
+	Mirror bool `json:"mirror,omitempty"`
 }
 
 type CanaryConfig struct {
@@ -479,6 +605,154 @@ type CustomMetadata struct {
 type ApplicationStatus struct {
 	SourceStatus *ApplicationSourceStatus `json:"sourceStatus,omitempty"`
 	SyncStatus   []*ApplicationSyncStatus `json:"syncStatus,omitempty"`
+
+	// RolloutStrategyStatus is the progress of the waves of the sync policies with rolloutStrategy.
+	// +optional
+	RolloutStrategyStatus []*RolloutStrategyStatus `json:"rolloutStrategyStatus,omitempty"`
+
+	// Phase is the aggregated phase of the Application across the source and all the clusters.
+	// +optional
+	Phase ApplicationPhase `json:"phase,omitempty"`
+
+	// Summary counts the destination clusters by whether they are in sync with the latest revision.
+	// +optional
+	Summary *ApplicationSyncSummary `json:"summary,omitempty"`
+
+	// ClusterStatus is the readiness of the Kustomization or HelmRelease of each sync policy in each cluster.
+	// +optional
+	ClusterStatus []*ApplicationClusterStatus `json:"clusterStatus,omitempty"`
+}
+
+type ApplicationPhase string
+
+const (
+	// ApplicationProgressing means the Application is being synced to the clusters.
+	ApplicationProgressing ApplicationPhase = "Progressing"
+	// ApplicationHealthy means the Application is ready with the latest revision in all the clusters.
+	ApplicationHealthy ApplicationPhase = "Healthy"
+	// ApplicationDegraded means the source, a cluster or a rollout failed.
+	ApplicationDegraded ApplicationPhase = "Degraded"
+	// ApplicationSuspended means the reconciliation of all the Kustomizations and HelmReleases is suspended.
+	ApplicationSuspended ApplicationPhase = "Suspended"
+)
+
+// ApplicationSyncSummary counts the destination clusters by sync state.
+type ApplicationSyncSummary struct {
+	// Clusters is the number of the destination clusters.
+	Clusters int `json:"clusters"`
+
+	// InSync is the number of the clusters where all the sync policies are ready with the latest revision.
+	InSync int `json:"inSync"`
+
+	// OutOfDate is the number of the clusters not in sync.
+	OutOfDate int `json:"outOfDate"`
+}
+
+// ApplicationClusterStatus defines the observed state of a sync policy in a cluster.
+type ApplicationClusterStatus struct {
+	// ClusterName is the name of the cluster.
+	ClusterName string `json:"clusterName"`
+
+	// ClusterKind is the kind of the cluster.
+	// +optional
+	ClusterKind string `json:"clusterKind,omitempty"`
+
+	// PolicyName is the name of the sync policy.
+	PolicyName string `json:"policyName"`
+
+	// Kind is the kind of the resource syncing the policy to the cluster, Kustomization or HelmRelease.
+	Kind string `json:"kind"`
+
+	// Name is the name of the Kustomization or HelmRelease.
+	Name string `json:"name"`
+
+	// Ready indicates whether the Kustomization or HelmRelease is ready with the latest spec.
+	Ready bool `json:"ready"`
+
+	// InSync indicates whether the latest revision of the source has been applied to the cluster.
+	InSync bool `json:"inSync"`
+
+	// Suspended indicates whether the reconciliation is suspended.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// LastAppliedRevision is the revision of the last successful apply,
+	// the source revision for a Kustomization and the chart version for a HelmRelease.
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+
+	// Message explains why the sync policy is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type RolloutStrategyPhase string
+
+const (
+	// RolloutStrategyProgressing means the waves are being deployed.
+	RolloutStrategyProgressing RolloutStrategyPhase = "Progressing"
+	// RolloutStrategySucceeded means all the waves are healthy.
+	RolloutStrategySucceeded RolloutStrategyPhase = "Succeeded"
+	// RolloutStrategyHalted means a wave failed, and the subsequent waves are not deployed.
+	RolloutStrategyHalted RolloutStrategyPhase = "Halted"
+)
+
+type RolloutWavePhase string
+
+const (
+	RolloutWavePending     RolloutWavePhase = "Pending"
+	RolloutWaveProgressing RolloutWavePhase = "Progressing"
+	RolloutWaveSucceeded   RolloutWavePhase = "Succeeded"
+	RolloutWaveFailed      RolloutWavePhase = "Failed"
+)
+
+// RolloutStrategyStatus defines the observed state of the waves of a sync policy.
+type RolloutStrategyStatus struct {
+	// PolicyName is the name of the sync policy.
+	PolicyName string `json:"policyName"`
+
+	// ObservedGeneration is the generation of the Application being rolled out.
+	// The waves restart from the first one when the Application is changed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the phase of the rollout across the waves.
+	// +optional
+	Phase RolloutStrategyPhase `json:"phase,omitempty"`
+
+	// CurrentWave is the index of the wave being deployed.
+	// +optional
+	CurrentWave int `json:"currentWave"`
+
+	// Message explains why the rollout halted.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Waves is the status of each wave.
+	// +optional
+	Waves []*RolloutWaveStatus `json:"waves,omitempty"`
+}
+
+// RolloutWaveStatus defines the observed state of a wave.
+type RolloutWaveStatus struct {
+	// Name is the name of the wave.
+	Name string `json:"name"`
+
+	// Clusters are the names of the clusters in this wave.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Phase is the phase of this wave.
+	// +optional
+	Phase RolloutWavePhase `json:"phase,omitempty"`
+
+	// StartTime is the time this wave started to be deployed.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Message explains why the wave is not healthy.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // applicationSourceStatus defines the observed state of the artifact source.
@@ -506,6 +780,10 @@ type RolloutStatus struct {
 	// +optional
 	RolloutNameInCluster string `json:"rolloutNameInCluster,omitempty"`
 
+	// Strategy is the release strategy of the rollout performed within this cluster.
+	// +optional
+	Strategy ReleaseStrategy `json:"strategy,omitempty"`
+
 	// RolloutStatusInCluster is the current status of the Rollout performed within this cluster.
 	// +optional
 	RolloutStatusInCluster *flaggerv1b1.CanaryStatus `json:"rolloutStatusInCluster,omitempty"`
@@ -74,12 +74,45 @@ type BackupPolicy struct {
 	// +optional
 	ResourceFilter *ResourceFilter `json:"resourceFilter,omitempty"`
 
-	// TODO: support volume snapshot
+	// SnapshotVolumes specifies whether to take snapshots of the persistent volumes as part of the backup.
+	// The snapshots are taken by the volume snapshot locations of the backup plugin.
+	// If not set, velero takes snapshots if the volume snapshot locations are configured.
+	// +optional
+	SnapshotVolumes *bool `json:"snapshotVolumes,omitempty"`
+
+	// DefaultVolumesToFsBackup specifies whether the file system backup is used for all the pod volumes by default.
+	// If not set, the default of the backup plugin is used, which is true.
+	// +optional
+	DefaultVolumesToFsBackup *bool `json:"defaultVolumesToFsBackup,omitempty"`
+
+	// SnapshotMoveData specifies whether the data of the CSI snapshots is moved to the backup storage,
+	// so that the volumes can be restored when the snapshots are lost with the cluster.
+	// It requires velero v1.12 or later, which can be set by the chart of the backup plugin.
+	// +optional
+	SnapshotMoveData *bool `json:"snapshotMoveData,omitempty"`
+
+	// VolumeSnapshotLocations is the list of the names of the volume snapshot locations used by the backup,
+	// which are defined in the backup plugin of the fleet.
+	// +optional
+	VolumeSnapshotLocations []string `json:"volumeSnapshotLocations,omitempty"`
 
 	// TTL is a time.Duration-parseable string describing how long the Backup should be retained for.
 	// +optional
 	TTL metav1.Duration `json:"ttl,omitempty"`
 
+	// KeepLast is the number of the most recent completed backups to keep in each cluster for a scheduled Backup.
+	// The older backups are deleted from the clusters and the object storage, in addition to the expiration by TTL,
+	// unless they are still used by the Restores or BackupVerifications of this Backup.
+	// If not set, the backups are only expired by TTL.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Hooks are the commands executed in the selected pods before and after they are backed up,
+	// e.g. to flush and lock the tables of a database, so that the backup of its volumes is application-consistent.
+	// +optional
+	Hooks []*BackupHook `json:"hooks,omitempty"`
+
 	// OrderedResources specifies the backup order of resources of specific Kind.
 	// The map key is the resource name and value is a list of object names separated by commas.
 	// Each resource name has format "namespace/objectname".  For cluster resources, simply use "objectname".
@@ -95,14 +128,112 @@ type BackupPolicy struct {
 	OrderedResources map[string]string `json:"orderedResources,omitempty"`
 }
 
+// BackupHook defines the commands executed in the selected pods when they are backed up.
+type BackupHook struct {
+	// Name is the name of the hook.
+	Name string `json:"name"`
+
+	// IncludedNamespaces specifies the namespaces of the pods the hook applies to.
+	// If empty, it applies to all namespaces.
+	// +optional
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+
+	// LabelSelector selects the pods the hook applies to.
+	// If not set, it applies to all the pods in the included namespaces.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Pre are the commands executed in order before the pod is backed up.
+	// +optional
+	Pre []*ExecHook `json:"pre,omitempty"`
+
+	// Post are the commands executed in order after the pod is backed up.
+	// +optional
+	Post []*ExecHook `json:"post,omitempty"`
+}
+
+// ExecHook is a command executed in a container of the pod.
+type ExecHook struct {
+	// Container is the container where the command is executed.
+	// If not set, the first container of the pod is used.
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Command is the command and arguments to execute.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// OnError specifies whether to continue or fail the backup if the command fails.
+	// If not set, the backup fails.
+	// +optional
+	OnError velerov1.HookErrorMode `json:"onError,omitempty"`
+
+	// Timeout is the maximum time to wait for the command to complete, 30s by default.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// BackupPhase is a string representation of the lifecycle phase of a Backup, aggregated from the backups in all the clusters.
+// +kubebuilder:validation:Enum=Pending;InProgress;PartiallyFailed;Completed;Failed
+type BackupPhase string
+
+const (
+	// BackupPhasePending means the backups have not been started in any cluster.
+	BackupPhasePending BackupPhase = "Pending"
+
+	// BackupPhaseInProgress means the backups are in progress in some clusters.
+	BackupPhaseInProgress BackupPhase = "InProgress"
+
+	// BackupPhasePartiallyFailed means the backups are finished, but some of them failed or partially failed.
+	BackupPhasePartiallyFailed BackupPhase = "PartiallyFailed"
+
+	// BackupPhaseCompleted means the backups in all the clusters completed without errors.
+	BackupPhaseCompleted BackupPhase = "Completed"
+
+	// BackupPhaseFailed means the backups in all the clusters failed.
+	BackupPhaseFailed BackupPhase = "Failed"
+)
+
+const (
+	// BackupInProgressReason (Severity=Info) documents the backups are pending or in progress.
+	BackupInProgressReason = "BackupInProgress"
+	// BackupPartiallyFailedReason (Severity=Warning) documents some of the backups failed or partially failed.
+	BackupPartiallyFailedReason = "BackupPartiallyFailed"
+	// BackupFailedReason (Severity=Error) documents the backups in all the clusters failed.
+	BackupFailedReason = "BackupFailed"
+)
+
 type BackupStatus struct {
 	// Conditions represent the current state of the backup operation.
+	// The Ready condition is true when the backups in all the clusters are completed.
 	// +optional
 	Conditions capiv1.Conditions `json:"conditions,omitempty"`
 
 	// Phase represents the current phase of the backup operation.
+	// For a scheduled Backup, it is the phase of the latest backups.
 	// +optional
-	Phase string `json:"phase,omitempty"`
+	Phase BackupPhase `json:"phase,omitempty"`
+
+	// ItemsBackedUp is the number of items backed up in all the clusters.
+	// +optional
+	ItemsBackedUp int `json:"itemsBackedUp,omitempty"`
+
+	// TotalItems is the number of items to be backed up in all the clusters.
+	// +optional
+	TotalItems int `json:"totalItems,omitempty"`
+
+	// Warnings is the number of warnings of the backups in all the clusters.
+	// +optional
+	Warnings int `json:"warnings,omitempty"`
+
+	// Errors is the number of errors of the backups in all the clusters.
+	// +optional
+	Errors int `json:"errors,omitempty"`
+
+	// Duration is the time from the start of the first backup to the completion of the last backup in all the clusters,
+	// which is set when the backups are finished.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 
 	// Details provides a detailed status for each backup in each cluster.
 	// +optional
@@ -126,6 +257,60 @@ type BackupDetails struct {
 	// BackupStatusInCluster is the current status of the backup performed within this cluster.
 	// +optional
 	BackupStatusInCluster *velerov1.BackupStatus `json:"backupStatusInCluster,omitempty"`
+
+	// LastSuccessfulBackupTime is the completion time of the most recent completed backup in this cluster,
+	// which is only set for a scheduled Backup.
+	// +optional
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+
+	// Volumes is the progress of the backup of each volume within this cluster,
+	// including the volumes backed up by the file system backup and the volume snapshots with data moved.
+	// +optional
+	Volumes []*VolumeBackupDetails `json:"volumes,omitempty"`
+}
+
+type VolumeBackupMethod string
+
+const (
+	// VolumeBackupMethodFileSystem indicates the volume is backed up by the file system backup.
+	VolumeBackupMethodFileSystem VolumeBackupMethod = "FileSystem"
+	// VolumeBackupMethodDataMover indicates the data of the volume snapshot is moved to the backup storage.
+	VolumeBackupMethodDataMover VolumeBackupMethod = "DataMover"
+)
+
+type VolumeBackupDetails struct {
+	// Namespace is the namespace of the pod or the persistent volume claim of the volume.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod is the name of the pod mounting the volume, which is set for the file system backup.
+	// +optional
+	Pod string `json:"pod,omitempty"`
+
+	// Volume is the name of the pod volume for the file system backup,
+	// or the name of the persistent volume claim for the data mover.
+	// +optional
+	Volume string `json:"volume,omitempty"`
+
+	// Method is how the volume is backed up.
+	// +optional
+	Method VolumeBackupMethod `json:"method,omitempty"`
+
+	// Phase is the phase of the volume backup.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// BytesDone is the number of bytes backed up.
+	// +optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+
+	// TotalBytes is the total number of bytes to be backed up.
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// Message is a message about the volume backup, usually the error if it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // BackupList contains a list of Backup.
@@ -136,3 +321,11 @@ type BackupList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Backup `json:"items"`
 }
+
+func (b *Backup) GetConditions() capiv1.Conditions {
+	return b.Status.Conditions
+}
+
+func (b *Backup) SetConditions(conditions capiv1.Conditions) {
+	b.Status.Conditions = conditions
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -40,16 +42,29 @@ type MigrateSpec struct {
 	// The user needs to ensure that SourceCluster points to only ONE cluster.
 	// Because the current migration only supports migrating from one SourceCluster to one or more TargetCluster.
 	// +required
-	SourceCluster Destination `json:"sourceCluster"`
+	SourceCluster MigrateDestination `json:"sourceCluster"`
 
 	// TargetClusters represents the target clusters for migration.
+	// The target clusters can belong to a different fleet, even in a different namespace, from the source cluster,
+	// as long as the velero of both sides shares the same backup storage.
 	// +required
-	TargetClusters Destination `json:"targetCluster"`
+	TargetClusters MigrateDestination `json:"targetCluster"`
 
 	// Policy defines the rules for the migration.
 	Policy *MigratePolicy `json:"policy,omitempty"`
 }
 
+// MigrateDestination defines the clusters of a migration.
+// Unlike Destination, the fleet can be in a different namespace from the Migrate,
+// so that workloads can be migrated across fleets and namespaces, e.g. when retiring a fleet.
+type MigrateDestination struct {
+	Destination `json:",inline"`
+
+	// Namespace is the namespace of the fleet. If not set, the namespace of the Migrate is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 type MigratePolicy struct {
 	// ResourceFilter specifies the resources to be included in the migration.
 	// If not set, all resources in source cluster will be migrated.
@@ -87,10 +102,38 @@ type MigratePolicy struct {
 	// +optional
 	// +nullable
 	PreserveNodePorts *bool `json:"preserveNodePorts,omitempty"`
+
+	// Cutover switches the workloads from the source cluster to the target clusters once the resources are migrated.
+	// If not set, the migration completes after the restores finish and the source workloads keep running.
+	// Cutover requires ResourceFilter.IncludedNamespaces to be set, the workloads out of these namespaces are never touched.
+	// +optional
+	Cutover *MigrateCutover `json:"cutover,omitempty"`
 }
 
+// MigrateCutover defines how to switch the workloads from the source cluster to the target clusters.
+// The cutover quiesces the source by scaling its Deployments and StatefulSets to zero, takes a final incremental backup
+// and restores it into the target clusters, then scales the target workloads to the original replicas and verifies they are ready.
+type MigrateCutover struct {
+	// DeleteSource specifies whether to delete the quiesced workloads in the source cluster after the target clusters are verified.
+	// +optional
+	// +kubebuilder:default:=false
+	DeleteSource bool `json:"deleteSource,omitempty"`
+
+	// Rollback specifies whether to scale the target workloads to zero and the source workloads back to the original replicas
+	// if the cutover fails, e.g. the final backup fails or the target workloads are not ready within Timeout. Enabled by default.
+	// +optional
+	Rollback *bool `json:"rollback,omitempty"`
+
+	// Timeout is the maximum time of the cutover, from quiescing the source to the target workloads being ready, 10m by default.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// DefaultMigrateCutoverTimeout is the default timeout of the cutover.
+const DefaultMigrateCutoverTimeout = 10 * time.Minute
+
 // MigratePhase is a string representation of the lifecycle phase of a Migrate instance
-// +kubebuilder:validation:Enum=Pending;FailedValidation;BackupInProgress;RestoreInProgress;Completed;Failed
+// +kubebuilder:validation:Enum=Pending;FailedValidation;BackupInProgress;RestoreInProgress;CutoverInProgress;Verifying;Completed;Failed;RolledBack
 type MigratePhase string
 
 const (
@@ -108,17 +151,45 @@ const (
 	// MigratePhaseRestoreInProgress indicates that the restore phase of the migrate is currently in progress.
 	MigratePhaseRestoreInProgress MigratePhase = "RestoreInProgress"
 
+	// MigratePhaseCutoverInProgress indicates that the source workloads are being quiesced and the final incremental backup is being restored.
+	MigratePhaseCutoverInProgress MigratePhase = "CutoverInProgress"
+
+	// MigratePhaseVerifying indicates that the readiness of the workloads in the target clusters is being verified.
+	MigratePhaseVerifying MigratePhase = "Verifying"
+
 	// MigratePhaseCompleted means the migrate has run successfully
 	// without errors.
 	MigratePhaseCompleted MigratePhase = "Completed"
 
 	// MigratePhaseFailed means the migrate was unable to execute.
 	MigratePhaseFailed MigratePhase = "Failed"
+
+	// MigratePhaseRolledBack means the cutover failed and the source workloads are scaled back to the original replicas.
+	MigratePhaseRolledBack MigratePhase = "RolledBack"
 )
 
 const (
 	// SourceReadyCondition reports on whether the resource of backup in source cluster is ready.
 	SourceReadyCondition capiv1.ConditionType = "sourceReady"
+
+	// BackupStorageSharedCondition reports on whether the velero of the source and target clusters share the same backup storage,
+	// which is required to sync the backup of the source cluster to the target clusters.
+	BackupStorageSharedCondition capiv1.ConditionType = "BackupStorageShared"
+
+	// BackupStorageMismatchReason (Severity=Error) documents the target clusters do not share the backup storage with the source cluster.
+	BackupStorageMismatchReason = "BackupStorageMismatch"
+	// BackupStorageNotFoundReason (Severity=Warning) documents the default backup storage location is not found in a cluster.
+	BackupStorageNotFoundReason = "BackupStorageNotFound"
+
+	// SourceQuiescedCondition reports on whether the workloads in source cluster are scaled to zero for the cutover.
+	SourceQuiescedCondition capiv1.ConditionType = "SourceQuiesced"
+	// TargetVerifiedCondition reports on whether the workloads in target clusters are ready after the cutover.
+	TargetVerifiedCondition capiv1.ConditionType = "TargetVerified"
+
+	// CutoverFailedReason (Severity=Error) documents the cutover failed.
+	CutoverFailedReason = "CutoverFailed"
+	// CutoverRolledBackReason (Severity=Warning) documents the cutover failed and the source workloads are scaled back.
+	CutoverRolledBackReason = "CutoverRolledBack"
 )
 
 type MigrateStatus struct {
@@ -135,6 +206,51 @@ type MigrateStatus struct {
 
 	// TargetClusterStatus provides a detailed status for each restore in each TargetCluster.
 	TargetClustersStatus []*RestoreDetails `json:"targetClusterStatus,omitempty"`
+
+	// CutoverStatus provides the status of the cutover.
+	// +optional
+	CutoverStatus *CutoverStatus `json:"cutoverStatus,omitempty"`
+}
+
+type CutoverStatus struct {
+	// StartTime is the time the cutover started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// QuiescedWorkloads are the workloads in source cluster scaled to zero for the cutover.
+	// +optional
+	QuiescedWorkloads []*QuiescedWorkload `json:"quiescedWorkloads,omitempty"`
+
+	// FinalBackup provides the status of the final incremental backup in source cluster.
+	// +optional
+	FinalBackup *BackupDetails `json:"finalBackup,omitempty"`
+
+	// FinalRestores provides the status of the restores of the final backup in each target cluster.
+	// +optional
+	FinalRestores []*RestoreDetails `json:"finalRestores,omitempty"`
+
+	// Checks are the readiness checks of the workloads in target clusters.
+	// +optional
+	Checks []*WorkloadCheck `json:"checks,omitempty"`
+
+	// Message is a human readable message of the cutover, e.g. the reason of the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// QuiescedWorkload is a workload in source cluster scaled to zero for the cutover.
+type QuiescedWorkload struct {
+	// Kind is the kind of the workload, Deployment or StatefulSet.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// Replicas is the number of replicas of the workload before the cutover.
+	Replicas int32 `json:"replicas"`
 }
 
 // MigrateList contains a list of Migrate.
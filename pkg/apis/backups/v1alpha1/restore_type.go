@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -50,6 +51,26 @@ type RestoreSpec struct {
 	// If null, the backup will be fully restored using default settings.
 	// +optional
 	Policy *RestorePolicy `json:"policy,omitempty"`
+
+	// PointInTime selects the backup to restore from when the referred backup is a scheduled Backup.
+	// If not set, the most recent completed backup in each cluster is restored.
+	// It is ignored for a one-time Backup.
+	// +optional
+	PointInTime *PointInTime `json:"pointInTime,omitempty"`
+}
+
+// PointInTime selects a backup created by a scheduled Backup.
+type PointInTime struct {
+	// Timestamp restores from the most recent completed backup started at or before the timestamp in each cluster,
+	// e.g. "2024-01-16T00:00:00Z" to roll back to the state before a bad deployment.
+	// +optional
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+
+	// BackupNames specifies the Velero backup to restore from in each cluster, which takes precedence over Timestamp.
+	// The key is the name of the cluster, and the value is the name of the Velero backup in the cluster,
+	// which is recorded as `backupNameInCluster` in the status of the Backup.
+	// +optional
+	BackupNames map[string]string `json:"backupNames,omitempty"`
 }
 
 // Note: partly copied from https://github.com/vmware-tanzu/velero/blob/v1.11.1/pkg/apis/velero/v1/restore_types.go
@@ -79,6 +100,72 @@ type RestorePolicy struct {
 	// +optional
 	// +nullable
 	PreserveNodePorts *bool `json:"preserveNodePorts,omitempty"`
+
+	// Hooks are the init containers added to and the commands executed in the selected pods after they are restored,
+	// e.g. to recover a database from the dump taken by the backup hooks.
+	// +optional
+	Hooks []*RestoreHook `json:"hooks,omitempty"`
+}
+
+// RestoreHook defines the init containers and the commands of the selected pods when they are restored.
+type RestoreHook struct {
+	// Name is the name of the hook.
+	Name string `json:"name"`
+
+	// IncludedNamespaces specifies the namespaces of the pods the hook applies to.
+	// If empty, it applies to all namespaces.
+	// +optional
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+
+	// LabelSelector selects the pods the hook applies to.
+	// If not set, it applies to all the pods in the included namespaces.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Init defines the init containers added to the restored pod before the existing init containers.
+	// +optional
+	Init *InitRestoreHook `json:"init,omitempty"`
+
+	// PostExec are the commands executed in order after the containers of the restored pod are running.
+	// +optional
+	PostExec []*ExecRestoreHook `json:"postExec,omitempty"`
+}
+
+// InitRestoreHook defines the init containers added to the restored pod.
+type InitRestoreHook struct {
+	// InitContainers are the init containers to add.
+	// +kubebuilder:validation:MinItems=1
+	InitContainers []corev1.Container `json:"initContainers"`
+
+	// Timeout is the maximum time to wait for the init containers to complete.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ExecRestoreHook is a command executed in a container of the restored pod.
+type ExecRestoreHook struct {
+	// Container is the container where the command is executed.
+	// If not set, the first container of the pod is used.
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Command is the command and arguments to execute.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// OnError specifies whether to continue or fail the restore if the command fails.
+	// If not set, the restore continues.
+	// +optional
+	OnError velerov1.HookErrorMode `json:"onError,omitempty"`
+
+	// ExecTimeout is the maximum time to wait for the command to complete, 30s by default.
+	// +optional
+	ExecTimeout metav1.Duration `json:"execTimeout,omitempty"`
+
+	// WaitTimeout is the maximum time to wait for the container to be running before executing the command.
+	// If not set, it waits until the restore times out.
+	// +optional
+	WaitTimeout metav1.Duration `json:"waitTimeout,omitempty"`
 }
 
 // PreserveStatus specifies which resources we should restore the status field.
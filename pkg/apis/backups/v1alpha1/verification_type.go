@@ -0,0 +1,194 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories=kurator-dev
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupName",description="Backup to verify"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the BackupVerification"
+// +kubebuilder:printcolumn:name="Last Result",type="string",JSONPath=".status.lastResult",description="Result of the last verification"
+
+// BackupVerification is the schema for the BackupVerification's API.
+// It verifies a Backup is restorable by restoring its latest completed backup into scratch namespaces
+// of a designated cluster, checking the restored workloads are ready, and cleaning them up afterwards.
+type BackupVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BackupVerificationSpec   `json:"spec,omitempty"`
+	Status            BackupVerificationStatus `json:"status,omitempty"`
+}
+
+type BackupVerificationSpec struct {
+	// BackupName is the name of the Backup to verify, which is in the same namespace.
+	// +required
+	BackupName string `json:"backupName"`
+
+	// SourceCluster is the cluster of the Backup whose latest completed backup is verified.
+	// It can be omitted if the Backup has only one cluster.
+	// +optional
+	SourceCluster *corev1.ObjectReference `json:"sourceCluster,omitempty"`
+
+	// TargetCluster is the designated cluster where the backup is restored for verification.
+	// The user needs to ensure that TargetCluster points to only ONE cluster,
+	// which shares the backup storage with the source cluster.
+	// +required
+	TargetCluster Destination `json:"targetCluster"`
+
+	// Schedule defines when to run the verification using a Cron expression, e.g. "0 3 * * 0" for every Sunday at 3:00 AM.
+	// If not set, the verification is executed only once.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Namespaces are the namespaces of the backup to restore and verify.
+	// Each of them is restored into the scratch namespace "<namespacePrefix>-<namespace>" by the namespace mapping of the restore.
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// NamespacePrefix is the prefix of the scratch namespaces, "kurator-verify" by default.
+	// +optional
+	NamespacePrefix string `json:"namespacePrefix,omitempty"`
+
+	// Timeout is the maximum time for the restore and the readiness checks of a verification, 30m by default.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// VerificationPhase is a string representation of the lifecycle phase of a BackupVerification.
+// +kubebuilder:validation:Enum=Pending;Restoring;Verifying;Passed;Failed
+type VerificationPhase string
+
+const (
+	// VerificationPhasePending means the verification is waiting for a completed backup or the cleanup of the last verification.
+	VerificationPhasePending VerificationPhase = "Pending"
+
+	// VerificationPhaseRestoring means the backup is being restored into the scratch namespaces.
+	VerificationPhaseRestoring VerificationPhase = "Restoring"
+
+	// VerificationPhaseVerifying means the readiness of the restored workloads is being checked.
+	VerificationPhaseVerifying VerificationPhase = "Verifying"
+
+	// VerificationPhasePassed means the last verification passed and the scratch namespaces are cleaned up.
+	VerificationPhasePassed VerificationPhase = "Passed"
+
+	// VerificationPhaseFailed means the last verification failed and the scratch namespaces are cleaned up.
+	VerificationPhaseFailed VerificationPhase = "Failed"
+)
+
+const (
+	// BackupVerifiedCondition reports on whether the last verification of the backup passed.
+	BackupVerifiedCondition capiv1.ConditionType = "BackupVerified"
+
+	// VerificationInProgressReason (Severity=Info) documents the first verification is in progress.
+	VerificationInProgressReason = "VerificationInProgress"
+	// VerificationFailedReason (Severity=Error) documents the last verification failed.
+	VerificationFailedReason = "VerificationFailed"
+)
+
+type BackupVerificationStatus struct {
+	// Conditions represent the current state of the verification.
+	// +optional
+	Conditions capiv1.Conditions `json:"conditions,omitempty"`
+
+	// Phase represents the current phase of the verification.
+	// +optional
+	Phase VerificationPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message of the current phase, e.g. the reason of the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// BackupNameInCluster is the name of the velero backup being verified.
+	// +optional
+	BackupNameInCluster string `json:"backupNameInCluster,omitempty"`
+
+	// RestoreNameInCluster is the name of the velero restore in the target cluster.
+	// +optional
+	RestoreNameInCluster string `json:"restoreNameInCluster,omitempty"`
+
+	// RestoreStatusInCluster is the status of the velero restore in the target cluster.
+	// +optional
+	RestoreStatusInCluster *velerov1.RestoreStatus `json:"restoreStatusInCluster,omitempty"`
+
+	// StartTime is the time the current or the last verification started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Checks are the readiness checks of the restored workloads in the current or the last verification.
+	// +optional
+	Checks []*WorkloadCheck `json:"checks,omitempty"`
+
+	// LastResult is the result of the last finished verification, Passed or Failed.
+	// +optional
+	LastResult VerificationPhase `json:"lastResult,omitempty"`
+
+	// LastVerificationTime is the time the last verification finished.
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// LastPassedTime is the time the last passed verification finished.
+	// +optional
+	LastPassedTime *metav1.Time `json:"lastPassedTime,omitempty"`
+}
+
+// WorkloadCheck is the readiness check of a restored or migrated workload.
+type WorkloadCheck struct {
+	// Cluster is the cluster of the workload, it is set when the workloads of several clusters are checked.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// Kind is the kind of the workload, e.g. Deployment, StatefulSet, DaemonSet, Pod or PersistentVolumeClaim.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload.
+	Name string `json:"name"`
+
+	// Ready indicates whether the workload is ready.
+	Ready bool `json:"ready"`
+
+	// Message is the reason why the workload is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// BackupVerificationList contains a list of BackupVerification.
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type BackupVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupVerification `json:"items"`
+}
+
+func (v *BackupVerification) GetConditions() capiv1.Conditions {
+	return v.Status.Conditions
+}
+
+func (v *BackupVerification) SetConditions(conditions capiv1.Conditions) {
+	v.Status.Conditions = conditions
+}
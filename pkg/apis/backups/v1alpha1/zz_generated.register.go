@@ -60,6 +60,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Backup{},
 		&BackupList{},
+		&BackupVerification{},
+		&BackupVerificationList{},
 		&Migrate{},
 		&MigrateList{},
 		&Restore{},
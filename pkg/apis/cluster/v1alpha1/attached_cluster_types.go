@@ -17,7 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,6 +29,8 @@ import (
 // +kubebuilder:resource:scope=Namespaced,categories=kurator-dev
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Indicates if the AttachedCluster is ready"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.kubernetesVersion",description="Kubernetes version of the AttachedCluster"
+// +kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.nodeCount",description="Number of nodes in the AttachedCluster"
 
 // AttachedCluster is the schema for the external cluster that are not created by kurator.
 type AttachedCluster struct {
@@ -40,6 +44,11 @@ type AttachedClusterSpec struct {
 	// Kubeconfig represents the secret that contains the credential to access this cluster.
 	// +optional
 	Kubeconfig SecretKeyRef `json:"kubeconfig,omitempty"`
+
+	// ProbeInterval is the interval to probe the health of the cluster.
+	// If unspecified, the cluster is probed every 30 seconds.
+	// +optional
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
 }
 
 // SecretKeyRef holds the reference to a secret key.
@@ -56,6 +65,29 @@ type AttachedClusterStatus struct {
 	// Ready indicates whether the cluster is ready to be registered with Kurator Fleet.
 	// +optional
 	Ready bool `json:"ready"`
+
+	// Conditions defines current service state of the cluster,
+	// e.g. whether the cluster is reachable, authenticated and its version is supported.
+	// +optional
+	Conditions capiv1beta1.Conditions `json:"conditions,omitempty"`
+
+	// KubernetesVersion is the kubernetes version of the cluster discovered by the last probe.
+	// It is cleared when the last probe fails to discover it.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// NodeCount is the number of nodes in the cluster discovered by the last probe.
+	// It is cleared when the last probe fails to discover it, as well as the capacity and allocatable resources.
+	// +optional
+	NodeCount int32 `json:"nodeCount,omitempty"`
+
+	// Capacity is the total capacity of all the nodes in the cluster.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// Allocatable is the total allocatable resources of all the nodes in the cluster.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
 }
 
 // AttachedClusterList contains a list of AttachedCluster.
@@ -67,6 +99,14 @@ type AttachedClusterList struct {
 	Items           []AttachedCluster `json:"items"`
 }
 
+func (ac *AttachedCluster) GetConditions() capiv1beta1.Conditions {
+	return ac.Status.Conditions
+}
+
+func (ac *AttachedCluster) SetConditions(conditions capiv1beta1.Conditions) {
+	ac.Status.Conditions = conditions
+}
+
 func (ac *AttachedCluster) IsReady() bool {
 	return ac.Status.Ready
 }
@@ -50,6 +50,28 @@ const (
 	PrecheckFailedReason = "PrecheckFailed"
 )
 
+const (
+	// ReachableCondition reports whether the api server of the attached cluster is reachable.
+	ReachableCondition capiv1.ConditionType = "Reachable"
+	// UnreachableReason (Severity=Error) documents that the api server of the attached cluster is unreachable.
+	UnreachableReason = "Unreachable"
+	// InvalidKubeconfigReason (Severity=Error) documents that the kubeconfig of the attached cluster is missing or invalid.
+	InvalidKubeconfigReason = "InvalidKubeconfig"
+
+	// AuthenticatedCondition reports whether the credential of the attached cluster is accepted by the api server.
+	AuthenticatedCondition capiv1.ConditionType = "Authenticated"
+	// AuthenticationFailedReason (Severity=Error) documents that the api server rejected the credential of the attached cluster.
+	AuthenticationFailedReason = "AuthenticationFailed"
+
+	// VersionSupportedCondition reports whether the kubernetes version of the attached cluster is supported.
+	VersionSupportedCondition capiv1.ConditionType = "VersionSupported"
+	// VersionUnsupportedReason (Severity=Error) documents that the kubernetes version of the attached cluster is not supported.
+	VersionUnsupportedReason = "VersionUnsupported"
+)
+
+// MinSupportedKubernetesVersion is the minimum kubernetes version of the attached cluster supported by Kurator.
+const MinSupportedKubernetesVersion = "v1.21.0"
+
 // ClusterPhase is a string representation of the cluster's phase.
 type ClusterPhase string
 
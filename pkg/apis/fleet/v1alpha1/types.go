@@ -17,9 +17,13 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 type FleetPhase string
@@ -46,6 +50,44 @@ const (
 // Current the supported value of the annotation is `karmada`.
 const ControlplaneAnnotation = "fleet.kurator.dev/controlplane"
 
+// AllowedMigrateNamespacesAnnotation is the annotation that can be added to the fleet
+// to allow the Migrates in other namespaces to migrate from or to the clusters of the fleet.
+// The value is a comma separated list of namespaces, `*` allows all namespaces.
+// The Migrates in the namespace of the fleet are always allowed.
+const AllowedMigrateNamespacesAnnotation = "fleet.kurator.dev/allowed-migrate-namespaces"
+
+const (
+	// ReadyCondition summarizes the operational state of the fleet.
+	ReadyCondition capiv1beta1.ConditionType = "Ready"
+
+	// ControlPlaneReadyCondition reports whether the fleet control plane is installed.
+	ControlPlaneReadyCondition capiv1beta1.ConditionType = "ControlPlaneReady"
+	// ControlPlaneInstallingReason (Severity=Info) documents that the fleet control plane is being installed.
+	ControlPlaneInstallingReason = "ControlPlaneInstalling"
+	// ControlPlaneFailedReason (Severity=Error) documents that the fleet control plane installation failed.
+	ControlPlaneFailedReason = "ControlPlaneFailed"
+
+	// ClustersReadyCondition reports whether all the member clusters of the fleet are ready.
+	ClustersReadyCondition capiv1beta1.ConditionType = "ClustersReady"
+	// ClustersNotReadyReason (Severity=Warning) documents that some member clusters are not ready.
+	ClustersNotReadyReason = "ClustersNotReady"
+	// ClustersReconcileFailedReason (Severity=Error) documents that the member clusters failed to be reconciled.
+	ClustersReconcileFailedReason = "ClustersReconcileFailed"
+	// ClustersConflictReason (Severity=Warning) documents that some selected clusters belong to other fleets,
+	// which are not managed by the fleet.
+	ClustersConflictReason = "ClustersConflict"
+	// ClustersUnreachableReason (Severity=Warning) documents that some ready member clusters failed to be probed,
+	// which are still registered to the fleet control plane but no plugins are installed in them.
+	ClustersUnreachableReason = "ClustersUnreachable"
+
+	// PluginsReadyCondition reports whether all the plugins of the fleet are installed and ready.
+	PluginsReadyCondition capiv1beta1.ConditionType = "PluginsReady"
+	// PluginsNotReadyReason (Severity=Warning) documents that some plugins are not ready yet.
+	PluginsNotReadyReason = "PluginsNotReady"
+	// PluginsReconcileFailedReason (Severity=Error) documents that some plugins failed to be reconciled.
+	PluginsReconcileFailedReason = "PluginsReconcileFailed"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
@@ -64,14 +106,32 @@ type Fleet struct {
 // FleetSpec defines the desired state of the fleet
 type FleetSpec struct {
 	// Clusters represents the clusters that would be registered to the fleet.
-	// +required
+	// +optional
 	Clusters []*corev1.ObjectReference `json:"clusters,omitempty"`
 
+	// ClusterSelector selects the clusters in the same namespace that would be registered to the fleet by labels.
+	// The selected clusters are merged with the clusters listed in `clusters`,
+	// clusters join or leave the fleet automatically as their labels change.
+	// +optional
+	ClusterSelector *ClusterSelector `json:"clusterSelector,omitempty"`
+
 	// Plugin defines the plugins that would be installed in the fleet.
 	// +optional
 	Plugin *PluginConfig `json:"plugin,omitempty"`
 }
 
+// ClusterSelector defines the label selector and the kind of the clusters to be selected.
+type ClusterSelector struct {
+	// Kind restricts the selected clusters to the specified kind.
+	// If unspecified, both `Cluster` and `AttachedCluster` are selected.
+	// +kubebuilder:validation:Enum=Cluster;AttachedCluster
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// An empty label selector matches all the clusters of the kind.
+	metav1.LabelSelector `json:",inline"`
+}
+
 type PluginConfig struct {
 	// Metric defines the configuration for the monitoring system installation and metrics collection..
 	// +optional
@@ -89,6 +149,72 @@ type PluginConfig struct {
 	Flagger *FlaggerConfig `json:"flagger,omitempty"`
 	// SubMarinerOperator defines the configuration for the kurator network management.
 	SubMarinerOperator *SubMarinerOperatorConfig `json:"submariner,omitempty"`
+	// Custom defines the custom plugins, each of them is an arbitrary helm chart installed by the fleet.
+	// +optional
+	Custom []*CustomPluginConfig `json:"custom,omitempty"`
+	// UpgradeStrategy defines how the upgrades of the chart versions of the plugins are rolled out to the clusters.
+	// It applies to each plugin component installed per cluster, e.g. prometheus, velero, kyverno.
+	// If unspecified, all the clusters are upgraded at once.
+	// +optional
+	UpgradeStrategy *PluginUpgradeStrategy `json:"upgradeStrategy,omitempty"`
+}
+
+// PluginUpgradeStrategy defines the staged upgrade of the plugin components.
+// The clusters are upgraded batch by batch in the order of their names,
+// the next batch starts after all the upgraded clusters are ready and the pause elapses.
+type PluginUpgradeStrategy struct {
+	// BatchSize is the number or the percentage of the clusters upgraded in a batch, e.g. 2 or 25%.
+	// Default is 1.
+	// +optional
+	BatchSize *intstr.IntOrString `json:"batchSize,omitempty"`
+	// PauseBetweenBatches is the duration to wait after a batch is ready before upgrading the next batch.
+	// +optional
+	PauseBetweenBatches *metav1.Duration `json:"pauseBetweenBatches,omitempty"`
+	// ContinueOnFailure indicates to continue upgrading the next batch when the HelmRelease of an upgraded cluster fails.
+	// By default, the upgrade stops until the failure is fixed.
+	// +optional
+	ContinueOnFailure bool `json:"continueOnFailure,omitempty"`
+}
+
+// CustomPluginConfig defines an arbitrary helm chart installed as a fleet plugin.
+type CustomPluginConfig struct {
+	// Name is the name of the plugin, it must be unique among the plugin components of the fleet.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// Chart defines the helm chart of the plugin, the repository, name and version are all required.
+	Chart ChartConfig `json:"chart"`
+	// TargetNamespace is the namespace where the chart is installed.
+	// Default is the name of the plugin.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+	// FleetScoped indicates the chart is installed only once in the cluster where the fleet is,
+	// otherwise it is installed in the clusters of the fleet selected by the cluster selector.
+	// +optional
+	FleetScoped bool `json:"fleetScoped,omitempty"`
+	// ClusterSelector selects the clusters of the fleet by labels that the chart is installed in.
+	// If unspecified, the chart is installed in all the clusters of the fleet.
+	// It is not applied to the fleet scoped plugins.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// ValuesTemplate is a go template of the chart values in YAML format,
+	// the hermetic sprig functions are supported, which exclude the functions reading the environment, e.g. `env`.
+	// The template is rendered for each cluster with `.Fleet.Name`, `.Fleet.Namespace`, `.Cluster.Name` and `.Cluster.Labels`,
+	// `.Cluster` is nil for the fleet scoped plugins.
+	// For example:
+	//
+	// ```yaml
+	// valuesTemplate: |
+	//   clusterName: {{ .Cluster.Name }}
+	//   txtOwnerId: {{ .Fleet.Name }}-{{ .Cluster.Name }}
+	// ```
+	//
+	// +optional
+	ValuesTemplate string `json:"valuesTemplate,omitempty"`
+	// Overrides defines the values overriding the rendered values for the selected clusters.
+	// They are not applied to the fleet scoped plugins.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 }
 
 type MetricConfig struct {
@@ -131,6 +257,10 @@ type PrometheusConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 }
 
 type PrometheusExporterConfig struct {
@@ -153,6 +283,31 @@ type ChartConfig struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ClusterOverride defines the chart values overriding the plugin values for the selected clusters.
+// Either ClusterName or ClusterSelector must be specified, if both are specified the cluster must match both.
+type ClusterOverride struct {
+	// ClusterName selects the cluster by name.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+	// ClusterSelector selects the clusters by labels.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Values is deep merged into the values of the selected clusters.
+	// For example, using following configuration to reduce the retention of prometheus in the edge clusters.
+	//
+	// ```yaml
+	// overrides:
+	//   - clusterSelector:
+	//       matchLabels:
+	//         tier: edge
+	//     values:
+	//       prometheus:
+	//         retention: 2d
+	// ```
+	//
+	Values apiextensionsv1.JSON `json:"values"`
+}
+
 type ThanosConfig struct {
 	// Chart defines the helm chart config of the thanos.
 	// default value is
@@ -185,8 +340,40 @@ type ThanosConfig struct {
 type ObjectStoreConfig struct {
 	// SecretName is the name of the secret that holds the object store configuration.
 	// The path of object store configuration must be `objstore.yml`
+	// If S3Compatible is set, the secret should contain `access-key` and `secret-key` instead,
+	// and the object store configuration is generated by the fleet manager.
 	// +required
 	SecretName string `json:"secretName"`
+	// S3Compatible defines the s3 compatible object storage, such as MinIO or Ceph RGW.
+	// +optional
+	S3Compatible *S3CompatibleObjectStore `json:"s3Compatible,omitempty"`
+}
+
+// S3CompatibleObjectStore defines the bucket of the s3 compatible object storage.
+type S3CompatibleObjectStore struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Endpoint is the URL of the object storage, e.g. `http://minio.minio:9000`.
+	Endpoint string `json:"endpoint"`
+	// Region is the region of the bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	S3CompatibleConfig `json:",inline"`
+}
+
+// S3CompatibleConfig defines the options to access the s3 compatible object storage.
+type S3CompatibleConfig struct {
+	// PathStyle indicates to use the path-style addressing `<endpoint>/<bucket>` instead of the virtual-hosted style.
+	// Default is true.
+	// +optional
+	PathStyle *bool `json:"pathStyle,omitempty"`
+	// CABundle is the PEM encoded CA bundle to verify the TLS certificate of the endpoint.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// InsecureSkipTLSVerify disables the verification of the TLS certificate of the endpoint.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 }
 
 type GrafanaConfig struct {
@@ -250,6 +437,10 @@ type KyvernoConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 }
 
 type PodSecurityPolicy struct {
@@ -290,6 +481,12 @@ type BackupConfig struct {
 	// Storage provides details on where the backup data should be stored.
 	Storage BackupStorage `json:"storage"`
 
+	// VolumeSnapshotLocations defines where the snapshots of the persistent volumes are taken,
+	// which can be referred by the backups with the name.
+	// If set, the volume snapshots are enabled in the backup engine.
+	// +optional
+	VolumeSnapshotLocations []*VolumeSnapshotLocation `json:"volumeSnapshotLocations,omitempty"`
+
 	// ExtraArgs provides the extra chart values for the backup engine chart.
 	// For example, use the following configuration to change the image tag or pull policy:
 	//
@@ -303,6 +500,10 @@ type BackupConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 }
 
 type BackupStorage struct {
@@ -315,10 +516,17 @@ type BackupStorage struct {
 	//   - `access-key`: The access key for S3 authentication.
 	//   - `secret-key`: The secret key for S3 authentication.
 	//
-	// - For GCP, the secret should be created according to the official GCP documentation.
+	// - For GCP, the secret should contain the following key:
+	//   - `service-account-key`: The key file of the GCP service account with the permissions of the bucket.
 	//   see https://github.com/vmware-tanzu/velero-plugin-for-gcp/blob/main/README.md
 	//
-	// - For Azure, the secret should be created according to the official Azure documentation.
+	// - For Azure, the secret should contain either the keys of the service principal:
+	//   - `subscription-id`, `tenant-id`, `client-id`, `client-secret`: The service principal used by Velero.
+	//   - `resource-group`: Optional, the resource group of the cluster, which is required by volume snapshots.
+	//   or the key of the storage account:
+	//   - `storage-account-key`: The access key of the storage account,
+	//     `storageAccountKeyEnvVar: AZURE_STORAGE_ACCOUNT_ACCESS_KEY` should be set in the location config.
+	//   and an optional `cloud-name` which defaults to `AzurePublicCloud`.
 	//   see https://github.com/vmware-tanzu/velero-plugin-for-microsoft-azure/blob/main/README.md
 	//
 	// +required
@@ -328,14 +536,19 @@ type BackupStorage struct {
 type BackupStorageLocation struct {
 	// Bucket specifies the storage bucket name.
 	Bucket string `json:"bucket"`
-	// Provider specifies the storage provider type (e.g., aws, huaweicloud, gcp, azure).
+	// Provider specifies the storage provider type (e.g., aws, huaweicloud, gcp, azure, s3compatible).
+	// Use s3compatible for the s3 compatible object storage, such as MinIO or Ceph RGW.
 	Provider string `json:"provider"`
 	// Endpoint provides the endpoint URL for the storage.
-	Endpoint string `json:"endpoint"`
+	// It is required for aws, huaweicloud and s3compatible, and not supported for gcp and azure.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 	// Region specifies the region of the storage.
 	// +optional
 	Region string `json:"region,omitempty"`
 	// Config is a map for additional provider-specific configurations.
+	// For azure, `storageAccount` and `resourceGroup` of the storage account are required,
+	// the `resourceGroup` can be omitted if the storage account access key is used.
 	//    #  region:
 	//    #  s3ForcePathStyle:
 	//    #  s3Url:
@@ -353,6 +566,22 @@ type BackupStorageLocation struct {
 	//    #  insecureSkipTLSVerify:
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
+	// S3Compatible defines the options to access the s3 compatible object storage,
+	// which are supported by the aws, huaweicloud and s3compatible providers.
+	// +optional
+	S3Compatible *S3CompatibleConfig `json:"s3Compatible,omitempty"`
+}
+
+type VolumeSnapshotLocation struct {
+	// Name is the name of the volume snapshot location.
+	Name string `json:"name"`
+	// Provider specifies the provider of the volume snapshots, one of aws, gcp and azure.
+	// The credentials of the backup storage are used to take the snapshots.
+	Provider string `json:"provider"`
+	// Config is a map for the provider-specific configurations,
+	// e.g. `region` for aws, `snapshotLocation` and `project` for gcp, `resourceGroup` and `incremental` for azure.
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
 }
 
 type DistributedStorageConfig struct {
@@ -383,6 +612,10 @@ type DistributedStorageConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 }
 
 type DistributedStorage struct {
@@ -559,10 +792,16 @@ type FlaggerConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 	// TrafficRoutingProvider defines traffic routing provider.
 	// And Kurator will install flagger in trafficRoutingProvider's namespace
 	// For example, If you use `istio` as a provider, flager will be installed in istio's namespace `istio-system`.
 	// And if you use `istio` as a provider, you need to install it manually.
+	// If you use `gatewayapi` as a provider, flagger will be installed in `flagger-system`,
+	// and the Gateway API CRDs and a Gateway implementation need to be installed manually.
 	// Otherwise, you can configure it in ProviderConfig (or use the default configuration) and Kurator will automatically deploy it.
 	// Other provider will be added later.
 	TrafficRoutingProvider Provider `json:"trafficRoutingProvider"`
@@ -628,6 +867,10 @@ type SubMarinerOperatorConfig struct {
 	//
 	// +optional
 	ExtraArgs apiextensionsv1.JSON `json:"extraArgs,omitempty"`
+	// Overrides defines the values overriding the chart values for the selected clusters,
+	// the values of the matched overrides are deep merged in order into the values of the cluster.
+	// +optional
+	Overrides []*ClusterOverride `json:"overrides,omitempty"`
 
 	// BrokerCluster is the name of cluster in which the broker will be installed.
 	// If the broker cluster is not specified, the first cluster in the fleet will be used as the broker cluster.
@@ -647,14 +890,16 @@ type SubMarinerOperatorConfig struct {
 	Globalcidrs map[string]string `json:"globalcidrs,omitempty"`
 }
 
-// Provider only can be istio now.
-// TODO: add Linkerd, APP Mesh, NGINX, Kuma, Gateway, Gloo
+// Provider is the traffic routing provider of the rollout, one of istio, kuma, nginx and gatewayapi.
+// TODO: add Linkerd, APP Mesh, Gloo
 type Provider string
 
 const (
 	Istio Provider = "istio"
 	Kuma  Provider = "kuma"
 	Nginx Provider = "nginx"
+	// GatewayAPI routes the traffic by the HTTPRoutes of Kubernetes Gateway API.
+	GatewayAPI Provider = "gatewayapi"
 )
 
 // FleetStatus defines the observed state of the fleet
@@ -667,7 +912,9 @@ type FleetStatus struct {
 	// +optional
 	Phase FleetPhase `json:"phase,omitempty"`
 
-	// TODO: add conditions fields if needed
+	// Conditions defines current service state of the fleet.
+	// +optional
+	Conditions capiv1beta1.Conditions `json:"conditions,omitempty"`
 
 	// A brief CamelCase message indicating details about why the fleet is in this state.
 	// +optional
@@ -681,10 +928,128 @@ type FleetStatus struct {
 
 	// Total number of unready clusters, not ready for use.
 	UnReadyClusters int32 `json:"unReadyClusters,omitempty"`
+
+	// Clusters is the resolved membership of the fleet,
+	// including the clusters listed in `spec.clusters` and the clusters matching `spec.clusterSelector`.
+	// +optional
+	Clusters []*FleetClusterStatus `json:"clusters,omitempty"`
+
+	// Plugins is the status of the plugins installed by the fleet, one entry per plugin component and cluster.
+	// +optional
+	Plugins []*FleetPluginStatus `json:"plugins,omitempty"`
+
+	// PluginUpgrades is the progress of the staged upgrades of the plugin components.
+	// +optional
+	PluginUpgrades []*PluginUpgradeStatus `json:"pluginUpgrades,omitempty"`
+}
+
+type PluginUpgradePhase string
+
+const (
+	// PluginUpgradeProgressing means the clusters are being upgraded batch by batch.
+	PluginUpgradeProgressing PluginUpgradePhase = "Progressing"
+	// PluginUpgradeFailed means the upgrade is stopped as some upgraded clusters failed.
+	PluginUpgradeFailed PluginUpgradePhase = "Failed"
+	// PluginUpgradeCompleted means all the clusters are upgraded.
+	PluginUpgradeCompleted PluginUpgradePhase = "Completed"
+)
+
+// PluginUpgradeStatus describes the progress of the staged upgrade of a plugin component.
+type PluginUpgradeStatus struct {
+	// Component is the component of the plugin, e.g. prometheus, velero.
+	Component string `json:"component"`
+	// Version is the chart version that the component is upgraded to.
+	Version string `json:"version"`
+	// Phase is the phase of the upgrade.
+	Phase PluginUpgradePhase `json:"phase"`
+	// UpdatedClusters is the clusters that have been upgraded.
+	// +optional
+	UpdatedClusters []string `json:"updatedClusters,omitempty"`
+	// PendingClusters is the clusters waiting to be upgraded.
+	// +optional
+	PendingClusters []string `json:"pendingClusters,omitempty"`
+	// LastBatchTime is the time when the last batch started.
+	// +optional
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+	// Message is a human readable message indicating details about the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FleetClusterStatus describes a member cluster of the fleet.
+type FleetClusterStatus struct {
+	// Kind is the kind of the cluster, e.g. Cluster, AttachedCluster.
+	Kind string `json:"kind"`
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+	// Joined indicates whether the cluster has joined the fleet,
+	// including the registration to the fleet control plane if it is specified.
+	// +optional
+	Joined bool `json:"joined"`
+	// Ready indicates whether the cluster is ready and reachable.
+	// +optional
+	Ready bool `json:"ready"`
+	// LastProbeTime is the last time the cluster was probed.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// KubernetesVersion is the kubernetes version of the cluster discovered by the last successful probe.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Message is a human readable message indicating why the cluster is not ready.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FleetPluginStatus describes the status of a plugin component installed in the fleet.
+type FleetPluginStatus struct {
+	// Name is the name of the plugin, e.g. metric, grafana, kyverno, backup,
+	// distributedStorage, flagger, submariner, provider.
+	Name string `json:"name"`
+	// Component is the component of the plugin, e.g. thanos, prometheus.
+	// +optional
+	Component string `json:"component,omitempty"`
+	// Cluster is the name of the cluster that the component is installed in.
+	// Empty means the component is installed in the cluster where the fleet is.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+	// Ready indicates whether the HelmRelease of the component is ready.
+	// +optional
+	Ready bool `json:"ready"`
+	// ChartVersion is the version of the chart of the component.
+	// +optional
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// LastError is the last error message of the component, either from the HelmRelease or from the reconciliation.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastTransitionTime is the last time the readiness of the component changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 type Endpoints []string
 
+// AllowsMigrateFrom returns whether the Migrates in the namespace are allowed to reference the fleet.
+func (f *Fleet) AllowsMigrateFrom(namespace string) bool {
+	if namespace == f.Namespace {
+		return true
+	}
+	for _, allowed := range strings.Split(f.Annotations[AllowedMigrateNamespacesAnnotation], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fleet) GetConditions() capiv1beta1.Conditions {
+	return f.Status.Conditions
+}
+
+func (f *Fleet) SetConditions(conditions capiv1beta1.Conditions) {
+	f.Status.Conditions = conditions
+}
+
 // FleetList contains a list of fleets.
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -22,8 +22,11 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -35,7 +38,29 @@ func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 		**out = **in
 	}
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.VolumeSnapshotLocations != nil {
+		in, out := &in.VolumeSnapshotLocations, &out.VolumeSnapshotLocations
+		*out = make([]*VolumeSnapshotLocation, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VolumeSnapshotLocation)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -76,6 +101,11 @@ func (in *BackupStorageLocation) DeepCopyInto(out *BackupStorageLocation) {
 			(*out)[key] = val
 		}
 	}
+	if in.S3Compatible != nil {
+		in, out := &in.S3Compatible, &out.S3Compatible
+		*out = new(S3CompatibleConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -105,6 +135,45 @@ func (in *ChartConfig) DeepCopy() *ChartConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOverride) DeepCopyInto(out *ClusterOverride) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Values.DeepCopyInto(&out.Values)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOverride.
+func (in *ClusterOverride) DeepCopy() *ClusterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSelector) DeepCopyInto(out *ClusterSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSelector.
+func (in *ClusterSelector) DeepCopy() *ClusterSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -127,6 +196,39 @@ func (in *Config) DeepCopy() *Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomPluginConfig) DeepCopyInto(out *CustomPluginConfig) {
+	*out = *in
+	out.Chart = in.Chart
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPluginConfig.
+func (in *CustomPluginConfig) DeepCopy() *CustomPluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomPluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Device) DeepCopyInto(out *Device) {
 	*out = *in
@@ -200,6 +302,17 @@ func (in *DistributedStorageConfig) DeepCopyInto(out *DistributedStorageConfig)
 		(*in).DeepCopyInto(*out)
 	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -242,6 +355,17 @@ func (in *FlaggerConfig) DeepCopyInto(out *FlaggerConfig) {
 		**out = **in
 	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.ProviderConfig != nil {
 		in, out := &in.ProviderConfig, &out.ProviderConfig
 		*out = new(Config)
@@ -288,6 +412,26 @@ func (in *Fleet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetClusterStatus) DeepCopyInto(out *FleetClusterStatus) {
+	*out = *in
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetClusterStatus.
+func (in *FleetClusterStatus) DeepCopy() *FleetClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetList) DeepCopyInto(out *FleetList) {
 	*out = *in
@@ -321,20 +465,45 @@ func (in *FleetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPluginStatus) DeepCopyInto(out *FleetPluginStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPluginStatus.
+func (in *FleetPluginStatus) DeepCopy() *FleetPluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetSpec) DeepCopyInto(out *FleetSpec) {
 	*out = *in
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
-		*out = make([]*v1.ObjectReference, len(*in))
+		*out = make([]*corev1.ObjectReference, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(v1.ObjectReference)
+				*out = new(corev1.ObjectReference)
 				**out = **in
 			}
 		}
 	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(ClusterSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugin != nil {
 		in, out := &in.Plugin, &out.Plugin
 		*out = new(PluginConfig)
@@ -361,6 +530,13 @@ func (in *FleetStatus) DeepCopyInto(out *FleetStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PluginEndpoints != nil {
 		in, out := &in.PluginEndpoints, &out.PluginEndpoints
 		*out = make(map[string]Endpoints, len(*in))
@@ -376,6 +552,39 @@ func (in *FleetStatus) DeepCopyInto(out *FleetStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]*FleetClusterStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FleetClusterStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]*FleetPluginStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FleetPluginStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PluginUpgrades != nil {
+		in, out := &in.PluginUpgrades, &out.PluginUpgrades
+		*out = make([]*PluginUpgradeStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PluginUpgradeStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -425,6 +634,17 @@ func (in *KyvernoConfig) DeepCopyInto(out *KyvernoConfig) {
 		**out = **in
 	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -556,6 +776,11 @@ func (in *Node) DeepCopy() *Node {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStoreConfig) DeepCopyInto(out *ObjectStoreConfig) {
 	*out = *in
+	if in.S3Compatible != nil {
+		in, out := &in.S3Compatible, &out.S3Compatible
+		*out = new(S3CompatibleObjectStore)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -574,29 +799,29 @@ func (in *Placement) DeepCopyInto(out *Placement) {
 	*out = *in
 	if in.NodeAffinity != nil {
 		in, out := &in.NodeAffinity, &out.NodeAffinity
-		*out = new(v1.NodeAffinity)
+		*out = new(corev1.NodeAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAffinity != nil {
 		in, out := &in.PodAffinity, &out.PodAffinity
-		*out = new(v1.PodAffinity)
+		*out = new(corev1.PodAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodAntiAffinity != nil {
 		in, out := &in.PodAntiAffinity, &out.PodAntiAffinity
-		*out = new(v1.PodAntiAffinity)
+		*out = new(corev1.PodAntiAffinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.TopologySpreadConstraints != nil {
 		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -652,6 +877,22 @@ func (in *PluginConfig) DeepCopyInto(out *PluginConfig) {
 		*out = new(SubMarinerOperatorConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]*CustomPluginConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CustomPluginConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(PluginUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -665,6 +906,62 @@ func (in *PluginConfig) DeepCopy() *PluginConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpgradeStatus) DeepCopyInto(out *PluginUpgradeStatus) {
+	*out = *in
+	if in.UpdatedClusters != nil {
+		in, out := &in.UpdatedClusters, &out.UpdatedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingClusters != nil {
+		in, out := &in.PendingClusters, &out.PendingClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpgradeStatus.
+func (in *PluginUpgradeStatus) DeepCopy() *PluginUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpgradeStrategy) DeepCopyInto(out *PluginUpgradeStrategy) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PauseBetweenBatches != nil {
+		in, out := &in.PauseBetweenBatches, &out.PauseBetweenBatches
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpgradeStrategy.
+func (in *PluginUpgradeStrategy) DeepCopy() *PluginUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurityPolicy) DeepCopyInto(out *PodSecurityPolicy) {
 	*out = *in
@@ -721,6 +1018,17 @@ func (in *PrometheusConfig) DeepCopyInto(out *PrometheusConfig) {
 		**out = **in
 	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -750,6 +1058,49 @@ func (in *PrometheusExporterConfig) DeepCopy() *PrometheusExporterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3CompatibleConfig) DeepCopyInto(out *S3CompatibleConfig) {
+	*out = *in
+	if in.PathStyle != nil {
+		in, out := &in.PathStyle, &out.PathStyle
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CompatibleConfig.
+func (in *S3CompatibleConfig) DeepCopy() *S3CompatibleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(S3CompatibleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3CompatibleObjectStore) DeepCopyInto(out *S3CompatibleObjectStore) {
+	*out = *in
+	in.S3CompatibleConfig.DeepCopyInto(&out.S3CompatibleConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CompatibleObjectStore.
+func (in *S3CompatibleObjectStore) DeepCopy() *S3CompatibleObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(S3CompatibleObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageDeviceSelection) DeepCopyInto(out *StorageDeviceSelection) {
 	*out = *in
@@ -806,6 +1157,17 @@ func (in *SubMarinerOperatorConfig) DeepCopyInto(out *SubMarinerOperatorConfig)
 		**out = **in
 	}
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]*ClusterOverride, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ClusterOverride)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.ClusterCidrs != nil {
 		in, out := &in.ClusterCidrs, &out.ClusterCidrs
 		*out = make(map[string]string, len(*in))
@@ -848,7 +1210,7 @@ func (in *ThanosConfig) DeepCopyInto(out *ThanosConfig) {
 		*out = new(ChartConfig)
 		**out = **in
 	}
-	out.ObjectStoreConfig = in.ObjectStoreConfig
+	in.ObjectStoreConfig.DeepCopyInto(&out.ObjectStoreConfig)
 	in.ExtraArgs.DeepCopyInto(&out.ExtraArgs)
 	return
 }
@@ -862,3 +1224,26 @@ func (in *ThanosConfig) DeepCopy() *ThanosConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotLocation) DeepCopyInto(out *VolumeSnapshotLocation) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotLocation.
+func (in *VolumeSnapshotLocation) DeepCopy() *VolumeSnapshotLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotLocation)
+	in.DeepCopyInto(out)
+	return out
+}
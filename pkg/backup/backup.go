@@ -0,0 +1,222 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	"kurator.dev/kurator/pkg/client"
+	"kurator.dev/kurator/pkg/generic"
+)
+
+// Args holds the common arguments of the backup, restore and migrate commands.
+type Args struct {
+	Namespace     string // Specific namespace of the objects.
+	AllNamespaces bool   // Flag to list the objects across all namespaces.
+	Output        string // Output format, one of tool.Formats.
+}
+
+// FilterArgs holds the arguments to build the resource filter.
+type FilterArgs struct {
+	IncludedNamespaces []string
+	ExcludedNamespaces []string
+	IncludedResources  []string
+	ExcludedResources  []string
+	Selector           string
+}
+
+// BackupArgs holds the arguments for creating a backup.
+type BackupArgs struct {
+	FilterArgs
+	Fleet    string
+	Clusters []string // Clusters in the form of <kind>/<name>, all clusters of the fleet if not set.
+	Schedule string
+	TTL      time.Duration
+}
+
+// Operator manages the backup, restore and migrate objects.
+type Operator struct {
+	client ctrlclient.Client
+	args   *Args
+	out    io.Writer
+	now    func() time.Time
+}
+
+// NewOperator creates a new Operator instance.
+func NewOperator(opts *generic.Options, args *Args, out io.Writer) (*Operator, error) {
+	// the output is only set for the commands printing objects
+	if args.Output != "" {
+		if err := ValidateOutput(args.Output); err != nil {
+			return nil, err
+		}
+	}
+
+	rest := opts.RESTClientGetter()
+	c, err := client.NewClient(rest)
+	if err != nil {
+		return nil, err
+	}
+	return &Operator{
+		client: c.CtrlRuntimeClient(),
+		args:   args,
+		out:    out,
+		now:    time.Now,
+	}, nil
+}
+
+// CreateBackup creates a Backup with the given name.
+func (o *Operator) CreateBackup(name string, a *BackupArgs) error {
+	backup, err := buildBackup(name, o.args.Namespace, a)
+	if err != nil {
+		return err
+	}
+
+	if err := o.client.Create(context.Background(), backup); err != nil {
+		return fmt.Errorf("failed to create backup %s/%s: %v", backup.Namespace, backup.Name, err)
+	}
+	_, err = fmt.Fprintf(o.out, "backup %s/%s created\n", backup.Namespace, backup.Name)
+	return err
+}
+
+func buildBackup(name, namespace string, a *BackupArgs) (*backupapi.Backup, error) {
+	destination, err := buildDestination(a.Fleet, a.Clusters)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := buildResourceFilter(&a.FilterArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &backupapi.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: backupapi.BackupSpec{
+			Schedule:    a.Schedule,
+			Destination: *destination,
+		},
+	}
+	if filter != nil || a.TTL != 0 {
+		backup.Spec.Policy = &backupapi.BackupPolicy{
+			ResourceFilter: filter,
+			TTL:            metav1.Duration{Duration: a.TTL},
+		}
+	}
+	return backup, nil
+}
+
+// buildDestination builds the destination from the fleet and the clusters in the form of <kind>/<name>.
+func buildDestination(fleet string, clusters []string) (*backupapi.Destination, error) {
+	if fleet == "" {
+		return nil, fmt.Errorf("fleet must be set")
+	}
+
+	destination := &backupapi.Destination{Fleet: fleet}
+	for _, cluster := range clusters {
+		kind, name, ok := strings.Cut(cluster, "/")
+		if !ok || kind == "" || name == "" {
+			return nil, fmt.Errorf("invalid cluster %q, must be in the form of <kind>/<name>, e.g. AttachedCluster/member1", cluster)
+		}
+		destination.Clusters = append(destination.Clusters, &corev1.ObjectReference{Kind: kind, Name: name})
+	}
+	return destination, nil
+}
+
+func buildResourceFilter(a *FilterArgs) (*backupapi.ResourceFilter, error) {
+	filter := &backupapi.ResourceFilter{
+		IncludedNamespaces: a.IncludedNamespaces,
+		ExcludedNamespaces: a.ExcludedNamespaces,
+		IncludedResources:  a.IncludedResources,
+		ExcludedResources:  a.ExcludedResources,
+	}
+	if a.Selector != "" {
+		selector, err := metav1.ParseToLabelSelector(a.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %v", a.Selector, err)
+		}
+		filter.LabelSelector = selector
+	}
+
+	if len(filter.IncludedNamespaces) == 0 && len(filter.ExcludedNamespaces) == 0 && len(filter.IncludedResources) == 0 &&
+		len(filter.ExcludedResources) == 0 && filter.LabelSelector == nil {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// ListBackups prints the Backups in the namespace, or across all namespaces.
+func (o *Operator) ListBackups() error {
+	backups := &backupapi.BackupList{}
+	if err := o.client.List(context.Background(), backups, o.listOptions()); err != nil {
+		return fmt.Errorf("failed to list backups: %v", err)
+	}
+
+	return backupListWriter(backups.Items, o.now()).PrintObj(o.out, o.args.Output)
+}
+
+// DescribeBackup prints the Backup with the velero backup phase in each cluster.
+func (o *Operator) DescribeBackup(name string) error {
+	backup := &backupapi.Backup{}
+	if err := o.client.Get(context.Background(), ctrlclient.ObjectKey{Namespace: o.args.Namespace, Name: name}, backup); err != nil {
+		return fmt.Errorf("failed to get backup %s/%s: %v", o.args.Namespace, name, err)
+	}
+
+	return backupDescribeWriter(backup).PrintObj(o.out, o.args.Output)
+}
+
+// DeleteBackups deletes the Backups, the velero backups in the clusters are cleaned up by the fleet manager.
+func (o *Operator) DeleteBackups(names []string) error {
+	for _, name := range names {
+		backup := &backupapi.Backup{ObjectMeta: metav1.ObjectMeta{Namespace: o.args.Namespace, Name: name}}
+		if err := o.delete(backup, "backup"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Operator) delete(obj ctrlclient.Object, kind string) error {
+	if err := o.client.Delete(context.Background(), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%s %s/%s not found", kind, obj.GetNamespace(), obj.GetName())
+		}
+		return fmt.Errorf("failed to delete %s %s/%s: %v", kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	_, err := fmt.Fprintf(o.out, "%s %s/%s deleted\n", kind, obj.GetNamespace(), obj.GetName())
+	return err
+}
+
+func (o *Operator) listOptions() *ctrlclient.ListOptions {
+	listOpts := &ctrlclient.ListOptions{}
+	// Apply namespace filter if AllNamespaces flag is not set.
+	if !o.args.AllNamespaces {
+		listOpts.Namespace = o.args.Namespace
+	}
+	return listOpts
+}
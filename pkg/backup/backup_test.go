@@ -0,0 +1,263 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+var testNow = time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+func newTestOperator(args *Args, objs ...client.Object) (*Operator, *bytes.Buffer) {
+	scheme := runtime.NewScheme()
+	_ = backupapi.AddToScheme(scheme)
+
+	out := &bytes.Buffer{}
+	return &Operator{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		args:   args,
+		out:    out,
+		now:    func() time.Time { return testNow },
+	}, out
+}
+
+func testBackup() *backupapi.Backup {
+	return &backupapi.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "schedule",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Time{Time: testNow.Add(-2 * time.Hour)},
+		},
+		Spec: backupapi.BackupSpec{
+			Schedule:    "*/5 * * * *",
+			Destination: backupapi.Destination{Fleet: "quickstart"},
+		},
+		Status: backupapi.BackupStatus{
+			Phase:         backupapi.BackupPhaseInProgress,
+			ItemsBackedUp: 10,
+			TotalItems:    20,
+			Details: []*backupapi.BackupDetails{
+				{
+					ClusterName:         "member1",
+					ClusterKind:         "AttachedCluster",
+					BackupNameInCluster: "member1-backup-default-schedule",
+					BackupStatusInCluster: &velerov1.BackupStatus{
+						Phase:    velerov1.BackupPhaseCompleted,
+						Progress: &velerov1.BackupProgress{ItemsBackedUp: 10, TotalItems: 10},
+					},
+				},
+				{
+					ClusterName:         "member2",
+					ClusterKind:         "AttachedCluster",
+					BackupNameInCluster: "member2-backup-default-schedule",
+					BackupStatusInCluster: &velerov1.BackupStatus{
+						Phase:    velerov1.BackupPhaseInProgress,
+						Progress: &velerov1.BackupProgress{ItemsBackedUp: 0, TotalItems: 10},
+						Errors:   1,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildBackup(t *testing.T) {
+	backup, err := buildBackup("schedule", "default", &BackupArgs{
+		FilterArgs: FilterArgs{IncludedNamespaces: []string{"kurator-backup"}, Selector: "app=busybox"},
+		Fleet:      "quickstart",
+		Clusters:   []string{"AttachedCluster/member1"},
+		Schedule:   "*/5 * * * *",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, backupapi.Destination{
+		Fleet:    "quickstart",
+		Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member1"}},
+	}, backup.Spec.Destination)
+	assert.Equal(t, []string{"kurator-backup"}, backup.Spec.Policy.ResourceFilter.IncludedNamespaces)
+	assert.Equal(t, map[string]string{"app": "busybox"}, backup.Spec.Policy.ResourceFilter.LabelSelector.MatchLabels)
+
+	backup, err = buildBackup("minimal", "default", &BackupArgs{Fleet: "quickstart"})
+	assert.NoError(t, err)
+	assert.Nil(t, backup.Spec.Policy)
+
+	_, err = buildBackup("minimal", "default", &BackupArgs{})
+	assert.Error(t, err)
+
+	_, err = buildBackup("minimal", "default", &BackupArgs{Fleet: "quickstart", Clusters: []string{"member1"}})
+	assert.Error(t, err)
+}
+
+func TestBuildRestore(t *testing.T) {
+	restore, err := buildRestore("minimal", "default", &RestoreArgs{FromBackup: "minimal"})
+	assert.NoError(t, err)
+	assert.Equal(t, "minimal", restore.Spec.BackupName)
+	assert.Nil(t, restore.Spec.Destination)
+	assert.Nil(t, restore.Spec.Policy)
+
+	restore, err = buildRestore("schedule", "default", &RestoreArgs{
+		FromBackup:       "schedule",
+		Fleet:            "quickstart",
+		Clusters:         []string{"AttachedCluster/member2"},
+		NamespaceMapping: map[string]string{"app": "app-restored"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "quickstart", restore.Spec.Destination.Fleet)
+	assert.Equal(t, map[string]string{"app": "app-restored"}, restore.Spec.Policy.NamespaceMapping)
+
+	_, err = buildRestore("minimal", "default", &RestoreArgs{})
+	assert.Error(t, err)
+}
+
+func TestBuildMigrate(t *testing.T) {
+	migrate, err := buildMigrate("cutover", "default", &MigrateArgs{
+		FilterArgs:     FilterArgs{IncludedNamespaces: []string{"kurator-backup"}},
+		SourceFleet:    "quickstart",
+		SourceCluster:  "AttachedCluster/member1",
+		TargetClusters: []string{"AttachedCluster/member2"},
+		Cutover:        true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "quickstart", migrate.Spec.TargetClusters.Fleet)
+	assert.Equal(t, "member1", migrate.Spec.SourceCluster.Clusters[0].Name)
+	assert.NotNil(t, migrate.Spec.Policy.Cutover)
+
+	_, err = buildMigrate("cutover", "default", &MigrateArgs{SourceFleet: "quickstart"})
+	assert.Error(t, err)
+}
+
+func TestCreateAndDeleteBackup(t *testing.T) {
+	operator, out := newTestOperator(&Args{Namespace: "default"})
+
+	assert.NoError(t, operator.CreateBackup("minimal", &BackupArgs{Fleet: "quickstart"}))
+	assert.Equal(t, "backup default/minimal created\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, operator.DeleteBackups([]string{"minimal"}))
+	assert.Equal(t, "backup default/minimal deleted\n", out.String())
+
+	assert.Error(t, operator.DeleteBackups([]string{"minimal"}))
+}
+
+func TestListBackups(t *testing.T) {
+	operator, out := newTestOperator(&Args{Namespace: "default", Output: "table"}, testBackup())
+	assert.NoError(t, operator.ListBackups())
+	assert.Contains(t, out.String(), "NAMESPACE")
+	assert.Contains(t, out.String(), "schedule")
+	assert.Contains(t, out.String(), "InProgress")
+	assert.Contains(t, out.String(), "1/2")
+	assert.Contains(t, out.String(), "120m")
+	assert.NotContains(t, out.String(), "ITEMS")
+
+	out.Reset()
+	operator.args.Output = "wide"
+	assert.NoError(t, operator.ListBackups())
+	assert.Contains(t, out.String(), "ITEMS")
+	assert.Contains(t, out.String(), "10/20")
+
+	out.Reset()
+	operator.args.Output = "json"
+	assert.NoError(t, operator.ListBackups())
+	var backups []backupapi.Backup
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &backups))
+	assert.Len(t, backups, 1)
+	assert.Equal(t, "schedule", backups[0].Name)
+
+	out.Reset()
+	operator.args.AllNamespaces = true
+	operator.args.Namespace = "other"
+	operator.args.Output = "yaml"
+	assert.NoError(t, operator.ListBackups())
+	backups = nil
+	assert.NoError(t, yaml.Unmarshal(out.Bytes(), &backups))
+	assert.Len(t, backups, 1)
+}
+
+func TestDescribeBackup(t *testing.T) {
+	operator, out := newTestOperator(&Args{Namespace: "default", Output: "table"}, testBackup())
+	assert.NoError(t, operator.DescribeBackup("schedule"))
+	assert.Contains(t, out.String(), "Fleet:")
+	assert.Contains(t, out.String(), "VELERO BACKUP")
+	assert.Contains(t, out.String(), "member1-backup-default-schedule")
+	assert.Contains(t, out.String(), "Completed")
+	assert.Contains(t, out.String(), "0/10")
+
+	out.Reset()
+	operator.args.Output = "yaml"
+	assert.NoError(t, operator.DescribeBackup("schedule"))
+	backup := &backupapi.Backup{}
+	assert.NoError(t, yaml.Unmarshal(out.Bytes(), backup))
+	assert.Equal(t, testBackup().Status, backup.Status)
+
+	assert.Error(t, operator.DescribeBackup("not-found"))
+}
+
+func TestDescribeMigrate(t *testing.T) {
+	migrate := &backupapi.Migrate{
+		ObjectMeta: metav1.ObjectMeta{Name: "select-labels", Namespace: "default"},
+		Spec: backupapi.MigrateSpec{
+			SourceCluster: backupapi.MigrateDestination{Destination: backupapi.Destination{
+				Fleet:    "quickstart",
+				Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member1"}},
+			}},
+			TargetClusters: backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "production"}, Namespace: "prod"},
+		},
+		Status: backupapi.MigrateStatus{
+			Phase: backupapi.MigratePhaseRestoreInProgress,
+			SourceClusterStatus: &backupapi.BackupDetails{
+				ClusterName:           "member1",
+				BackupNameInCluster:   "member1-migrate-default-select-labels",
+				BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+			},
+			TargetClustersStatus: []*backupapi.RestoreDetails{
+				{
+					ClusterName:            "member2",
+					RestoreNameInCluster:   "member2-migrate-default-select-labels",
+					RestoreStatusInCluster: &velerov1.RestoreStatus{Phase: velerov1.RestorePhaseInProgress},
+				},
+			},
+		},
+	}
+
+	operator, out := newTestOperator(&Args{Namespace: "default", Output: "table"}, migrate)
+	assert.NoError(t, operator.DescribeMigrate("select-labels"))
+	assert.Contains(t, out.String(), "quickstart(member1)")
+	assert.Contains(t, out.String(), "prod/production")
+	assert.Contains(t, out.String(), "member1-migrate-default-select-labels")
+	assert.Contains(t, out.String(), "member2-migrate-default-select-labels")
+	assert.Contains(t, out.String(), "InProgress")
+}
+
+func TestValidateOutput(t *testing.T) {
+	for _, format := range []string{"table", "wide", "json", "yaml", "JSON"} {
+		assert.NoError(t, ValidateOutput(format))
+	}
+	assert.Error(t, ValidateOutput("xml"))
+}
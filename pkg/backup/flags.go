@@ -0,0 +1,54 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"kurator.dev/kurator/pkg/tool"
+)
+
+// AddNamespaceFlag adds the namespace flag, and the all-namespaces flag if allNamespaces is true.
+func AddNamespaceFlag(cmd *cobra.Command, args *Args, allNamespaces bool) {
+	cmd.PersistentFlags().StringVarP(&args.Namespace, "namespace", "n", "default", "specific namespace")
+	if allNamespaces {
+		cmd.PersistentFlags().BoolVarP(&args.AllNamespaces, "all-namespaces", "A", false, "If true, list the objects across all namespaces")
+	}
+}
+
+// AddOutputFlag adds the output flag with completion of the supported formats.
+func AddOutputFlag(cmd *cobra.Command, args *Args) {
+	cmd.PersistentFlags().StringVarP(&args.Output, "output", "o", tool.Table.String(), fmt.Sprintf("Output format. (-o|--output=)%v", tool.Formats()))
+	if err := cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return tool.Formats(), cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		logrus.Warn(err)
+	}
+}
+
+// AddFilterFlags adds the flags to build the resource filter.
+func AddFilterFlags(cmd *cobra.Command, a *FilterArgs) {
+	f := cmd.PersistentFlags()
+	f.StringSliceVar(&a.IncludedNamespaces, "include-namespaces", nil, "namespaces to include, all namespaces if not set")
+	f.StringSliceVar(&a.ExcludedNamespaces, "exclude-namespaces", nil, "namespaces to exclude")
+	f.StringSliceVar(&a.IncludedResources, "include-resources", nil, "resources to include, e.g. deployments,configmaps")
+	f.StringSliceVar(&a.ExcludedResources, "exclude-resources", nil, "resources to exclude")
+	f.StringVarP(&a.Selector, "selector", "l", "", "only include resources matching this label selector, e.g. app=nginx")
+}
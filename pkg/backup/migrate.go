@@ -0,0 +1,126 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+// MigrateArgs holds the arguments for creating a migrate.
+type MigrateArgs struct {
+	FilterArgs
+	SourceFleet     string
+	SourceNamespace string
+	SourceCluster   string // Source cluster in the form of <kind>/<name>.
+	TargetFleet     string
+	TargetNamespace string
+	TargetClusters  []string // Target clusters in the form of <kind>/<name>, all clusters of the target fleet if not set.
+	Cutover         bool
+	DeleteSource    bool
+}
+
+// CreateMigrate creates a Migrate with the given name.
+func (o *Operator) CreateMigrate(name string, a *MigrateArgs) error {
+	migrate, err := buildMigrate(name, o.args.Namespace, a)
+	if err != nil {
+		return err
+	}
+
+	if err := o.client.Create(context.Background(), migrate); err != nil {
+		return fmt.Errorf("failed to create migrate %s/%s: %v", migrate.Namespace, migrate.Name, err)
+	}
+	_, err = fmt.Fprintf(o.out, "migrate %s/%s created\n", migrate.Namespace, migrate.Name)
+	return err
+}
+
+func buildMigrate(name, namespace string, a *MigrateArgs) (*backupapi.Migrate, error) {
+	if a.SourceCluster == "" {
+		return nil, fmt.Errorf("source cluster must be set")
+	}
+	source, err := buildDestination(a.SourceFleet, []string{a.SourceCluster})
+	if err != nil {
+		return nil, fmt.Errorf("invalid source: %v", err)
+	}
+	targetFleet := a.TargetFleet
+	if targetFleet == "" {
+		targetFleet = a.SourceFleet
+	}
+	target, err := buildDestination(targetFleet, a.TargetClusters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target: %v", err)
+	}
+	filter, err := buildResourceFilter(&a.FilterArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	migrate := &backupapi.Migrate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: backupapi.MigrateSpec{
+			SourceCluster:  backupapi.MigrateDestination{Destination: *source, Namespace: a.SourceNamespace},
+			TargetClusters: backupapi.MigrateDestination{Destination: *target, Namespace: a.TargetNamespace},
+		},
+	}
+	if filter != nil || a.Cutover {
+		migrate.Spec.Policy = &backupapi.MigratePolicy{ResourceFilter: filter}
+		if a.Cutover {
+			migrate.Spec.Policy.Cutover = &backupapi.MigrateCutover{DeleteSource: a.DeleteSource}
+		}
+	}
+	return migrate, nil
+}
+
+// ListMigrates prints the Migrates in the namespace, or across all namespaces.
+func (o *Operator) ListMigrates() error {
+	migrates := &backupapi.MigrateList{}
+	if err := o.client.List(context.Background(), migrates, o.listOptions()); err != nil {
+		return fmt.Errorf("failed to list migrates: %v", err)
+	}
+
+	return migrateListWriter(migrates.Items, o.now()).PrintObj(o.out, o.args.Output)
+}
+
+// DescribeMigrate prints the Migrate with the velero backup phase in the source cluster
+// and the velero restore phase in each target cluster.
+func (o *Operator) DescribeMigrate(name string) error {
+	migrate := &backupapi.Migrate{}
+	if err := o.client.Get(context.Background(), ctrlclient.ObjectKey{Namespace: o.args.Namespace, Name: name}, migrate); err != nil {
+		return fmt.Errorf("failed to get migrate %s/%s: %v", o.args.Namespace, name, err)
+	}
+
+	return migrateDescribeWriter(migrate).PrintObj(o.out, o.args.Output)
+}
+
+// DeleteMigrates deletes the Migrates.
+func (o *Operator) DeleteMigrates(names []string) error {
+	for _, name := range names {
+		migrate := &backupapi.Migrate{ObjectMeta: metav1.ObjectMeta{Namespace: o.args.Namespace, Name: name}}
+		if err := o.delete(migrate, "migrate"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uitable"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	"kurator.dev/kurator/pkg/tool"
+)
+
+// objectWriter writes the backup specific table columns of the objects, the other output formats are written by the tool package.
+type objectWriter struct {
+	// summary is the key value pairs printed before the table, it is only used by describe.
+	summary [][2]string
+	header  []string
+	rows    [][]string
+	// wideHeader and wideRows are appended to header and rows in wide output format.
+	wideHeader []string
+	wideRows   [][]string
+	// obj is the object printed in JSON and YAML output format.
+	obj interface{}
+}
+
+func (w *objectWriter) writeTable(out io.Writer, wide bool) error {
+	if len(w.summary) != 0 {
+		summary := uitable.New()
+		for _, kv := range w.summary {
+			summary.AddRow(kv[0]+":", kv[1])
+		}
+		if err := tool.EncodeTable(out, summary); err != nil {
+			return err
+		}
+		if len(w.rows) != 0 {
+			if _, err := out.Write([]byte("\n")); err != nil {
+				return err
+			}
+		}
+	}
+	if len(w.rows) == 0 {
+		return nil
+	}
+
+	table := uitable.New()
+	table.AddRow(toCells(w.header, w.wideHeader, wide)...)
+	for i, row := range w.rows {
+		var wideRow []string
+		if i < len(w.wideRows) {
+			wideRow = w.wideRows[i]
+		}
+		table.AddRow(toCells(row, wideRow, wide)...)
+	}
+
+	return tool.EncodeTable(out, table)
+}
+
+func toCells(row, wideRow []string, wide bool) []interface{} {
+	cells := make([]interface{}, 0, len(row)+len(wideRow))
+	for _, c := range row {
+		cells = append(cells, c)
+	}
+	if wide {
+		for _, c := range wideRow {
+			cells = append(cells, c)
+		}
+	}
+	return cells
+}
+
+func (w *objectWriter) PrintObj(out io.Writer, format string) error {
+	return tool.PrintObj(out, format, w.obj, w.writeTable)
+}
+
+// ValidateOutput checks the output format is one of tool.Formats.
+func ValidateOutput(format string) error {
+	for _, f := range tool.Formats() {
+		if strings.ToLower(format) == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q, must be one of %v", format, tool.Formats())
+}
+
+func backupListWriter(backups []backupapi.Backup, now time.Time) *objectWriter {
+	w := &objectWriter{
+		header:     []string{"NAMESPACE", "NAME", "FLEET", "SCHEDULE", "PHASE", "CLUSTERS", "AGE"},
+		wideHeader: []string{"ITEMS", "WARNINGS", "ERRORS", "DURATION"},
+		obj:        backups,
+	}
+	for _, b := range backups {
+		completed := 0
+		for _, detail := range b.Status.Details {
+			if detail.BackupStatusInCluster != nil && detail.BackupStatusInCluster.Phase == velerov1.BackupPhaseCompleted {
+				completed++
+			}
+		}
+		w.rows = append(w.rows, []string{
+			b.Namespace,
+			b.Name,
+			b.Spec.Destination.Fleet,
+			valueOrNone(b.Spec.Schedule),
+			valueOrNone(string(b.Status.Phase)),
+			fmt.Sprintf("%d/%d", completed, len(b.Status.Details)),
+			age(b.CreationTimestamp, now),
+		})
+		w.wideRows = append(w.wideRows, []string{
+			fmt.Sprintf("%d/%d", b.Status.ItemsBackedUp, b.Status.TotalItems),
+			fmt.Sprint(b.Status.Warnings),
+			fmt.Sprint(b.Status.Errors),
+			durationOrNone(b.Status.Duration),
+		})
+	}
+	return w
+}
+
+func backupDescribeWriter(b *backupapi.Backup) *objectWriter {
+	w := &objectWriter{
+		summary: [][2]string{
+			{"Name", b.Name},
+			{"Namespace", b.Namespace},
+			{"Fleet", b.Spec.Destination.Fleet},
+			{"Schedule", valueOrNone(b.Spec.Schedule)},
+			{"Phase", valueOrNone(string(b.Status.Phase))},
+			{"Items", fmt.Sprintf("%d/%d", b.Status.ItemsBackedUp, b.Status.TotalItems)},
+			{"Warnings", fmt.Sprint(b.Status.Warnings)},
+			{"Errors", fmt.Sprint(b.Status.Errors)},
+			{"Duration", durationOrNone(b.Status.Duration)},
+		},
+		obj: b,
+	}
+	w.header, w.wideHeader = clusterHeaders("VELERO BACKUP")
+	for _, detail := range b.Status.Details {
+		row, wideRow := backupDetailsRow(detail)
+		w.rows = append(w.rows, row)
+		w.wideRows = append(w.wideRows, wideRow)
+	}
+	return w
+}
+
+func clusterHeaders(veleroName string) ([]string, []string) {
+	return []string{"CLUSTER", "KIND", veleroName, "PHASE", "ITEMS", "WARNINGS", "ERRORS"}, []string{"STARTED", "COMPLETED"}
+}
+
+func backupDetailsRow(detail *backupapi.BackupDetails) ([]string, []string) {
+	row := []string{detail.ClusterName, detail.ClusterKind, detail.BackupNameInCluster, "<none>", "0/0", "0", "0"}
+	wideRow := []string{"<none>", "<none>"}
+
+	status := detail.BackupStatusInCluster
+	if status == nil {
+		return row, wideRow
+	}
+	row[3] = valueOrNone(string(status.Phase))
+	if status.Progress != nil {
+		row[4] = fmt.Sprintf("%d/%d", status.Progress.ItemsBackedUp, status.Progress.TotalItems)
+	}
+	row[5] = fmt.Sprint(status.Warnings)
+	row[6] = fmt.Sprint(status.Errors)
+	wideRow[0] = timeOrNone(status.StartTimestamp)
+	wideRow[1] = timeOrNone(status.CompletionTimestamp)
+	return row, wideRow
+}
+
+func restoreListWriter(restores []backupapi.Restore, now time.Time) *objectWriter {
+	w := &objectWriter{
+		header:     []string{"NAMESPACE", "NAME", "BACKUP", "PHASE", "CLUSTERS", "AGE"},
+		wideHeader: []string{"FLEET"},
+		obj:        restores,
+	}
+	for _, r := range restores {
+		fleet := "<backup>"
+		if r.Spec.Destination != nil {
+			fleet = r.Spec.Destination.Fleet
+		}
+		w.rows = append(w.rows, []string{
+			r.Namespace,
+			r.Name,
+			r.Spec.BackupName,
+			valueOrNone(r.Status.Phase),
+			fmt.Sprintf("%d/%d", completedRestores(r.Status.Details), len(r.Status.Details)),
+			age(r.CreationTimestamp, now),
+		})
+		w.wideRows = append(w.wideRows, []string{fleet})
+	}
+	return w
+}
+
+func restoreDescribeWriter(r *backupapi.Restore) *objectWriter {
+	w := &objectWriter{
+		summary: [][2]string{
+			{"Name", r.Name},
+			{"Namespace", r.Namespace},
+			{"Backup", r.Spec.BackupName},
+			{"Phase", valueOrNone(r.Status.Phase)},
+		},
+		obj: r,
+	}
+	w.header, w.wideHeader = clusterHeaders("VELERO RESTORE")
+	for _, detail := range r.Status.Details {
+		row, wideRow := restoreDetailsRow(detail)
+		w.rows = append(w.rows, row)
+		w.wideRows = append(w.wideRows, wideRow)
+	}
+	return w
+}
+
+func restoreDetailsRow(detail *backupapi.RestoreDetails) ([]string, []string) {
+	row := []string{detail.ClusterName, detail.ClusterKind, detail.RestoreNameInCluster, "<none>", "0/0", "0", "0"}
+	wideRow := []string{"<none>", "<none>"}
+
+	status := detail.RestoreStatusInCluster
+	if status == nil {
+		return row, wideRow
+	}
+	row[3] = valueOrNone(string(status.Phase))
+	if status.Progress != nil {
+		row[4] = fmt.Sprintf("%d/%d", status.Progress.ItemsRestored, status.Progress.TotalItems)
+	}
+	row[5] = fmt.Sprint(status.Warnings)
+	row[6] = fmt.Sprint(status.Errors)
+	wideRow[0] = timeOrNone(status.StartTimestamp)
+	wideRow[1] = timeOrNone(status.CompletionTimestamp)
+	return row, wideRow
+}
+
+func completedRestores(details []*backupapi.RestoreDetails) int {
+	completed := 0
+	for _, detail := range details {
+		if detail.RestoreStatusInCluster != nil && detail.RestoreStatusInCluster.Phase == velerov1.RestorePhaseCompleted {
+			completed++
+		}
+	}
+	return completed
+}
+
+func migrateListWriter(migrates []backupapi.Migrate, now time.Time) *objectWriter {
+	w := &objectWriter{
+		header:     []string{"NAMESPACE", "NAME", "SOURCE", "TARGET", "PHASE", "CLUSTERS", "AGE"},
+		wideHeader: []string{"CUTOVER"},
+		obj:        migrates,
+	}
+	for _, m := range migrates {
+		cutover := "false"
+		if m.Spec.Policy != nil && m.Spec.Policy.Cutover != nil {
+			cutover = "true"
+		}
+		w.rows = append(w.rows, []string{
+			m.Namespace,
+			m.Name,
+			migrateDestinationString(m.Spec.SourceCluster),
+			migrateDestinationString(m.Spec.TargetClusters),
+			valueOrNone(string(m.Status.Phase)),
+			fmt.Sprintf("%d/%d", completedRestores(m.Status.TargetClustersStatus), len(m.Status.TargetClustersStatus)),
+			age(m.CreationTimestamp, now),
+		})
+		w.wideRows = append(w.wideRows, []string{cutover})
+	}
+	return w
+}
+
+func migrateDescribeWriter(m *backupapi.Migrate) *objectWriter {
+	w := &objectWriter{
+		summary: [][2]string{
+			{"Name", m.Name},
+			{"Namespace", m.Namespace},
+			{"Source", migrateDestinationString(m.Spec.SourceCluster)},
+			{"Target", migrateDestinationString(m.Spec.TargetClusters)},
+			{"Phase", valueOrNone(string(m.Status.Phase))},
+		},
+		obj: m,
+	}
+	if m.Status.CutoverStatus != nil && m.Status.CutoverStatus.Message != "" {
+		w.summary = append(w.summary, [2]string{"Message", m.Status.CutoverStatus.Message})
+	}
+
+	// the source backup is listed together with the target restores, the velero name tells them apart
+	w.header, w.wideHeader = clusterHeaders("VELERO BACKUP/RESTORE")
+	if m.Status.SourceClusterStatus != nil {
+		row, wideRow := backupDetailsRow(m.Status.SourceClusterStatus)
+		w.rows = append(w.rows, row)
+		w.wideRows = append(w.wideRows, wideRow)
+	}
+	for _, detail := range m.Status.TargetClustersStatus {
+		row, wideRow := restoreDetailsRow(detail)
+		w.rows = append(w.rows, row)
+		w.wideRows = append(w.wideRows, wideRow)
+	}
+	return w
+}
+
+func migrateDestinationString(dest backupapi.MigrateDestination) string {
+	s := dest.Fleet
+	if dest.Namespace != "" {
+		s = dest.Namespace + "/" + s
+	}
+	if len(dest.Clusters) == 0 {
+		return s
+	}
+
+	clusters := make([]string, 0, len(dest.Clusters))
+	for _, cluster := range dest.Clusters {
+		clusters = append(clusters, cluster.Name)
+	}
+	return s + "(" + strings.Join(clusters, ",") + ")"
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func timeOrNone(t *metav1.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func durationOrNone(d *metav1.Duration) string {
+	if d == nil {
+		return "<none>"
+	}
+	return d.Duration.String()
+}
+
+func age(t metav1.Time, now time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now.Sub(t.Time))
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+// RestoreArgs holds the arguments for creating a restore.
+type RestoreArgs struct {
+	FilterArgs
+	FromBackup string
+	// Fleet and Clusters restore a subset of the clusters of the backup, all clusters of the backup if not set.
+	Fleet            string
+	Clusters         []string
+	NamespaceMapping map[string]string
+}
+
+// CreateRestore creates a Restore with the given name from the backup.
+func (o *Operator) CreateRestore(name string, a *RestoreArgs) error {
+	restore, err := buildRestore(name, o.args.Namespace, a)
+	if err != nil {
+		return err
+	}
+
+	if err := o.client.Create(context.Background(), restore); err != nil {
+		return fmt.Errorf("failed to create restore %s/%s: %v", restore.Namespace, restore.Name, err)
+	}
+	_, err = fmt.Fprintf(o.out, "restore %s/%s created\n", restore.Namespace, restore.Name)
+	return err
+}
+
+func buildRestore(name, namespace string, a *RestoreArgs) (*backupapi.Restore, error) {
+	if a.FromBackup == "" {
+		return nil, fmt.Errorf("backup to restore from must be set")
+	}
+	filter, err := buildResourceFilter(&a.FilterArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	restore := &backupapi.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: backupapi.RestoreSpec{
+			BackupName: a.FromBackup,
+		},
+	}
+	if a.Fleet != "" || len(a.Clusters) != 0 {
+		destination, err := buildDestination(a.Fleet, a.Clusters)
+		if err != nil {
+			return nil, err
+		}
+		restore.Spec.Destination = destination
+	}
+	if filter != nil || len(a.NamespaceMapping) != 0 {
+		restore.Spec.Policy = &backupapi.RestorePolicy{
+			ResourceFilter:   filter,
+			NamespaceMapping: a.NamespaceMapping,
+		}
+	}
+	return restore, nil
+}
+
+// ListRestores prints the Restores in the namespace, or across all namespaces.
+func (o *Operator) ListRestores() error {
+	restores := &backupapi.RestoreList{}
+	if err := o.client.List(context.Background(), restores, o.listOptions()); err != nil {
+		return fmt.Errorf("failed to list restores: %v", err)
+	}
+
+	return restoreListWriter(restores.Items, o.now()).PrintObj(o.out, o.args.Output)
+}
+
+// DescribeRestore prints the Restore with the velero restore phase in each cluster.
+func (o *Operator) DescribeRestore(name string) error {
+	restore := &backupapi.Restore{}
+	if err := o.client.Get(context.Background(), ctrlclient.ObjectKey{Namespace: o.args.Namespace, Name: name}, restore); err != nil {
+		return fmt.Errorf("failed to get restore %s/%s: %v", o.args.Namespace, name, err)
+	}
+
+	return restoreDescribeWriter(restore).PrintObj(o.out, o.args.Output)
+}
+
+// DeleteRestores deletes the Restores.
+func (o *Operator) DeleteRestores(names []string) error {
+	for _, name := range names {
+		restore := &backupapi.Restore{ObjectMeta: metav1.ObjectMeta{Namespace: o.args.Namespace, Name: name}}
+		if err := o.delete(restore, "restore"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
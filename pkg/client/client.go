@@ -43,6 +43,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
 )
 
 type Client struct {
@@ -52,7 +54,7 @@ type Client struct {
 
 	karmada karmadaclientset.Interface
 	prom    promclient.Interface
-	// it currently only support k8s core API, tekton API and velero API, because only these schemes are registered
+	// it currently only support k8s core API, tekton API, velero API and kurator backup API, because only these schemes are registered
 	ctrlRuntimeClient client.Client
 }
 
@@ -91,6 +93,9 @@ func NewClient(rest genericclioptions.RESTClientGetter) (*Client, error) {
 	if err := ingressv1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("failed to add ingress api to scheme: %v", err)
 	}
+	if err := backupapi.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add backup api to scheme: %v", err)
+	}
 	// create controller-runtime client with scheme
 	ctrlRuntimeClient, err := client.New(c, client.Options{Scheme: scheme})
 	if err != nil {
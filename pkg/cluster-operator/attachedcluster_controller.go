@@ -19,6 +19,7 @@ package clusteroperator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +28,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/version"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +41,11 @@ import (
 	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
 )
 
+const (
+	defaultProbeInterval = 30 * time.Second
+	probeTimeout         = 10 * time.Second
+)
+
 // AttachedClusterController reconciles a AttachedCluster object
 type AttachedClusterController struct {
 	client.Client
@@ -84,28 +93,164 @@ func (a *AttachedClusterController) Reconcile(ctx context.Context, req ctrl.Requ
 func (a *AttachedClusterController) reconcile(ctx context.Context, attachedCluster *clusterv1alpha1.AttachedCluster) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	attachedCluster.Status.Ready = false
+	// The discovered details are only reported when the current probe discovers them,
+	// so that a failed probe does not keep reporting the details of a previous probe as current.
+	resetProbedStatus(attachedCluster)
+
+	// Always probe the cluster periodically, so that the status reflects the latest health of the cluster.
+	result := ctrl.Result{RequeueAfter: probeInterval(attachedCluster)}
+	defer func() {
+		conditions.SetSummary(attachedCluster,
+			conditions.WithConditions(
+				clusterv1alpha1.ReachableCondition,
+				clusterv1alpha1.AuthenticatedCondition,
+				clusterv1alpha1.VersionSupportedCondition,
+			),
+		)
+	}()
 
 	var secret corev1.Secret
 	secretKey := types.NamespacedName{Name: attachedCluster.GetSecretName(), Namespace: attachedCluster.Namespace}
 
 	if err := a.Get(ctx, secretKey, &secret); err != nil {
 		log.Error(err, "failed to get attached cluster secret")
-		return ctrl.Result{}, err
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		conditions.MarkFalse(attachedCluster, clusterv1alpha1.ReachableCondition, clusterv1alpha1.InvalidKubeconfigReason,
+			capiv1.ConditionSeverityError, "secret %s not found", secretKey)
+		markNotProbed(attachedCluster, clusterv1alpha1.InvalidKubeconfigReason,
+			clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.VersionSupportedCondition)
+		return result, nil
 	}
 
 	attachedClusterConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[attachedCluster.GetSecretKey()])
 	if err != nil {
-		log.Error(err, "build restconfig for controlplane failed")
-		return ctrl.Result{}, fmt.Errorf("build restconfig for controlplane failed %v", err)
+		log.Error(err, "build restconfig for attached cluster failed")
+		conditions.MarkFalse(attachedCluster, clusterv1alpha1.ReachableCondition, clusterv1alpha1.InvalidKubeconfigReason,
+			capiv1.ConditionSeverityError, err.Error())
+		markNotProbed(attachedCluster, clusterv1alpha1.InvalidKubeconfigReason,
+			clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.VersionSupportedCondition)
+		return result, nil
+	}
+	attachedClusterConfig.Timeout = probeTimeout
+	attachedClusterKubeClient, err := kubeclient.NewForConfig(attachedClusterConfig)
+	if err != nil {
+		log.Error(err, "build client for attached cluster failed")
+		conditions.MarkFalse(attachedCluster, clusterv1alpha1.ReachableCondition, clusterv1alpha1.InvalidKubeconfigReason,
+			capiv1.ConditionSeverityError, err.Error())
+		markNotProbed(attachedCluster, clusterv1alpha1.InvalidKubeconfigReason,
+			clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.VersionSupportedCondition)
+		return result, nil
+	}
+
+	serverVersion, err := attachedClusterKubeClient.Discovery().ServerVersion()
+	if err != nil {
+		log.Error(err, "failed to get attached cluster version")
+		markProbeFailed(attachedCluster, err)
+		return result, nil
 	}
-	controlPlaneKubeClient := kubeclient.NewForConfigOrDie(attachedClusterConfig)
+	conditions.MarkTrue(attachedCluster, clusterv1alpha1.ReachableCondition)
+	attachedCluster.Status.KubernetesVersion = serverVersion.GitVersion
 
 	// If this NamespaceSystem cannot be obtained, the cluster will not be ready for registration with Karmada Fleet.
-	if _, err := controlPlaneKubeClient.CoreV1().Namespaces().Get(context.TODO(), metav1.NamespaceSystem, metav1.GetOptions{}); err != nil {
+	if _, err := attachedClusterKubeClient.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{}); err != nil {
 		log.Error(err, "failed to get attached cluster id")
-		return ctrl.Result{}, err
+		markProbeFailed(attachedCluster, err)
+		return result, nil
+	}
+	conditions.MarkTrue(attachedCluster, clusterv1alpha1.AuthenticatedCondition)
+
+	if err := checkVersionSupported(serverVersion.GitVersion); err != nil {
+		conditions.MarkFalse(attachedCluster, clusterv1alpha1.VersionSupportedCondition, clusterv1alpha1.VersionUnsupportedReason,
+			capiv1.ConditionSeverityError, err.Error())
+		return result, nil
 	}
+	conditions.MarkTrue(attachedCluster, clusterv1alpha1.VersionSupportedCondition)
+
+	// The node summary is informative, a failure to collect it does not make the cluster unready.
+	nodes, err := attachedClusterKubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error(err, "failed to list nodes of attached cluster")
+	} else {
+		attachedCluster.Status.NodeCount = int32(len(nodes.Items))
+		attachedCluster.Status.Capacity, attachedCluster.Status.Allocatable = summarizeNodeResources(nodes.Items)
+	}
+
 	attachedCluster.Status.Ready = true
 
-	return ctrl.Result{}, nil
+	return result, nil
+}
+
+// resetProbedStatus clears the details of the attached cluster discovered by the previous probe.
+func resetProbedStatus(attachedCluster *clusterv1alpha1.AttachedCluster) {
+	attachedCluster.Status.KubernetesVersion = ""
+	attachedCluster.Status.NodeCount = 0
+	attachedCluster.Status.Capacity = nil
+	attachedCluster.Status.Allocatable = nil
+}
+
+// markProbeFailed records the failure of probing the attached cluster,
+// an unauthorized or forbidden response means the cluster is reachable but the credential is rejected.
+func markProbeFailed(attachedCluster *clusterv1alpha1.AttachedCluster, err error) {
+	if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+		conditions.MarkTrue(attachedCluster, clusterv1alpha1.ReachableCondition)
+		conditions.MarkFalse(attachedCluster, clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.AuthenticationFailedReason,
+			capiv1.ConditionSeverityError, err.Error())
+		markNotProbed(attachedCluster, clusterv1alpha1.AuthenticationFailedReason, clusterv1alpha1.VersionSupportedCondition)
+		return
+	}
+
+	conditions.MarkFalse(attachedCluster, clusterv1alpha1.ReachableCondition, clusterv1alpha1.UnreachableReason,
+		capiv1.ConditionSeverityError, err.Error())
+	markNotProbed(attachedCluster, clusterv1alpha1.UnreachableReason,
+		clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.VersionSupportedCondition)
+}
+
+// markNotProbed resets the conditions which are not probed because of a failed earlier probe step to unknown,
+// so that they do not keep reporting the result of a previous probe.
+func markNotProbed(attachedCluster *clusterv1alpha1.AttachedCluster, reason string, conditionTypes ...capiv1.ConditionType) {
+	for _, t := range conditionTypes {
+		conditions.MarkUnknown(attachedCluster, t, reason, "not probed since the earlier probe failed")
+	}
+}
+
+func checkVersionSupported(gitVersion string) error {
+	v, err := version.ParseGeneric(gitVersion)
+	if err != nil {
+		return fmt.Errorf("invalid kubernetes version %q: %v", gitVersion, err)
+	}
+
+	if v.LessThan(version.MustParseGeneric(clusterv1alpha1.MinSupportedKubernetesVersion)) {
+		return fmt.Errorf("kubernetes version %s is lower than the minimum supported version %s", gitVersion, clusterv1alpha1.MinSupportedKubernetesVersion)
+	}
+	return nil
+}
+
+func summarizeNodeResources(nodes []corev1.Node) (corev1.ResourceList, corev1.ResourceList) {
+	capacity := corev1.ResourceList{}
+	allocatable := corev1.ResourceList{}
+	for _, node := range nodes {
+		addResourceList(capacity, node.Status.Capacity)
+		addResourceList(allocatable, node.Status.Allocatable)
+	}
+	return capacity, allocatable
+}
+
+func addResourceList(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if value, ok := total[name]; ok {
+			value.Add(quantity)
+			total[name] = value
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func probeInterval(attachedCluster *clusterv1alpha1.AttachedCluster) time.Duration {
+	if attachedCluster.Spec.ProbeInterval != nil && attachedCluster.Spec.ProbeInterval.Duration > 0 {
+		return attachedCluster.Spec.ProbeInterval.Duration
+	}
+	return defaultProbeInterval
 }
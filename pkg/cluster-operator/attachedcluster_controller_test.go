@@ -0,0 +1,153 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteroperator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+)
+
+func TestCheckVersionSupported(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		expectErr bool
+	}{
+		{
+			name:    "supported version",
+			version: "v1.25.3",
+		},
+		{
+			name:    "supported version with build metadata",
+			version: "v1.27.8+k3s1",
+		},
+		{
+			name:      "unsupported version",
+			version:   "v1.19.16",
+			expectErr: true,
+		},
+		{
+			name:      "invalid version",
+			version:   "unknown",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersionSupported(tt.version)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMarkProbeFailedResetsStaleConditions(t *testing.T) {
+	attachedCluster := &clusterv1alpha1.AttachedCluster{}
+	conditions.MarkTrue(attachedCluster, clusterv1alpha1.ReachableCondition)
+	conditions.MarkFalse(attachedCluster, clusterv1alpha1.AuthenticatedCondition, clusterv1alpha1.AuthenticationFailedReason,
+		capiv1.ConditionSeverityError, "unauthorized")
+	conditions.MarkFalse(attachedCluster, clusterv1alpha1.VersionSupportedCondition, clusterv1alpha1.VersionUnsupportedReason,
+		capiv1.ConditionSeverityError, "unsupported")
+
+	markProbeFailed(attachedCluster, errors.New("connection refused"))
+
+	assert.True(t, conditions.IsFalse(attachedCluster, clusterv1alpha1.ReachableCondition))
+	assert.True(t, conditions.IsUnknown(attachedCluster, clusterv1alpha1.AuthenticatedCondition))
+	assert.True(t, conditions.IsUnknown(attachedCluster, clusterv1alpha1.VersionSupportedCondition))
+}
+
+func TestReconcileResetsStaleProbedStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	a := &AttachedClusterController{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+
+	// the status reported by a previous successful probe, while the kubeconfig secret has been deleted since
+	attachedCluster := &clusterv1alpha1.AttachedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "member1", Namespace: "default"},
+		Spec:       clusterv1alpha1.AttachedClusterSpec{Kubeconfig: clusterv1alpha1.SecretKeyRef{Name: "member1", Key: "kubeconfig"}},
+		Status: clusterv1alpha1.AttachedClusterStatus{
+			Ready:             true,
+			KubernetesVersion: "v1.25.3",
+			NodeCount:         2,
+			Capacity:          corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Allocatable:       corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+		},
+	}
+
+	_, err := a.reconcile(context.Background(), attachedCluster)
+	assert.NoError(t, err)
+
+	assert.False(t, attachedCluster.Status.Ready)
+	assert.True(t, conditions.IsFalse(attachedCluster, clusterv1alpha1.ReachableCondition))
+	assert.Empty(t, attachedCluster.Status.KubernetesVersion)
+	assert.Zero(t, attachedCluster.Status.NodeCount)
+	assert.Nil(t, attachedCluster.Status.Capacity)
+	assert.Nil(t, attachedCluster.Status.Allocatable)
+}
+
+func TestSummarizeNodeResources(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3500m"),
+					corev1.ResourceMemory: resource.MustParse("7Gi"),
+				},
+			},
+		},
+		{
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("3Gi"),
+				},
+			},
+		},
+	}
+
+	capacity, allocatable := summarizeNodeResources(nodes)
+	assert.True(t, resource.MustParse("6").Equal(capacity[corev1.ResourceCPU]))
+	assert.True(t, resource.MustParse("12Gi").Equal(capacity[corev1.ResourceMemory]))
+	assert.True(t, resource.MustParse("5").Equal(allocatable[corev1.ResourceCPU]))
+	assert.True(t, resource.MustParse("10Gi").Equal(allocatable[corev1.ResourceMemory]))
+	// the resources of nodes should not be changed
+	assert.True(t, resource.MustParse("4").Equal(nodes[0].Status.Capacity[corev1.ResourceCPU]))
+}
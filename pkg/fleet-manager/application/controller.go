@@ -49,6 +49,7 @@ const (
 	HelmReleaseKind   = helmv2b1.HelmReleaseKind
 
 	ApplicationLabel     = "apps.kurator.dev/app-name"
+	PolicyAnnotation     = "apps.kurator.dev/policy-name"
 	ApplicationKind      = "Application"
 	ApplicationFinalizer = "apps.kurator.dev"
 )
@@ -197,6 +198,12 @@ func (a *ApplicationManager) reconcileApplicationResources(ctx context.Context,
 			return result, err
 		}
 	}
+	pruneRolloutStrategyStatus(app)
+
+	// Delete the resources of the clusters which leave the destination, and of the removed policies
+	if result, err := a.pruneOrphanedPolicyResources(ctx, app, fleet); err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -215,7 +222,7 @@ func (a *ApplicationManager) reconcileStatus(ctx context.Context, app *applicati
 	return
 }
 
-// reconcileSourceStatus reconciles the source status of the given application by fetching the status of the source resource (e.g. GitRepository, HelmRepository)
+// reconcileSourceStatus reconciles the source status of the given application by fetching the status of the source resource (e.g. GitRepository, HelmRepository, OCIRepository)
 func (a *ApplicationManager) reconcileSourceStatus(ctx context.Context, app *applicationapi.Application) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -256,6 +263,19 @@ func (a *ApplicationManager) reconcileSourceStatus(ctx context.Context, app *app
 			return nil
 		}
 		app.Status.SourceStatus.HelmRepoStatus = &currentResource.Status
+
+	case OCIRepoKind:
+		currentResource := &sourcev1beta2.OCIRepository{}
+		err := a.Client.Get(ctx, sourceKey, currentResource)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to get OCIRepository from the API server when reconciling status")
+			return err
+		}
+		// if not found, return directly. new created OCIRepository will be watched in subsequent loop
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		app.Status.SourceStatus.OCIRepoStatus = &currentResource.Status
 	}
 	return nil
 }
@@ -314,6 +334,10 @@ func (a *ApplicationManager) reconcileSyncStatus(ctx context.Context, app *appli
 	}
 
 	app.Status.SyncStatus = syncStatus
+
+	if _, err := a.reconcileAggregatedStatus(ctx, app, fleet, kustomizationList.Items, helmReleaseList.Items); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to aggregate application status")
+	}
 	return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
 }
 
@@ -52,6 +52,10 @@ func (a *ApplicationManager) syncPolicyResource(ctx context.Context, app *applic
 		if err != nil || result.RequeueAfter > 0 {
 			return result, err
 		}
+		if syncPolicy.RolloutStrategy != nil {
+			// deploy the clusters wave by wave, the rollout of each wave is handled there as well.
+			return a.syncPolicyResourceInWaves(ctx, app, fleet, syncPolicy, policyName, fleetClusterList)
+		}
 		// Iterate through all clusters, and create/update kustomization/helmRelease for each of them.
 		for _, currentFleetCluster := range fleetClusterList {
 			// fetch kubeconfig for each cluster.
@@ -83,12 +87,17 @@ func (a *ApplicationManager) syncPolicyResource(ctx context.Context, app *applic
 	return ctrl.Result{}, nil
 }
 
-// fetchFleetClusterList fetch fleet cluster list that recorded in fleet and matches the selector.
+// fetchFleetClusterList fetch fleet cluster list that belongs to the fleet and matches the selector.
 func (a *ApplicationManager) fetchFleetClusterList(ctx context.Context, fleet *fleetapi.Fleet, selector *applicationapi.ClusterSelector) ([]fleetmanager.ClusterInterface, ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	var fleetClusterList []fleetmanager.ClusterInterface
 
-	for _, cluster := range fleet.Spec.Clusters {
+	clusters, err := fleetmanager.FleetClusterRefs(ctx, a.Client, fleet)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+
+	for _, cluster := range clusters {
 		// cluster.kind cluster.name that recorded in fleet must be valid
 		kind := cluster.Kind
 		name := cluster.Name
@@ -166,16 +175,19 @@ func (a *ApplicationManager) handleSyncPolicyByKind(
 	kubeConfig *fluxmeta.KubeConfigReference,
 ) (ctrl.Result, error) {
 	var policyResourceName string
+	var cluster fleetmanager.ClusterInterface
 	if kubeConfig != nil && fleetCluster != nil {
-		policyResourceName = generatePolicyResourceName(policyName, (*fleetCluster).GetObject().GetObjectKind().GroupVersionKind().Kind, (*fleetCluster).GetObject().GetName())
+		cluster = *fleetCluster
+		policyResourceName = generatePolicyResourceName(policyName, cluster.GetObject().GetObjectKind().GroupVersionKind().Kind, cluster.GetObject().GetName())
 	} else {
 		policyResourceName = generatePolicyResourceName(policyName, currentClusterKind, currentClusterName)
 	}
 	// handle kustomization
 	if policyKind == KustomizationKind {
-		kustomization := syncPolicy.Kustomization
+		// substitute the variables of the cluster in the manifests
+		kustomization := renderKustomizationForCluster(syncPolicy.Kustomization, cluster)
 		// sync kustomization using the provided kubeconfig and source.
-		if result, err := a.syncKustomizationForCluster(ctx, app, kustomization, kubeConfig, policyResourceName); err != nil || result.RequeueAfter > 0 {
+		if result, err := a.syncKustomizationForCluster(ctx, app, kustomization, kubeConfig, policyName, policyResourceName); err != nil || result.RequeueAfter > 0 {
 			return result, err
 		}
 		return ctrl.Result{}, nil
@@ -183,9 +195,13 @@ func (a *ApplicationManager) handleSyncPolicyByKind(
 
 	// handle helmRelease
 	if policyKind == HelmReleaseKind {
-		helmRelease := syncPolicy.Helm
+		// merge the values overlays of the cluster
+		helmRelease, err := renderHelmReleaseForCluster(syncPolicy.Helm, cluster)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to render helmRelease %s", policyResourceName)
+		}
 		// sync helmRelease using the provided kubeconfig and source.
-		if result, err := a.syncHelmReleaseForCluster(ctx, app, helmRelease, kubeConfig, policyResourceName); err != nil || result.RequeueAfter > 0 {
+		if result, err := a.syncHelmReleaseForCluster(ctx, app, helmRelease, kubeConfig, policyName, policyResourceName); err != nil || result.RequeueAfter > 0 {
 			return result, err
 		}
 		return ctrl.Result{}, nil
@@ -206,7 +222,7 @@ func (a *ApplicationManager) generateKubeConfig(fleetCluster fleetmanager.Cluste
 }
 
 // syncKustomizationForCluster ensures that the Kustomization object is in sync with Flux's requirements for the object.
-func (a *ApplicationManager) syncKustomizationForCluster(ctx context.Context, app *applicationapi.Application, kustomization *applicationapi.Kustomization, kubeConfig *fluxmeta.KubeConfigReference, kustomizationName string) (ctrl.Result, error) {
+func (a *ApplicationManager) syncKustomizationForCluster(ctx context.Context, app *applicationapi.Application, kustomization *applicationapi.Kustomization, kubeConfig *fluxmeta.KubeConfigReference, policyName, kustomizationName string) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Create a target Kustomization object with details extracted from the provided application's Kustomization spec
@@ -236,6 +252,20 @@ func (a *ApplicationManager) syncKustomizationForCluster(ctx context.Context, ap
 		Components:      kustomization.Components,
 	}
 
+	// If available, apply Kustomization PostBuild data to the target Kustomization
+	if kustomization.PostBuild != nil {
+		targetKustomizationSpec.PostBuild = &kustomizev1beta2.PostBuild{
+			Substitute: kustomization.PostBuild.Substitute,
+		}
+		for _, ref := range kustomization.PostBuild.SubstituteFrom {
+			targetKustomizationSpec.PostBuild.SubstituteFrom = append(targetKustomizationSpec.PostBuild.SubstituteFrom, kustomizev1beta2.SubstituteReference{
+				Kind:     ref.Kind,
+				Name:     ref.Name,
+				Optional: ref.Optional,
+			})
+		}
+	}
+
 	// If available, apply Kustomization CommonMetadata data to the target Kustomization
 	if kustomization.CommonMetadata != nil {
 		targetKustomizationSpec.CommonMetadata = &kustomizev1beta2.CommonMetadata{
@@ -247,6 +277,7 @@ func (a *ApplicationManager) syncKustomizationForCluster(ctx context.Context, ap
 	// sync Kustomization resource
 	syncResult, syncError := controllerutil.CreateOrUpdate(ctx, a.Client, targetKustomization, func() error {
 		targetKustomization.Spec = targetKustomizationSpec
+		setPolicyAnnotation(targetKustomization, policyName)
 		return nil
 	})
 
@@ -258,7 +289,7 @@ func (a *ApplicationManager) syncKustomizationForCluster(ctx context.Context, ap
 }
 
 // syncHelmReleaseForCluster ensures that the HelmRelease object is in sync with Flux's requirements for the object.
-func (a *ApplicationManager) syncHelmReleaseForCluster(ctx context.Context, app *applicationapi.Application, helmRelease *applicationapi.HelmRelease, kubeConfig *fluxmeta.KubeConfigReference, helmReleaseName string) (ctrl.Result, error) {
+func (a *ApplicationManager) syncHelmReleaseForCluster(ctx context.Context, app *applicationapi.Application, helmRelease *applicationapi.HelmRelease, kubeConfig *fluxmeta.KubeConfigReference, policyName, helmReleaseName string) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Create a target HelmRelease object with details extracted from the provided application's HelmRelease spec
@@ -311,6 +342,7 @@ func (a *ApplicationManager) syncHelmReleaseForCluster(ctx context.Context, app
 	// sync HelmRelease resource
 	syncResult, syncError := controllerutil.CreateOrUpdate(ctx, a.Client, targetHelmRelease, func() error {
 		targetHelmRelease.Spec = targetHelmReleaseSpec
+		setPolicyAnnotation(targetHelmRelease, policyName)
 		return nil
 	})
 
@@ -487,6 +519,16 @@ func doLabelsMatchSelector(labels map[string]string, selector *applicationapi.Cl
 	return true
 }
 
+// setPolicyAnnotation records the sync policy of the Kustomization or HelmRelease, which is used to clean up the rollout resources when it is orphaned.
+func setPolicyAnnotation(obj client.Object, policyName string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[PolicyAnnotation] = policyName
+	obj.SetAnnotations(annotations)
+}
+
 func buildObjectMetaWithApplication(name string, app *applicationapi.Application) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
 		Name:      name,
@@ -0,0 +1,85 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+func TestFetchFleetClusterList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clusterv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "member1", Namespace: "default",
+			Labels: map[string]string{"env": "prod", fleetmanager.FleetLabel: "fleet"}}},
+		&clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "member2", Namespace: "default",
+			Labels: map[string]string{"env": "prod", "region": "eu"}}},
+		// the cluster matches the selector but belongs to another fleet
+		&clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "member3", Namespace: "default",
+			Labels: map[string]string{"env": "prod", "region": "eu", fleetmanager.FleetLabel: "other"}}},
+	).Build()
+	a := &ApplicationManager{Client: c}
+
+	fleet := &fleetapi.Fleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+		Spec: fleetapi.FleetSpec{
+			ClusterSelector: &fleetapi.ClusterSelector{
+				LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		selector *applicationapi.ClusterSelector
+		expected []string
+	}{
+		{
+			name:     "all clusters of the fleet",
+			expected: []string{"member1", "member2"},
+		},
+		{
+			name:     "clusters matching the application selector",
+			selector: &applicationapi.ClusterSelector{MatchLabels: map[string]string{"region": "eu"}},
+			expected: []string{"member2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusters, _, err := a.fetchFleetClusterList(context.Background(), fleet, tc.selector)
+			assert.NoError(t, err)
+
+			var names []string
+			for _, cluster := range clusters {
+				names = append(names, cluster.GetObject().GetName())
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+// policyResource is a Kustomization or HelmRelease syncing a policy to a cluster.
+type policyResource struct {
+	policyName  string
+	kind        string
+	clusterKind string
+	clusterName string
+}
+
+// desiredPolicyResources returns the Kustomizations and HelmReleases desired by the application, keyed by name.
+func (a *ApplicationManager) desiredPolicyResources(ctx context.Context, app *applicationapi.Application, fleet *fleetapi.Fleet) (map[string]policyResource, ctrl.Result, error) {
+	desired := make(map[string]policyResource)
+	for index, policy := range app.Spec.SyncPolicies {
+		policyName := generatePolicyName(app, index)
+		policyKind := getSyncPolicyKind(policy)
+
+		if fleet == nil {
+			desired[generatePolicyResourceName(policyName, currentClusterKind, currentClusterName)] = policyResource{
+				policyName:  policyName,
+				kind:        policyKind,
+				clusterKind: currentClusterKind,
+				clusterName: currentClusterName,
+			}
+			continue
+		}
+
+		destination := getPolicyDestination(app, policy)
+		fleetClusterList, result, err := a.fetchFleetClusterList(ctx, fleet, destination.ClusterSelector)
+		if err != nil || result.RequeueAfter > 0 {
+			return nil, result, err
+		}
+		for _, cluster := range fleetClusterList {
+			kind := cluster.GetObject().GetObjectKind().GroupVersionKind().Kind
+			desired[generatePolicyResourceName(policyName, kind, cluster.GetObject().GetName())] = policyResource{
+				policyName:  policyName,
+				kind:        policyKind,
+				clusterKind: kind,
+				clusterName: cluster.GetObject().GetName(),
+			}
+		}
+	}
+	return desired, ctrl.Result{}, nil
+}
+
+// pruneOrphanedPolicyResources deletes the Kustomizations and HelmReleases of the application that are not desired anymore,
+// together with the rollout resources created by Kurator in the cluster they deployed to.
+// Nothing is deleted when the orphan policy of the application is Orphan.
+func (a *ApplicationManager) pruneOrphanedPolicyResources(ctx context.Context, app *applicationapi.Application, fleet *fleetapi.Fleet) (ctrl.Result, error) {
+	if app.Spec.OrphanPolicy == applicationapi.OrphanPolicyOrphan {
+		return ctrl.Result{}, nil
+	}
+
+	desired, result, err := a.desiredPolicyResources(ctx, app, fleet)
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
+
+	kustomizationList, err := a.getKustomizationList(ctx, app)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range kustomizationList.Items {
+		kustomization := &kustomizationList.Items[i]
+		if desired[kustomization.Name].kind == KustomizationKind {
+			continue
+		}
+		if err := a.deleteOrphanedPolicyResource(ctx, kustomization, kustomization.Spec.KubeConfig, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	helmReleaseList, err := a.getHelmReleaseList(ctx, app)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range helmReleaseList.Items {
+		helmRelease := &helmReleaseList.Items[i]
+		if desired[helmRelease.Name].kind == HelmReleaseKind {
+			continue
+		}
+		if err := a.deleteOrphanedPolicyResource(ctx, helmRelease, helmRelease.Spec.KubeConfig, desired); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteOrphanedPolicyResource deletes the orphaned Kustomization or HelmRelease.
+// The rollout resources are kept if the cluster is still desired by the policy, which happens when the policy changes from kustomization to helm or vice versa.
+func (a *ApplicationManager) deleteOrphanedPolicyResource(ctx context.Context, obj client.Object, kubeConfig *fluxmeta.KubeConfigReference, desired map[string]policyResource) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if _, exist := desired[obj.GetName()]; !exist {
+		// the resources created before the policy annotation was introduced don't record their policy,
+		// so the rollout resources of them are left to be cleaned up manually.
+		if policyName := obj.GetAnnotations()[PolicyAnnotation]; policyName != "" {
+			if err := a.deleteRolloutResourcesOfOrphan(ctx, obj.GetNamespace(), kubeConfig, policyName); err != nil {
+				return err
+			}
+		} else {
+			log.Info("orphaned policy resource has no policy annotation, skip cleaning up rollout resources", "name", obj.GetName())
+		}
+	}
+
+	if err := a.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete orphaned %s %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+	}
+	log.Info("orphaned policy resource deleted", "name", obj.GetName())
+	return nil
+}
+
+// deleteRolloutResourcesOfOrphan deletes the rollout resources of the policy in the cluster the orphaned resource deployed to.
+// The cleanup is skipped if the kubeconfig of the cluster is gone, e.g. the cluster has been deleted.
+func (a *ApplicationManager) deleteRolloutResourcesOfOrphan(ctx context.Context, namespace string, kubeConfig *fluxmeta.KubeConfigReference, policyName string) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	var (
+		kclient *fleetmanager.FleetCluster
+		err     error
+	)
+	if kubeConfig == nil {
+		kclient, err = a.hostCluster()
+	} else {
+		kclient, err = a.clusterOfKubeConfig(namespace, kubeConfig)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("kubeconfig of the orphaned cluster is not found, skip cleaning up rollout resources", "policy", policyName)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get client of the orphaned cluster")
+	}
+
+	return deleteRolloutResourcesOfPolicy(ctx, kclient.GetRuntimeClient(), policyName)
+}
+
+func (a *ApplicationManager) hostCluster() (*fleetmanager.FleetCluster, error) {
+	kclient, err := fleetmanager.WrapClient(a.Client)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to wrap client")
+	}
+	return &fleetmanager.FleetCluster{Client: kclient}, nil
+}
+
+func (a *ApplicationManager) clusterOfKubeConfig(namespace string, kubeConfig *fluxmeta.KubeConfigReference) (*fleetmanager.FleetCluster, error) {
+	kclient, err := fleetmanager.ClientForKubeConfigSecret(a.Client, namespace, kubeConfig.SecretRef.Name, kubeConfig.SecretRef.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &fleetmanager.FleetCluster{
+		Secret:    kubeConfig.SecretRef.Name,
+		SecretKey: kubeConfig.SecretRef.Key,
+		Client:    kclient,
+	}, nil
+}
+
+// deleteRolloutResourcesOfPolicy deletes the canaries created for the policy in the cluster,
+// and the ingress, testloader and metric templates created by Kurator for each of them.
+// The resources are found from the canaries, so they can be cleaned up even if the policy has been removed from the application.
+func deleteRolloutResourcesOfPolicy(ctx context.Context, kubeClient client.Client, policyName string) error {
+	canaryList := &flaggerv1b1.CanaryList{}
+	if err := kubeClient.List(ctx, canaryList); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			// flagger is not installed in the cluster
+			return nil
+		}
+		return errors.Wrapf(err, "failed to list canaries")
+	}
+
+	for i := range canaryList.Items {
+		canary := &canaryList.Items[i]
+		if canary.GetAnnotations()[RolloutIdentifier] != policyName {
+			continue
+		}
+
+		if canary.Spec.IngressRef != nil {
+			host, err := ingressHostOfService(ctx, kubeClient, canary.Namespace, canary.Spec.Service.Name)
+			if err != nil {
+				return err
+			}
+			rollout := &applicationapi.RolloutConfig{
+				Workload:    &applicationapi.CrossNamespaceObjectReference{Namespace: canary.Namespace, Name: canary.Spec.TargetRef.Name},
+				ServiceName: canary.Spec.Service.Name,
+				RolloutPolicy: &applicationapi.RolloutPolicy{
+					TrafficRouting: &applicationapi.TrafficRoutingConfig{Host: host},
+				},
+			}
+			if err := deleteIngressCreatedByKurator(ctx, kubeClient, rollout); err != nil {
+				return err
+			}
+		}
+
+		testloaderNamespaceName := types.NamespacedName{
+			Namespace: canary.Namespace,
+			Name:      canary.Spec.TargetRef.Name + "-testloader",
+		}
+		if err := deleteResourceCreatedByKurator(ctx, testloaderNamespaceName, kubeClient, &appsv1.Deployment{}); err != nil {
+			return errors.Wrapf(err, "failed to delete testloader deployment")
+		}
+		if err := deleteResourceCreatedByKurator(ctx, testloaderNamespaceName, kubeClient, &corev1.Service{}); err != nil {
+			return errors.Wrapf(err, "failed to delete testloader service")
+		}
+
+		var metricTemplates []types.NamespacedName
+		if canary.Spec.Analysis != nil {
+			for _, metric := range canary.Spec.Analysis.Metrics {
+				if metric.TemplateRef != nil {
+					metricTemplates = append(metricTemplates, types.NamespacedName{Namespace: metric.TemplateRef.Namespace, Name: metric.TemplateRef.Name})
+				}
+			}
+		}
+		if err := deleteMetricTemplateName(ctx, metricTemplates, kubeClient); err != nil {
+			return err
+		}
+
+		if err := kubeClient.Delete(ctx, canary); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete canary %s in %s", canary.Name, canary.Namespace)
+		}
+	}
+	return nil
+}
+
+// ingressHostOfService returns the host of the rule routing to the service in the ingress created by Kurator,
+// since the rollout policy which configured the host may have been removed from the application.
+func ingressHostOfService(ctx context.Context, kubeClient client.Client, namespace, serviceName string) (string, error) {
+	ingress := &ingressv1.Ingress{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ingressName}, ingress); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get ingress %s in %s", ingressName, namespace)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil && path.Backend.Service.Name == serviceName {
+				return rule.Host, nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"testing"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	helmv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1beta2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+)
+
+func newPruneTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = ingressv1.AddToScheme(scheme)
+	_ = kustomizev1beta2.AddToScheme(scheme)
+	_ = helmv2b1.AddToScheme(scheme)
+	_ = flaggerv1b1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newPolicyResourceMeta(name, policyName string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   "default",
+		Labels:      map[string]string{ApplicationLabel: "app"},
+		Annotations: map[string]string{PolicyAnnotation: policyName},
+	}
+}
+
+// orphanKubeConfig refers to a kubeconfig secret which does not exist, as the cluster has been deleted.
+var orphanKubeConfig = &fluxmeta.KubeConfigReference{SecretRef: fluxmeta.SecretKeyReference{Name: "deleted-cluster", Key: "kubeconfig"}}
+
+func TestPruneOrphanedPolicyResources(t *testing.T) {
+	app := &applicationapi.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: applicationapi.ApplicationSpec{
+			SyncPolicies: []*applicationapi.ApplicationSyncPolicy{
+				{Kustomization: &applicationapi.Kustomization{}},
+			},
+		},
+	}
+	desired := generatePolicyResourceName("app-0", currentClusterKind, currentClusterName)
+
+	cases := []struct {
+		name         string
+		orphanPolicy applicationapi.OrphanPolicy
+		pruned       bool
+	}{
+		{
+			name:   "default orphan policy",
+			pruned: true,
+		},
+		{
+			name:         "delete orphan policy",
+			orphanPolicy: applicationapi.OrphanPolicyDelete,
+			pruned:       true,
+		},
+		{
+			name:         "orphan orphan policy",
+			orphanPolicy: applicationapi.OrphanPolicyOrphan,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newPruneTestClient(
+				&kustomizev1beta2.Kustomization{ObjectMeta: newPolicyResourceMeta(desired, "app-0")},
+				// the policy app-1 has been removed
+				&kustomizev1beta2.Kustomization{
+					ObjectMeta: newPolicyResourceMeta("app-1-attachedcluster-member1", "app-1"),
+					Spec:       kustomizev1beta2.KustomizationSpec{KubeConfig: orphanKubeConfig},
+				},
+				// the policy app-0 has been changed from helm to kustomization
+				&helmv2b1.HelmRelease{ObjectMeta: newPolicyResourceMeta(desired, "app-0")},
+				// resources of other applications are never touched
+				&kustomizev1beta2.Kustomization{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{ApplicationLabel: "other"}}},
+			)
+			a := &ApplicationManager{Client: c}
+
+			app := app.DeepCopy()
+			app.Spec.OrphanPolicy = tc.orphanPolicy
+			_, err := a.pruneOrphanedPolicyResources(ctx, app, nil)
+			assert.NoError(t, err)
+
+			assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: desired}, &kustomizev1beta2.Kustomization{}))
+			assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "other"}, &kustomizev1beta2.Kustomization{}))
+
+			err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "app-1-attachedcluster-member1"}, &kustomizev1beta2.Kustomization{})
+			assert.Equal(t, tc.pruned, apierrors.IsNotFound(err))
+			err = c.Get(ctx, client.ObjectKey{Namespace: "default", Name: desired}, &helmv2b1.HelmRelease{})
+			assert.Equal(t, tc.pruned, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestDeleteRolloutResourcesOfPolicy(t *testing.T) {
+	ctx := context.Background()
+	annotations := map[string]string{RolloutIdentifier: "app-0"}
+	prefix := ingressv1.PathTypePrefix
+	ingressRule := func(host, service string) ingressv1.IngressRule {
+		return ingressv1.IngressRule{
+			Host: host,
+			IngressRuleValue: ingressv1.IngressRuleValue{
+				HTTP: &ingressv1.HTTPIngressRuleValue{
+					Paths: []ingressv1.HTTPIngressPath{{
+						PathType: &prefix,
+						Path:     "/",
+						Backend:  ingressv1.IngressBackend{Service: &ingressv1.IngressServiceBackend{Name: service}},
+					}},
+				},
+			},
+		}
+	}
+
+	c := newPruneTestClient(
+		&flaggerv1b1.Canary{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "test", Annotations: annotations},
+			Spec: flaggerv1b1.CanarySpec{
+				TargetRef:  flaggerv1b1.LocalObjectReference{Name: "podinfo"},
+				IngressRef: &flaggerv1b1.LocalObjectReference{Name: ingressName},
+				Service:    flaggerv1b1.CanaryService{Name: "podinfo-svc"},
+				Analysis: &flaggerv1b1.CanaryAnalysis{
+					Metrics: []flaggerv1b1.CanaryMetric{
+						{Name: "error-count", TemplateRef: &flaggerv1b1.CrossNamespaceObjectReference{Name: "error-count", Namespace: "test"}},
+					},
+				},
+			},
+		},
+		&flaggerv1b1.Canary{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test", Annotations: map[string]string{RolloutIdentifier: "app-1"}},
+		},
+		&ingressv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ingressName,
+				Namespace:   "test",
+				Labels:      map[string]string{ingressLabelKey: "podinfo-svc,other-svc"},
+				Annotations: annotations,
+			},
+			Spec: ingressv1.IngressSpec{
+				Rules: []ingressv1.IngressRule{ingressRule("podinfo.example.com", "podinfo-svc"), ingressRule("other.example.com", "other-svc")},
+			},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "podinfo-testloader", Namespace: "test", Annotations: annotations}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "podinfo-testloader", Namespace: "test", Annotations: annotations}},
+		&flaggerv1b1.MetricTemplate{ObjectMeta: metav1.ObjectMeta{Name: "error-count", Namespace: "test", Annotations: annotations}},
+	)
+
+	assert.NoError(t, deleteRolloutResourcesOfPolicy(ctx, c, "app-0"))
+
+	deleted := map[string]client.Object{
+		"podinfo":            &flaggerv1b1.Canary{},
+		"podinfo-testloader": &appsv1.Deployment{},
+		"error-count":        &flaggerv1b1.MetricTemplate{},
+	}
+	for name, obj := range deleted {
+		err := c.Get(ctx, client.ObjectKey{Namespace: "test", Name: name}, obj)
+		assert.True(t, apierrors.IsNotFound(err), "%T %s should be deleted", obj, name)
+	}
+	err := c.Get(ctx, client.ObjectKey{Namespace: "test", Name: "podinfo-testloader"}, &corev1.Service{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// the canary of other policy is kept
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "test", Name: "other"}, &flaggerv1b1.Canary{}))
+
+	// the ingress is still used by other canary
+	ingress := &ingressv1.Ingress{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "test", Name: ingressName}, ingress))
+	assert.Equal(t, "other-svc", ingress.Labels[ingressLabelKey])
+	assert.Equal(t, []ingressv1.IngressRule{ingressRule("other.example.com", "other-svc")}, ingress.Spec.Rules)
+}
@@ -154,7 +154,8 @@ func (a *ApplicationManager) syncRolloutPolicyForCluster(ctx context.Context,
 				return ctrl.Result{}, errors.Wrapf(err, "failed to operate ingress")
 			}
 			log.Info("sync nginx", "result:", result)
-
+		case fleetapi.GatewayAPI:
+			// The HTTPRoute is generated by flagger and attached to the gateways set by the user, nothing to prepare in the cluster.
 		default:
 			return ctrl.Result{}, errors.Errorf("unknown provider type %s", provider)
 		}
@@ -255,6 +256,7 @@ func (a *ApplicationManager) reconcileRolloutSyncStatus(ctx context.Context,
 			currentstatus := applicationapi.RolloutStatus{
 				ClusterName:            clusterKey.Name,
 				RolloutNameInCluster:   canaryNamespacedName.Name,
+				Strategy:               syncPolicy.Rollout.EffectiveStrategy(),
 				RolloutStatusInCluster: &canary.Status,
 			}
 			rolloutStatus[name] = &currentstatus
@@ -564,6 +566,8 @@ func renderCanary(rolloutPolicy applicationapi.RolloutConfig, canaryInCluster *f
 		}
 	case fleetapi.Kuma:
 		canaryInCluster.SetAnnotations(map[string]string{"kuma.io/mesh": "default"})
+	case fleetapi.GatewayAPI:
+		canaryInCluster.Spec.Provider = plugin.GatewayAPIMeshProvider
 	}
 	return canaryInCluster
 }
@@ -584,6 +588,9 @@ func renderCanaryService(rolloutPolicy applicationapi.RolloutConfig, service *co
 		canaryService.Retries = rolloutPolicy.RolloutPolicy.TrafficRouting.Retries
 		canaryService.Headers = rolloutPolicy.RolloutPolicy.TrafficRouting.Headers
 		canaryService.CorsPolicy = rolloutPolicy.RolloutPolicy.TrafficRouting.CorsPolicy
+	case fleetapi.GatewayAPI:
+		canaryService.GatewayRefs = rolloutPolicy.RolloutPolicy.TrafficRouting.GatewayRefs
+		canaryService.Hosts = rolloutPolicy.RolloutPolicy.TrafficRouting.Hosts
 	case fleetapi.Kuma:
 		annotations := &flaggerv1b1.CustomMetadata{Annotations: map[string]string{kumaAnnotation: rolloutPolicy.RolloutPolicy.TrafficRouting.Protocol}}
 		canaryService.Apex = annotations
@@ -628,19 +635,40 @@ func applyMetricTemplate(ctx context.Context, fleetClusterClient client.Client,
 	return nil
 }
 
+func renderCanaryWeights(canaryAnalysis *flaggerv1b1.CanaryAnalysis, canaryStrategy *applicationapi.CanaryConfig) {
+	if canaryStrategy == nil {
+		return
+	}
+	canaryAnalysis.MaxWeight = canaryStrategy.MaxWeight
+	canaryAnalysis.StepWeight = canaryStrategy.StepWeight
+	canaryAnalysis.StepWeights = canaryStrategy.StepWeights
+	canaryAnalysis.StepWeightPromotion = canaryStrategy.StepWeightPromotion
+}
+
 func renderCanaryAnalysis(rolloutPolicy applicationapi.RolloutConfig, clusterName string) *flaggerv1b1.CanaryAnalysis {
+	trafficRouting := rolloutPolicy.RolloutPolicy.TrafficRouting
 	canaryAnalysis := flaggerv1b1.CanaryAnalysis{
-		Iterations:      rolloutPolicy.RolloutPolicy.TrafficRouting.AnalysisTimes,
 		Threshold:       *rolloutPolicy.RolloutPolicy.TrafficAnalysis.CheckFailedTimes,
-		Match:           rolloutPolicy.RolloutPolicy.TrafficRouting.Match,
 		SessionAffinity: (*flaggerv1b1.SessionAffinity)(rolloutPolicy.RolloutPolicy.TrafficAnalysis.SessionAffinity),
 	}
 
-	if rolloutPolicy.RolloutPolicy.TrafficRouting.CanaryStrategy != nil {
-		canaryAnalysis.MaxWeight = rolloutPolicy.RolloutPolicy.TrafficRouting.CanaryStrategy.MaxWeight
-		canaryAnalysis.StepWeight = rolloutPolicy.RolloutPolicy.TrafficRouting.CanaryStrategy.StepWeight
-		canaryAnalysis.StepWeights = rolloutPolicy.RolloutPolicy.TrafficRouting.CanaryStrategy.StepWeights
-		canaryAnalysis.StepWeightPromotion = rolloutPolicy.RolloutPolicy.TrafficRouting.CanaryStrategy.StepWeightPromotion
+	// Flagger determines the release strategy by the fields of the analysis,
+	// so only the fields of the specified strategy are rendered.
+	switch rolloutPolicy.Strategy {
+	case applicationapi.BlueGreenReleaseStrategy:
+		canaryAnalysis.Iterations = trafficRouting.AnalysisTimes
+		canaryAnalysis.Mirror = trafficRouting.Mirror
+	case applicationapi.ABTestingReleaseStrategy:
+		canaryAnalysis.Iterations = trafficRouting.AnalysisTimes
+		canaryAnalysis.Match = trafficRouting.Match
+	case applicationapi.CanaryReleaseStrategy:
+		canaryAnalysis.Match = trafficRouting.Match
+		renderCanaryWeights(&canaryAnalysis, trafficRouting.CanaryStrategy)
+	default:
+		// The strategy is not specified, leave it to Flagger to infer.
+		canaryAnalysis.Iterations = trafficRouting.AnalysisTimes
+		canaryAnalysis.Match = trafficRouting.Match
+		renderCanaryWeights(&canaryAnalysis, trafficRouting.CanaryStrategy)
 	}
 
 	CheckInterval := fmt.Sprintf("%d", *rolloutPolicy.RolloutPolicy.TrafficAnalysis.CheckIntervalSeconds) + "s"
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	gatewayapiv1beta1 "github.com/fluxcd/flagger/pkg/apis/gatewayapi/v1beta1"
 	"github.com/fluxcd/flagger/pkg/apis/istio/common/v1alpha1"
 	istiov1alpha3 "github.com/fluxcd/flagger/pkg/apis/istio/v1alpha3"
 	corev1 "k8s.io/api/core/v1"
@@ -139,6 +140,20 @@ func generateRolloutPolicy(installPrivateTestloader *bool) applicationapi.Rollou
 	return rolloutPolicy
 }
 
+func generateGatewayAPIRolloutPolicy() applicationapi.RolloutConfig {
+	sign := true
+	rolloutPolicy := generateRolloutPolicy(&sign)
+	rolloutPolicy.TrafficRoutingProvider = fleetapi.GatewayAPI
+	namespace := gatewayapiv1beta1.Namespace("gateway-system")
+	rolloutPolicy.RolloutPolicy.TrafficRouting.GatewayRefs = []gatewayapiv1beta1.ParentReference{
+		{
+			Name:      "public-gateway",
+			Namespace: &namespace,
+		},
+	}
+	return rolloutPolicy
+}
+
 func generateRolloutPolicyWithCustomMetric() applicationapi.RolloutConfig {
 	timeout := 50
 	RolloutTimeoutSeconds := int32(50)
@@ -306,6 +321,31 @@ func Test_renderCanary(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "gateway api",
+			args: args{
+				rolloutPolicy: generateGatewayAPIRolloutPolicy(),
+			},
+			want: &flaggerv1b1.Canary{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "podinfo",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Canary",
+					APIVersion: "flagger.app/v1beta1",
+				},
+				Spec: flaggerv1b1.CanarySpec{
+					Provider: "gatewayapi:v1beta1",
+					TargetRef: flaggerv1b1.LocalObjectReference{
+						APIVersion: "appv1/deployment",
+						Kind:       "Deployment",
+						Name:       "podinfo",
+					},
+					ProgressDeadlineSeconds: &int32Time,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -319,6 +359,7 @@ func Test_renderCanary(t *testing.T) {
 func Test_renderCanaryService(t *testing.T) {
 	sign := true
 	rolloutPolicy := generateRolloutPolicy(&sign)
+	gatewayAPIRolloutPolicy := generateGatewayAPIRolloutPolicy()
 	type args struct {
 		rolloutPolicy applicationapi.RolloutConfig
 		service       *corev1.Service
@@ -367,6 +408,37 @@ func Test_renderCanaryService(t *testing.T) {
 				CorsPolicy: rolloutPolicy.RolloutPolicy.TrafficRouting.CorsPolicy,
 			},
 		},
+		{
+			name: "gateway api",
+			args: args{
+				rolloutPolicy: gatewayAPIRolloutPolicy,
+				service: &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "podinfo-service",
+					},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{
+							{
+								Protocol:   corev1.ProtocolTCP,
+								Port:       80,
+								TargetPort: intstr.FromInt(8080),
+							},
+						},
+					},
+				},
+			},
+			want: &flaggerv1b1.CanaryService{
+				Name:        "podinfo-service",
+				Port:        80,
+				Timeout:     "50s",
+				TargetPort:  intstr.FromInt(8080),
+				GatewayRefs: gatewayAPIRolloutPolicy.RolloutPolicy.TrafficRouting.GatewayRefs,
+				Hosts: []string{
+					"app.example.com",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -592,6 +664,116 @@ func Test_renderCanaryAnalysis(t *testing.T) {
 	}
 }
 
+func Test_renderCanaryAnalysisWithStrategy(t *testing.T) {
+	sign := true
+	tests := []struct {
+		name           string
+		strategy       applicationapi.ReleaseStrategy
+		mirror         bool
+		wantIterations int
+		wantMatch      bool
+		wantStepWeight int
+		wantMirror     bool
+	}{
+		{
+			name:           "canary",
+			strategy:       applicationapi.CanaryReleaseStrategy,
+			wantMatch:      true,
+			wantStepWeight: 10,
+		},
+		{
+			name:           "blue green with mirror",
+			strategy:       applicationapi.BlueGreenReleaseStrategy,
+			mirror:         true,
+			wantIterations: 5,
+			wantMirror:     true,
+		},
+		{
+			name:           "A/B testing",
+			strategy:       applicationapi.ABTestingReleaseStrategy,
+			mirror:         true,
+			wantIterations: 5,
+			wantMatch:      true,
+		},
+		{
+			name:           "strategy not set",
+			wantIterations: 5,
+			wantMatch:      true,
+			wantStepWeight: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rolloutPolicy := generateRolloutPolicy(&sign)
+			rolloutPolicy.Strategy = tt.strategy
+			rolloutPolicy.RolloutPolicy.TrafficRouting.Mirror = tt.mirror
+
+			got := renderCanaryAnalysis(rolloutPolicy, "kurator-member")
+			if got.Iterations != tt.wantIterations {
+				t.Errorf("renderCanaryAnalysis() iterations = %v, want %v", got.Iterations, tt.wantIterations)
+			}
+			if (len(got.Match) != 0) != tt.wantMatch {
+				t.Errorf("renderCanaryAnalysis() match = %v, want match %v", got.Match, tt.wantMatch)
+			}
+			if got.StepWeight != tt.wantStepWeight {
+				t.Errorf("renderCanaryAnalysis() stepWeight = %v, want %v", got.StepWeight, tt.wantStepWeight)
+			}
+			if got.Mirror != tt.wantMirror {
+				t.Errorf("renderCanaryAnalysis() mirror = %v, want %v", got.Mirror, tt.wantMirror)
+			}
+		})
+	}
+}
+
+func TestEffectiveStrategy(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       applicationapi.ReleaseStrategy
+		trafficRouting *applicationapi.TrafficRoutingConfig
+		want           applicationapi.ReleaseStrategy
+	}{
+		{
+			name:           "strategy set",
+			strategy:       applicationapi.BlueGreenReleaseStrategy,
+			trafficRouting: &applicationapi.TrafficRoutingConfig{CanaryStrategy: &applicationapi.CanaryConfig{StepWeight: 10}},
+			want:           applicationapi.BlueGreenReleaseStrategy,
+		},
+		{
+			name:           "canary strategy configured",
+			trafficRouting: &applicationapi.TrafficRoutingConfig{CanaryStrategy: &applicationapi.CanaryConfig{StepWeight: 10}},
+			want:           applicationapi.CanaryReleaseStrategy,
+		},
+		{
+			name: "analysis times and match configured",
+			trafficRouting: &applicationapi.TrafficRoutingConfig{
+				AnalysisTimes: 3,
+				Match:         []istiov1alpha3.HTTPMatchRequest{{Headers: map[string]v1alpha1.StringMatch{"x-canary": {Exact: "insider"}}}},
+			},
+			want: applicationapi.ABTestingReleaseStrategy,
+		},
+		{
+			name:           "only analysis times configured",
+			trafficRouting: &applicationapi.TrafficRoutingConfig{AnalysisTimes: 3},
+			want:           applicationapi.BlueGreenReleaseStrategy,
+		},
+		{
+			name: "traffic routing not set",
+			want: applicationapi.CanaryReleaseStrategy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := &applicationapi.RolloutConfig{
+				Strategy:      tt.strategy,
+				RolloutPolicy: &applicationapi.RolloutPolicy{TrafficRouting: tt.trafficRouting},
+			}
+			if got := rollout.EffectiveStrategy(); got != tt.want {
+				t.Errorf("EffectiveStrategy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_addLables(t *testing.T) {
 	type args struct {
 		obj   client.Object
@@ -0,0 +1,238 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	helmv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1beta2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+)
+
+// reconcileAggregatedStatus aggregates the status of the source and of the Kustomizations and HelmReleases in all the clusters
+// into the phase, summary and cluster status of the application.
+func (a *ApplicationManager) reconcileAggregatedStatus(ctx context.Context,
+	app *applicationapi.Application,
+	fleet *fleetapi.Fleet,
+	kustomizations []kustomizev1beta2.Kustomization,
+	helmReleases []helmv2b1.HelmRelease,
+) (ctrl.Result, error) {
+	desired, result, err := a.desiredPolicyResources(ctx, app, fleet)
+	if err != nil || result.RequeueAfter > 0 {
+		return result, err
+	}
+
+	aggregateApplicationStatus(app, desired, kustomizations, helmReleases)
+	return ctrl.Result{}, nil
+}
+
+// aggregateApplicationStatus sets the phase, summary and cluster status of the application from the desired policy resources.
+func aggregateApplicationStatus(app *applicationapi.Application,
+	desired map[string]policyResource,
+	kustomizations []kustomizev1beta2.Kustomization,
+	helmReleases []helmv2b1.HelmRelease,
+) {
+	sourceRevision, sourceFailed := sourceState(app.Status.SourceStatus)
+
+	kustomizationByName := make(map[string]*kustomizev1beta2.Kustomization, len(kustomizations))
+	for i := range kustomizations {
+		kustomizationByName[kustomizations[i].Name] = &kustomizations[i]
+	}
+	helmReleaseByName := make(map[string]*helmv2b1.HelmRelease, len(helmReleases))
+	for i := range helmReleases {
+		helmReleaseByName[helmReleases[i].Name] = &helmReleases[i]
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		clusterStatus []*applicationapi.ApplicationClusterStatus
+		failed        []string
+		suspended     int
+		inSync        int
+	)
+	clusterInSync := make(map[string]bool)
+	for _, name := range names {
+		resource := desired[name]
+		status := &applicationapi.ApplicationClusterStatus{
+			ClusterName: resource.clusterName,
+			ClusterKind: resource.clusterKind,
+			PolicyName:  resource.policyName,
+			Kind:        resource.kind,
+			Name:        name,
+		}
+
+		var resourceFailed bool
+		switch resource.kind {
+		case KustomizationKind:
+			resourceFailed = kustomizationClusterStatus(status, kustomizationByName[name], sourceRevision)
+		case HelmReleaseKind:
+			resourceFailed = helmReleaseClusterStatus(status, helmReleaseByName[name])
+		}
+		if resourceFailed {
+			failed = append(failed, fmt.Sprintf("%s in cluster %s", resource.policyName, resource.clusterName))
+		}
+		if status.Suspended {
+			suspended++
+		}
+		if status.InSync {
+			inSync++
+		}
+
+		clusterKey := resource.clusterKind + "/" + resource.clusterName
+		if synced, exist := clusterInSync[clusterKey]; !exist || synced {
+			clusterInSync[clusterKey] = status.InSync
+		}
+		clusterStatus = append(clusterStatus, status)
+	}
+
+	summary := &applicationapi.ApplicationSyncSummary{Clusters: len(clusterInSync)}
+	for _, synced := range clusterInSync {
+		if synced {
+			summary.InSync++
+		} else {
+			summary.OutOfDate++
+		}
+	}
+
+	app.Status.ClusterStatus = clusterStatus
+	app.Status.Summary = summary
+
+	switch {
+	case sourceFailed || len(failed) != 0 || rolloutFailed(app.Status):
+		app.Status.Phase = applicationapi.ApplicationDegraded
+	case len(clusterStatus) != 0 && suspended == len(clusterStatus):
+		app.Status.Phase = applicationapi.ApplicationSuspended
+	case len(clusterStatus) != 0 && inSync == len(clusterStatus):
+		app.Status.Phase = applicationapi.ApplicationHealthy
+	default:
+		app.Status.Phase = applicationapi.ApplicationProgressing
+	}
+}
+
+// kustomizationClusterStatus fills the cluster status from the Kustomization, and returns whether it failed.
+// The Kustomization is in sync when it is ready with the latest revision of the source.
+func kustomizationClusterStatus(status *applicationapi.ApplicationClusterStatus, kustomization *kustomizev1beta2.Kustomization, sourceRevision string) bool {
+	if kustomization == nil {
+		status.Message = "not created"
+		return false
+	}
+
+	ready, failed, message := fluxConditionsState(kustomization.Generation, kustomization.Status.ObservedGeneration, kustomization.Status.Conditions)
+	status.Ready = ready
+	status.Suspended = kustomization.Spec.Suspend
+	status.LastAppliedRevision = kustomization.Status.LastAppliedRevision
+	status.Message = message
+
+	latestRevision := sourceRevision
+	if latestRevision == "" {
+		latestRevision = kustomization.Status.LastAttemptedRevision
+	}
+	status.InSync = ready && status.LastAppliedRevision != "" && status.LastAppliedRevision == latestRevision
+	return failed
+}
+
+// helmReleaseClusterStatus fills the cluster status from the HelmRelease, and returns whether it failed.
+// The HelmRelease is in sync when it is ready with the chart version last attempted.
+func helmReleaseClusterStatus(status *applicationapi.ApplicationClusterStatus, helmRelease *helmv2b1.HelmRelease) bool {
+	if helmRelease == nil {
+		status.Message = "not created"
+		return false
+	}
+
+	ready, failed, message := fluxConditionsState(helmRelease.Generation, helmRelease.Status.ObservedGeneration, helmRelease.Status.Conditions)
+	status.Ready = ready
+	status.Suspended = helmRelease.Spec.Suspend
+	status.LastAppliedRevision = helmRelease.Status.LastAppliedRevision
+	status.Message = message
+	status.InSync = ready && status.LastAppliedRevision != "" && status.LastAppliedRevision == helmRelease.Status.LastAttemptedRevision
+	return failed
+}
+
+// fluxConditionsState returns whether the flux object is ready with the latest spec, or failed.
+// Besides stalled, the object failed when it is not ready and the controller is not working on it anymore.
+func fluxConditionsState(generation, observedGeneration int64, conditions []metav1.Condition) (ready, failed bool, message string) {
+	ready, failed, message = fluxObjectHealth(generation, observedGeneration, conditions)
+	if ready || failed || observedGeneration != generation {
+		return
+	}
+
+	readyCondition := apimeta.FindStatusCondition(conditions, fluxmeta.ReadyCondition)
+	if readyCondition != nil && readyCondition.Status == metav1.ConditionFalse && !apimeta.IsStatusConditionTrue(conditions, fluxmeta.ReconcilingCondition) {
+		return false, true, readyCondition.Message
+	}
+	return
+}
+
+// sourceState returns the latest artifact revision of the git or oci source, and whether the source failed.
+// The revision of a helm repository is the checksum of the index, which is not applied to the clusters, so it is not returned.
+func sourceState(status *applicationapi.ApplicationSourceStatus) (revision string, failed bool) {
+	if status == nil {
+		return "", false
+	}
+
+	var (
+		artifact   *sourcev1.Artifact
+		conditions []metav1.Condition
+		generation int64
+	)
+	switch {
+	case status.GitRepoStatus != nil:
+		artifact, conditions, generation = status.GitRepoStatus.Artifact, status.GitRepoStatus.Conditions, status.GitRepoStatus.ObservedGeneration
+	case status.OCIRepoStatus != nil:
+		artifact, conditions, generation = status.OCIRepoStatus.Artifact, status.OCIRepoStatus.Conditions, status.OCIRepoStatus.ObservedGeneration
+	case status.HelmRepoStatus != nil:
+		conditions, generation = status.HelmRepoStatus.Conditions, status.HelmRepoStatus.ObservedGeneration
+	}
+
+	if artifact != nil {
+		revision = artifact.Revision
+	}
+	_, failed, _ = fluxConditionsState(generation, generation, conditions)
+	return revision, failed
+}
+
+// rolloutFailed returns whether the waves halted or the canary failed in any cluster.
+func rolloutFailed(status applicationapi.ApplicationStatus) bool {
+	for _, strategy := range status.RolloutStrategyStatus {
+		if strategy.Phase == applicationapi.RolloutStrategyHalted {
+			return true
+		}
+	}
+	for _, syncStatus := range status.SyncStatus {
+		if syncStatus.RolloutStatus != nil && syncStatus.RolloutStatus.RolloutStatusInCluster != nil &&
+			syncStatus.RolloutStatus.RolloutStatusInCluster.Phase == flaggerv1b1.CanaryPhaseFailed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"testing"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	helmv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1beta2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+)
+
+var (
+	readyConditions       = []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue}}
+	failedConditions      = []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse, Message: "apply failed"}}
+	reconcilingConditions = []metav1.Condition{
+		{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse},
+		{Type: fluxmeta.ReconcilingCondition, Status: metav1.ConditionTrue},
+	}
+)
+
+func newStatusTestKustomization(name, revision string, conditions []metav1.Condition) kustomizev1beta2.Kustomization {
+	return kustomizev1beta2.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 1},
+		Status: kustomizev1beta2.KustomizationStatus{
+			ObservedGeneration:    1,
+			Conditions:            conditions,
+			LastAppliedRevision:   revision,
+			LastAttemptedRevision: revision,
+		},
+	}
+}
+
+func TestAggregateApplicationStatus(t *testing.T) {
+	desired := map[string]policyResource{
+		"app-0-attachedcluster-member1": {policyName: "app-0", kind: KustomizationKind, clusterKind: "AttachedCluster", clusterName: "member1"},
+		"app-0-attachedcluster-member2": {policyName: "app-0", kind: KustomizationKind, clusterKind: "AttachedCluster", clusterName: "member2"},
+	}
+	gitSource := &applicationapi.ApplicationSourceStatus{
+		GitRepoStatus: &sourcev1beta2.GitRepositoryStatus{
+			Conditions: readyConditions,
+			Artifact:   &sourcev1.Artifact{Revision: "main@sha1:new"},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		status         applicationapi.ApplicationStatus
+		kustomizations []kustomizev1beta2.Kustomization
+		phase          applicationapi.ApplicationPhase
+		inSync         int
+	}{
+		{
+			name:   "healthy",
+			status: applicationapi.ApplicationStatus{SourceStatus: gitSource},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+				newStatusTestKustomization("app-0-attachedcluster-member2", "main@sha1:new", readyConditions),
+			},
+			phase:  applicationapi.ApplicationHealthy,
+			inSync: 2,
+		},
+		{
+			name:   "out of date",
+			status: applicationapi.ApplicationStatus{SourceStatus: gitSource},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+				newStatusTestKustomization("app-0-attachedcluster-member2", "main@sha1:old", reconcilingConditions),
+			},
+			phase:  applicationapi.ApplicationProgressing,
+			inSync: 1,
+		},
+		{
+			name:   "not created",
+			status: applicationapi.ApplicationStatus{SourceStatus: gitSource},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+			},
+			phase:  applicationapi.ApplicationProgressing,
+			inSync: 1,
+		},
+		{
+			name:   "failed in a cluster",
+			status: applicationapi.ApplicationStatus{SourceStatus: gitSource},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+				newStatusTestKustomization("app-0-attachedcluster-member2", "main@sha1:old", failedConditions),
+			},
+			phase:  applicationapi.ApplicationDegraded,
+			inSync: 1,
+		},
+		{
+			name: "source failed",
+			status: applicationapi.ApplicationStatus{
+				SourceStatus: &applicationapi.ApplicationSourceStatus{
+					OCIRepoStatus: &sourcev1beta2.OCIRepositoryStatus{Conditions: failedConditions},
+				},
+			},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+				newStatusTestKustomization("app-0-attachedcluster-member2", "main@sha1:new", readyConditions),
+			},
+			phase:  applicationapi.ApplicationDegraded,
+			inSync: 2,
+		},
+		{
+			name: "canary failed",
+			status: applicationapi.ApplicationStatus{
+				SourceStatus: gitSource,
+				SyncStatus: []*applicationapi.ApplicationSyncStatus{{
+					Name: "app-0-attachedcluster-member1",
+					RolloutStatus: &applicationapi.RolloutStatus{
+						RolloutStatusInCluster: &flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseFailed},
+					},
+				}},
+			},
+			kustomizations: []kustomizev1beta2.Kustomization{
+				newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+				newStatusTestKustomization("app-0-attachedcluster-member2", "main@sha1:new", readyConditions),
+			},
+			phase:  applicationapi.ApplicationDegraded,
+			inSync: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := &applicationapi.Application{Status: tc.status}
+			aggregateApplicationStatus(app, desired, tc.kustomizations, nil)
+
+			assert.Equal(t, tc.phase, app.Status.Phase)
+			assert.Equal(t, &applicationapi.ApplicationSyncSummary{Clusters: 2, InSync: tc.inSync, OutOfDate: 2 - tc.inSync}, app.Status.Summary)
+			assert.Len(t, app.Status.ClusterStatus, 2)
+			assert.Equal(t, "member1", app.Status.ClusterStatus[0].ClusterName)
+		})
+	}
+}
+
+func TestAggregateApplicationStatusByCluster(t *testing.T) {
+	// two policies deployed to the same cluster, the cluster is only in sync when both are in sync
+	desired := map[string]policyResource{
+		"app-0-attachedcluster-member1": {policyName: "app-0", kind: KustomizationKind, clusterKind: "AttachedCluster", clusterName: "member1"},
+		"app-1-attachedcluster-member1": {policyName: "app-1", kind: HelmReleaseKind, clusterKind: "AttachedCluster", clusterName: "member1"},
+	}
+	kustomizations := []kustomizev1beta2.Kustomization{
+		newStatusTestKustomization("app-0-attachedcluster-member1", "main@sha1:new", readyConditions),
+	}
+	helmReleases := []helmv2b1.HelmRelease{{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-1-attachedcluster-member1", Generation: 2},
+		Spec:       helmv2b1.HelmReleaseSpec{Suspend: true},
+		Status: helmv2b1.HelmReleaseStatus{
+			ObservedGeneration:    2,
+			Conditions:            readyConditions,
+			LastAppliedRevision:   "6.5.0",
+			LastAttemptedRevision: "6.5.1",
+		},
+	}}
+
+	app := &applicationapi.Application{}
+	aggregateApplicationStatus(app, desired, kustomizations, helmReleases)
+
+	assert.Equal(t, applicationapi.ApplicationProgressing, app.Status.Phase)
+	assert.Equal(t, &applicationapi.ApplicationSyncSummary{Clusters: 1, OutOfDate: 1}, app.Status.Summary)
+	assert.Equal(t, &applicationapi.ApplicationClusterStatus{
+		ClusterName:         "member1",
+		ClusterKind:         "AttachedCluster",
+		PolicyName:          "app-1",
+		Kind:                HelmReleaseKind,
+		Name:                "app-1-attachedcluster-member1",
+		Ready:               true,
+		Suspended:           true,
+		LastAppliedRevision: "6.5.0",
+	}, app.Status.ClusterStatus[1])
+
+	// all suspended
+	desired = map[string]policyResource{"app-1-attachedcluster-member1": desired["app-1-attachedcluster-member1"]}
+	aggregateApplicationStatus(app, desired, nil, helmReleases)
+	assert.Equal(t, applicationapi.ApplicationSuspended, app.Status.Phase)
+}
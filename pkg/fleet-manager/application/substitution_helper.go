@@ -0,0 +1,157 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+const (
+	// cluster variables substituted in the manifests of kustomization
+	ClusterNameVariable        = "CLUSTER_NAME"
+	ClusterKindVariable        = "CLUSTER_KIND"
+	ClusterRegionVariable      = "CLUSTER_REGION"
+	ClusterLabelVariablePrefix = "CLUSTER_LABEL_"
+
+	// regionLabel is the well-known label of the region, used when the region is not set in the cluster spec.
+	regionLabel = "topology.kubernetes.io/region"
+)
+
+var invalidVariableChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// clusterVariables returns the variables of the cluster to substitute in the manifests of kustomization.
+func clusterVariables(cluster fleetmanager.ClusterInterface) map[string]string {
+	obj := cluster.GetObject()
+	vars := map[string]string{
+		ClusterNameVariable: obj.GetName(),
+	}
+
+	switch c := cluster.(type) {
+	case *clusterv1alpha1.Cluster:
+		vars[ClusterKindVariable] = fleetmanager.ClusterKind
+		vars[ClusterRegionVariable] = c.Spec.Region
+	case *clusterv1alpha1.AttachedCluster:
+		vars[ClusterKindVariable] = fleetmanager.AttachedClusterKind
+	default:
+		vars[ClusterKindVariable] = obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	if vars[ClusterRegionVariable] == "" {
+		vars[ClusterRegionVariable] = obj.GetLabels()[regionLabel]
+	}
+
+	// the label keys are iterated in order, so that the first one wins if several keys map to the same variable,
+	// e.g. kurator.dev/tier and kurator-dev/tier
+	labels := obj.GetLabels()
+	for _, key := range sets.List(sets.KeySet(labels)) {
+		name := labelVariableName(key)
+		if _, exist := vars[name]; !exist {
+			vars[name] = labels[key]
+		}
+	}
+	return vars
+}
+
+// labelVariableName returns the variable name of the label key, e.g. CLUSTER_LABEL_TOPOLOGY_KUBERNETES_IO_REGION for topology.kubernetes.io/region.
+func labelVariableName(key string) string {
+	return ClusterLabelVariablePrefix + invalidVariableChars.ReplaceAllString(strings.ToUpper(key), "_")
+}
+
+// renderKustomizationForCluster returns the kustomization with the cluster variables added to the substitutions.
+// The cluster variables are only added when postBuild is set, as flux replaces the undefined variables in the manifests once the substitution is enabled.
+func renderKustomizationForCluster(kustomization *applicationapi.Kustomization, cluster fleetmanager.ClusterInterface) *applicationapi.Kustomization {
+	if kustomization.PostBuild == nil || cluster == nil {
+		return kustomization
+	}
+
+	rendered := kustomization.DeepCopy()
+	substitute := clusterVariables(cluster)
+	for key, value := range kustomization.PostBuild.Substitute {
+		substitute[key] = value
+	}
+	rendered.PostBuild.Substitute = substitute
+	return rendered
+}
+
+// renderHelmReleaseForCluster returns the helmRelease with the values overlays matching the cluster merged into the values.
+func renderHelmReleaseForCluster(helmRelease *applicationapi.HelmRelease, cluster fleetmanager.ClusterInterface) (*applicationapi.HelmRelease, error) {
+	if len(helmRelease.ValuesOverlays) == 0 || cluster == nil {
+		return helmRelease, nil
+	}
+
+	values, err := unmarshalValues(helmRelease.Values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid values: %v", err)
+	}
+	matched := false
+	for i, overlay := range helmRelease.ValuesOverlays {
+		if !doLabelsMatchSelector(cluster.GetObject().GetLabels(), &overlay.ClusterSelector) {
+			continue
+		}
+		overlayValues, err := unmarshalValues(overlay.Values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values of valuesOverlays[%d]: %v", i, err)
+		}
+		values = mergeValues(values, overlayValues)
+		matched = true
+	}
+	if !matched {
+		return helmRelease, nil
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	rendered := helmRelease.DeepCopy()
+	rendered.Values = &apiextensionsv1.JSON{Raw: raw}
+	return rendered, nil
+}
+
+func unmarshalValues(values *apiextensionsv1.JSON) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if values == nil || len(values.Raw) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(values.Raw, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergeValues merges the overlay into the base recursively, the values in the overlay take precedence.
+func mergeValues(base, overlay map[string]interface{}) map[string]interface{} {
+	for key, value := range overlay {
+		if overlayMap, ok := value.(map[string]interface{}); ok {
+			if baseMap, ok := base[key].(map[string]interface{}); ok {
+				base[key] = mergeValues(baseMap, overlayMap)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+)
+
+func TestClusterVariables(t *testing.T) {
+	cluster := &clusterv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-eu", Labels: map[string]string{"env": "prod", "kurator.dev/tier": "gold"}},
+		Spec:       clusterv1alpha1.ClusterSpec{Region: "eu-west-1"},
+	}
+	assert.Equal(t, map[string]string{
+		"CLUSTER_NAME":                   "prod-eu",
+		"CLUSTER_KIND":                   "Cluster",
+		"CLUSTER_REGION":                 "eu-west-1",
+		"CLUSTER_LABEL_ENV":              "prod",
+		"CLUSTER_LABEL_KURATOR_DEV_TIER": "gold",
+	}, clusterVariables(cluster))
+
+	attachedCluster := &clusterv1alpha1.AttachedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-us", Labels: map[string]string{regionLabel: "us-east-1"}},
+	}
+	assert.Equal(t, map[string]string{
+		"CLUSTER_NAME":   "prod-us",
+		"CLUSTER_KIND":   "AttachedCluster",
+		"CLUSTER_REGION": "us-east-1",
+		"CLUSTER_LABEL_TOPOLOGY_KUBERNETES_IO_REGION": "us-east-1",
+	}, clusterVariables(attachedCluster))
+
+	// the label keys mapping to the same variable, the first one in order wins
+	for i := 0; i < 10; i++ {
+		collided := &clusterv1alpha1.AttachedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod-ap", Labels: map[string]string{"kurator.dev/tier": "gold", "kurator-dev/tier": "silver", "kurator_dev/tier": "bronze"}},
+		}
+		assert.Equal(t, "silver", clusterVariables(collided)["CLUSTER_LABEL_KURATOR_DEV_TIER"])
+	}
+}
+
+func TestRenderKustomizationForCluster(t *testing.T) {
+	cluster := &clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "member1"}}
+
+	// the substitution is not enabled
+	kustomization := &applicationapi.Kustomization{Path: "./deploy"}
+	assert.Same(t, kustomization, renderKustomizationForCluster(kustomization, cluster))
+
+	kustomization = &applicationapi.Kustomization{
+		Path: "./deploy",
+		PostBuild: &applicationapi.PostBuild{
+			Substitute: map[string]string{"DOMAIN": "example.com", ClusterNameVariable: "overridden"},
+		},
+	}
+	rendered := renderKustomizationForCluster(kustomization, cluster)
+	assert.Equal(t, map[string]string{
+		"DOMAIN":            "example.com",
+		ClusterNameVariable: "overridden",
+		ClusterKindVariable: "AttachedCluster",
+		// the region is unknown
+		ClusterRegionVariable: "",
+	}, rendered.PostBuild.Substitute)
+	// the policy is not changed
+	assert.Len(t, kustomization.PostBuild.Substitute, 2)
+
+	// the host cluster has no cluster variables
+	assert.Same(t, kustomization, renderKustomizationForCluster(kustomization, nil))
+}
+
+func TestRenderHelmReleaseForCluster(t *testing.T) {
+	helmRelease := &applicationapi.HelmRelease{
+		Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":1,"ingress":{"enabled":true,"host":"app.example.com"}}`)},
+		ValuesOverlays: []applicationapi.HelmValuesOverlay{
+			{
+				ClusterSelector: applicationapi.ClusterSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Values:          &apiextensionsv1.JSON{Raw: []byte(`{"replicas":3}`)},
+			},
+			{
+				ClusterSelector: applicationapi.ClusterSelector{MatchLabels: map[string]string{"region": "eu"}},
+				Values:          &apiextensionsv1.JSON{Raw: []byte(`{"ingress":{"host":"eu.example.com"}}`)},
+			},
+			{
+				ClusterSelector: applicationapi.ClusterSelector{MatchLabels: map[string]string{"region": "us"}},
+				Values:          &apiextensionsv1.JSON{Raw: []byte(`{"ingress":{"host":"us.example.com"}}`)},
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{
+			name:     "no overlay matched",
+			labels:   map[string]string{"env": "dev"},
+			expected: `{"replicas":1,"ingress":{"enabled":true,"host":"app.example.com"}}`,
+		},
+		{
+			name:     "prod eu",
+			labels:   map[string]string{"env": "prod", "region": "eu"},
+			expected: `{"replicas":3,"ingress":{"enabled":true,"host":"eu.example.com"}}`,
+		},
+		{
+			name:     "prod us",
+			labels:   map[string]string{"env": "prod", "region": "us"},
+			expected: `{"replicas":3,"ingress":{"enabled":true,"host":"us.example.com"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "member", Labels: tc.labels}}
+			rendered, err := renderHelmReleaseForCluster(helmRelease, cluster)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(rendered.Values.Raw))
+		})
+	}
+
+	// the policy is not changed
+	assert.JSONEq(t, `{"replicas":1,"ingress":{"enabled":true,"host":"app.example.com"}}`, string(helmRelease.Values.Raw))
+}
@@ -0,0 +1,408 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	helmv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	kustomizev1beta2 "github.com/fluxcd/kustomize-controller/api/v1beta2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+const (
+	// defaultWaveTimeout is the default maximum time for the clusters of a wave to become healthy.
+	defaultWaveTimeout = 10 * time.Minute
+
+	// remainingWaveName is the name of the implicit last wave, which deploys the clusters not selected by any wave.
+	remainingWaveName = "remaining"
+)
+
+// rolloutWave is a group of the destination clusters deployed together.
+type rolloutWave struct {
+	name     string
+	clusters []fleetmanager.ClusterInterface
+}
+
+// waveHealth is the health of the clusters of a wave.
+type waveHealth struct {
+	healthy bool
+	failed  bool
+	// message explains why the wave is not healthy or failed.
+	message string
+}
+
+// splitRolloutWaves groups the destination clusters into the waves of the strategy.
+// A cluster belongs to the first wave selecting it, and the clusters not selected by any wave are put into an implicit last wave.
+func splitRolloutWaves(strategy *applicationapi.RolloutStrategy, clusters []fleetmanager.ClusterInterface) []rolloutWave {
+	waves := make([]rolloutWave, len(strategy.Waves))
+	for i, wave := range strategy.Waves {
+		waves[i].name = wave.Name
+	}
+
+	var remaining []fleetmanager.ClusterInterface
+	for _, cluster := range clusters {
+		index := waveIndexOfCluster(strategy.Waves, cluster.GetObject())
+		if index < 0 {
+			remaining = append(remaining, cluster)
+			continue
+		}
+		waves[index].clusters = append(waves[index].clusters, cluster)
+	}
+
+	if len(remaining) != 0 {
+		waves = append(waves, rolloutWave{name: remainingWaveName, clusters: remaining})
+	}
+	return waves
+}
+
+// waveIndexOfCluster returns the index of the first wave selecting the cluster, -1 if no wave selects it.
+func waveIndexOfCluster(waves []applicationapi.RolloutWave, cluster client.Object) int {
+	for i, wave := range waves {
+		for _, name := range wave.Clusters {
+			if name == cluster.GetName() {
+				return i
+			}
+		}
+		if wave.ClusterSelector != nil && len(wave.ClusterSelector.MatchLabels) != 0 && doLabelsMatchSelector(cluster.GetLabels(), wave.ClusterSelector) {
+			return i
+		}
+	}
+	return -1
+}
+
+// rolloutStrategyStatusFor returns the wave status of the sync policy, which is reset when the Application or the waves are changed.
+func rolloutStrategyStatusFor(app *applicationapi.Application, policyName string, waves []rolloutWave) *applicationapi.RolloutStrategyStatus {
+	var status *applicationapi.RolloutStrategyStatus
+	for _, s := range app.Status.RolloutStrategyStatus {
+		if s.PolicyName == policyName {
+			status = s
+			break
+		}
+	}
+	if status == nil {
+		status = &applicationapi.RolloutStrategyStatus{PolicyName: policyName}
+		app.Status.RolloutStrategyStatus = append(app.Status.RolloutStrategyStatus, status)
+	}
+
+	if status.ObservedGeneration != app.Generation || !sameWaves(status.Waves, waves) {
+		status.ObservedGeneration = app.Generation
+		status.Phase = applicationapi.RolloutStrategyProgressing
+		status.CurrentWave = 0
+		status.Message = ""
+		status.Waves = make([]*applicationapi.RolloutWaveStatus, 0, len(waves))
+		for _, wave := range waves {
+			status.Waves = append(status.Waves, &applicationapi.RolloutWaveStatus{
+				Name:  wave.name,
+				Phase: applicationapi.RolloutWavePending,
+			})
+		}
+	}
+
+	// the clusters of the waves are refreshed in every loop, because the clusters may join or leave the fleet.
+	for i, wave := range waves {
+		status.Waves[i].Clusters = clusterNames(wave.clusters)
+	}
+	return status
+}
+
+func sameWaves(status []*applicationapi.RolloutWaveStatus, waves []rolloutWave) bool {
+	if len(status) != len(waves) {
+		return false
+	}
+	for i := range waves {
+		if status[i].Name != waves[i].name {
+			return false
+		}
+	}
+	return true
+}
+
+func clusterNames(clusters []fleetmanager.ClusterInterface) []string {
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.GetObject().GetName())
+	}
+	return names
+}
+
+// pruneRolloutStrategyStatus removes the wave status of the sync policies without rolloutStrategy.
+func pruneRolloutStrategyStatus(app *applicationapi.Application) {
+	policies := make(map[string]bool, len(app.Spec.SyncPolicies))
+	for index, policy := range app.Spec.SyncPolicies {
+		if policy.RolloutStrategy != nil {
+			policies[generatePolicyName(app, index)] = true
+		}
+	}
+
+	var status []*applicationapi.RolloutStrategyStatus
+	for _, s := range app.Status.RolloutStrategyStatus {
+		if policies[s.PolicyName] {
+			status = append(status, s)
+		}
+	}
+	app.Status.RolloutStrategyStatus = status
+}
+
+// syncPolicyResourceInWaves deploys the sync policy to the clusters wave by wave.
+// The resources in the clusters of the deployed waves are kept in sync, while the clusters of the subsequent waves are untouched,
+// so they keep the previous version until their wave starts.
+func (a *ApplicationManager) syncPolicyResourceInWaves(ctx context.Context,
+	app *applicationapi.Application,
+	fleet *fleetapi.Fleet,
+	syncPolicy *applicationapi.ApplicationSyncPolicy,
+	policyName string,
+	fleetClusterList []fleetmanager.ClusterInterface,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	waves := splitRolloutWaves(syncPolicy.RolloutStrategy, fleetClusterList)
+	status := rolloutStrategyStatusFor(app, policyName, waves)
+
+	for {
+		// sync the resources of all the deployed waves, including the current one.
+		for i := 0; i <= status.CurrentWave && i < len(waves); i++ {
+			if result, err := a.syncWaveResources(ctx, app, fleet, syncPolicy, policyName, waves[i]); err != nil || result.RequeueAfter > 0 {
+				return result, err
+			}
+		}
+
+		if status.Phase != applicationapi.RolloutStrategyProgressing {
+			return ctrl.Result{}, nil
+		}
+		if status.CurrentWave >= len(waves) {
+			status.Phase = applicationapi.RolloutStrategySucceeded
+			return ctrl.Result{}, nil
+		}
+
+		waveStatus := status.Waves[status.CurrentWave]
+		if waveStatus.StartTime == nil {
+			now := metav1.Now()
+			waveStatus.StartTime = &now
+		}
+		waveStatus.Phase = applicationapi.RolloutWaveProgressing
+
+		health, err := a.checkWaveHealth(ctx, fleet, syncPolicy, policyName, waves[status.CurrentWave])
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		waveStatus.Message = health.message
+
+		if !health.failed && !health.healthy && waveTimedOut(syncPolicy.RolloutStrategy, waveStatus, time.Now()) {
+			health.failed = true
+			waveStatus.Message = fmt.Sprintf("not healthy within %s: %s", waveTimeout(syncPolicy.RolloutStrategy), health.message)
+		}
+		if health.failed {
+			waveStatus.Phase = applicationapi.RolloutWaveFailed
+			status.Phase = applicationapi.RolloutStrategyHalted
+			status.Message = fmt.Sprintf("wave %s failed, the subsequent waves are not deployed: %s", waveStatus.Name, waveStatus.Message)
+			log.Info("rollout halted", "policy", policyName, "wave", waveStatus.Name, "reason", waveStatus.Message)
+			return ctrl.Result{}, nil
+		}
+		if !health.healthy {
+			return ctrl.Result{}, nil
+		}
+
+		// the current wave is healthy, go on with the next one
+		waveStatus.Phase = applicationapi.RolloutWaveSucceeded
+		waveStatus.Message = ""
+		status.CurrentWave++
+		log.Info("rollout wave succeeded", "policy", policyName, "wave", waveStatus.Name)
+	}
+}
+
+// syncWaveResources syncs the Kustomization or HelmRelease and the rollout of the sync policy for the clusters of the wave.
+func (a *ApplicationManager) syncWaveResources(ctx context.Context,
+	app *applicationapi.Application,
+	fleet *fleetapi.Fleet,
+	syncPolicy *applicationapi.ApplicationSyncPolicy,
+	policyName string,
+	wave rolloutWave,
+) (ctrl.Result, error) {
+	policyKind := getSyncPolicyKind(syncPolicy)
+	for _, currentFleetCluster := range wave.clusters {
+		kubeconfig := a.generateKubeConfig(currentFleetCluster)
+		if result, err := a.handleSyncPolicyByKind(ctx, app, policyKind, syncPolicy, policyName, &currentFleetCluster, kubeconfig); err != nil || result.RequeueAfter > 0 {
+			return result, errors.Wrapf(err, "failed to handleSyncPolicyByKind currentFleetCluster=%s", currentFleetCluster.GetObject().GetName())
+		}
+	}
+
+	if syncPolicy.Rollout == nil || len(wave.clusters) == 0 {
+		return ctrl.Result{}, nil
+	}
+	rolloutClusters, err := a.waveRolloutClusters(fleet, wave)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if result, err := a.syncRolloutPolicyForCluster(ctx, syncPolicy.Rollout, rolloutClusters, policyName); err != nil {
+		return result, errors.Wrapf(err, "failed to syncRolloutPolicy")
+	}
+	return ctrl.Result{}, nil
+}
+
+func (a *ApplicationManager) waveRolloutClusters(fleet *fleetapi.Fleet, wave rolloutWave) (map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster, error) {
+	fleetclusters := make(map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster, len(wave.clusters))
+	for _, cluster := range wave.clusters {
+		kclient, err := fleetmanager.ClientForCluster(a.Client, fleet.Namespace, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		kind := cluster.GetObject().GetObjectKind().GroupVersionKind().Kind
+		fleetclusters[fleetmanager.ClusterKey{Kind: kind, Name: cluster.GetObject().GetName()}] = &fleetmanager.FleetCluster{
+			Secret:    cluster.GetSecretName(),
+			SecretKey: cluster.GetSecretKey(),
+			Client:    kclient,
+		}
+	}
+	return fleetclusters, nil
+}
+
+// checkWaveHealth checks the Kustomization or HelmRelease and the canary (if rollout is configured) of each cluster in the wave.
+func (a *ApplicationManager) checkWaveHealth(ctx context.Context,
+	fleet *fleetapi.Fleet,
+	syncPolicy *applicationapi.ApplicationSyncPolicy,
+	policyName string,
+	wave rolloutWave,
+) (waveHealth, error) {
+	health := waveHealth{healthy: true}
+	for _, cluster := range wave.clusters {
+		kind := cluster.GetObject().GetObjectKind().GroupVersionKind().Kind
+		name := cluster.GetObject().GetName()
+		key := client.ObjectKey{
+			Namespace: fleet.Namespace,
+			Name:      generatePolicyResourceName(policyName, kind, name),
+		}
+
+		var healthy, failed bool
+		var message string
+		switch getSyncPolicyKind(syncPolicy) {
+		case KustomizationKind:
+			kustomization := &kustomizev1beta2.Kustomization{}
+			if err := a.Client.Get(ctx, key, kustomization); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return waveHealth{}, errors.Wrapf(err, "failed to get kustomization %s", key)
+				}
+				healthy, message = false, "kustomization is not found"
+			} else {
+				healthy, failed, message = fluxObjectHealth(kustomization.Generation, kustomization.Status.ObservedGeneration, kustomization.Status.Conditions)
+			}
+		case HelmReleaseKind:
+			helmRelease := &helmv2b1.HelmRelease{}
+			if err := a.Client.Get(ctx, key, helmRelease); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return waveHealth{}, errors.Wrapf(err, "failed to get helmRelease %s", key)
+				}
+				healthy, message = false, "helmRelease is not found"
+			} else {
+				healthy, failed, message = fluxObjectHealth(helmRelease.Generation, helmRelease.Status.ObservedGeneration, helmRelease.Status.Conditions)
+			}
+		}
+
+		if healthy && syncPolicy.Rollout != nil {
+			canary, err := a.fetchWaveCanary(ctx, fleet, syncPolicy.Rollout, cluster)
+			if err != nil {
+				return waveHealth{}, err
+			}
+			healthy, failed, message = canaryHealth(canary)
+		}
+
+		if failed {
+			return waveHealth{failed: true, message: fmt.Sprintf("cluster %s: %s", name, message)}, nil
+		}
+		if !healthy && health.healthy {
+			// report the first unhealthy cluster, and go on checking whether any cluster failed
+			health = waveHealth{message: fmt.Sprintf("cluster %s: %s", name, message)}
+		}
+	}
+	return health, nil
+}
+
+func (a *ApplicationManager) fetchWaveCanary(ctx context.Context, fleet *fleetapi.Fleet, rollout *applicationapi.RolloutConfig, cluster fleetmanager.ClusterInterface) (*flaggerv1b1.Canary, error) {
+	kclient, err := fleetmanager.ClientForCluster(a.Client, fleet.Namespace, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	canary := &flaggerv1b1.Canary{}
+	key := types.NamespacedName{Namespace: rollout.Workload.Namespace, Name: rollout.Workload.Name}
+	if err := kclient.CtrlRuntimeClient().Get(ctx, key, canary); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get canary %s in %s", key, cluster.GetObject().GetName())
+	}
+	return canary, nil
+}
+
+// fluxObjectHealth returns whether the Kustomization or HelmRelease is ready with the latest spec, or stalled.
+func fluxObjectHealth(generation, observedGeneration int64, conditions []metav1.Condition) (healthy, failed bool, message string) {
+	if stalled := apimeta.FindStatusCondition(conditions, fluxmeta.StalledCondition); stalled != nil && stalled.Status == metav1.ConditionTrue {
+		return false, true, fmt.Sprintf("stalled: %s", stalled.Message)
+	}
+
+	ready := apimeta.FindStatusCondition(conditions, fluxmeta.ReadyCondition)
+	if ready == nil || observedGeneration != generation {
+		return false, false, "waiting for reconciliation"
+	}
+	if ready.Status != metav1.ConditionTrue {
+		return false, false, fmt.Sprintf("not ready: %s", ready.Message)
+	}
+	return true, false, ""
+}
+
+// canaryHealth returns whether the canary has been initialized or promoted with the latest workload, or failed.
+func canaryHealth(canary *flaggerv1b1.Canary) (healthy, failed bool, message string) {
+	if canary == nil {
+		return false, false, "canary is not found"
+	}
+
+	switch canary.Status.Phase {
+	case flaggerv1b1.CanaryPhaseFailed:
+		return false, true, "canary failed"
+	case flaggerv1b1.CanaryPhaseInitialized, flaggerv1b1.CanaryPhaseSucceeded:
+		if canary.Status.LastAppliedSpec == canary.Status.LastPromotedSpec {
+			return true, false, ""
+		}
+	}
+	return false, false, fmt.Sprintf("canary is %s", canary.Status.Phase)
+}
+
+func waveTimeout(strategy *applicationapi.RolloutStrategy) time.Duration {
+	if strategy.WaveTimeout == nil {
+		return defaultWaveTimeout
+	}
+	return strategy.WaveTimeout.Duration
+}
+
+func waveTimedOut(strategy *applicationapi.RolloutStrategy, wave *applicationapi.RolloutWaveStatus, now time.Time) bool {
+	return wave.StartTime != nil && now.Sub(wave.StartTime.Time) > waveTimeout(strategy)
+}
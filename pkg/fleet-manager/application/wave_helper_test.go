@@ -0,0 +1,273 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	applicationapi "kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+func newAttachedCluster(name string, labels map[string]string) fleetmanager.ClusterInterface {
+	return &clusterv1alpha1.AttachedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+	}
+}
+
+func Test_splitRolloutWaves(t *testing.T) {
+	clusters := []fleetmanager.ClusterInterface{
+		newAttachedCluster("canary", map[string]string{"stage": "canary"}),
+		newAttachedCluster("prod-1", map[string]string{"stage": "prod"}),
+		newAttachedCluster("prod-2", map[string]string{"stage": "prod"}),
+		newAttachedCluster("other", nil),
+	}
+
+	cases := []struct {
+		name     string
+		strategy *applicationapi.RolloutStrategy
+		expected map[string][]string
+		order    []string
+	}{
+		{
+			name: "waves by selector and name",
+			strategy: &applicationapi.RolloutStrategy{
+				Waves: []applicationapi.RolloutWave{
+					{Name: "canary", ClusterSelector: &applicationapi.ClusterSelector{MatchLabels: map[string]string{"stage": "canary"}}},
+					{Name: "prod", Clusters: []string{"prod-1", "prod-2"}},
+				},
+			},
+			order: []string{"canary", "prod", remainingWaveName},
+			expected: map[string][]string{
+				"canary":          {"canary"},
+				"prod":            {"prod-1", "prod-2"},
+				remainingWaveName: {"other"},
+			},
+		},
+		{
+			name: "cluster belongs to the first matching wave",
+			strategy: &applicationapi.RolloutStrategy{
+				Waves: []applicationapi.RolloutWave{
+					{Name: "first", Clusters: []string{"prod-1"}},
+					{Name: "second", ClusterSelector: &applicationapi.ClusterSelector{MatchLabels: map[string]string{"stage": "prod"}}},
+					{Name: "third", Clusters: []string{"canary", "other"}},
+				},
+			},
+			order: []string{"first", "second", "third"},
+			expected: map[string][]string{
+				"first":  {"prod-1"},
+				"second": {"prod-2"},
+				"third":  {"canary", "other"},
+			},
+		},
+		{
+			name: "empty wave is kept",
+			strategy: &applicationapi.RolloutStrategy{
+				Waves: []applicationapi.RolloutWave{
+					{Name: "none", Clusters: []string{"not-exist"}},
+					{Name: "all", ClusterSelector: &applicationapi.ClusterSelector{MatchLabels: map[string]string{"stage": "prod"}}},
+				},
+			},
+			order: []string{"none", "all", remainingWaveName},
+			expected: map[string][]string{
+				"none":            {},
+				"all":             {"prod-1", "prod-2"},
+				remainingWaveName: {"canary", "other"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			waves := splitRolloutWaves(tc.strategy, clusters)
+			var order []string
+			actual := map[string][]string{}
+			for _, wave := range waves {
+				order = append(order, wave.name)
+				actual[wave.name] = clusterNames(wave.clusters)
+			}
+			if !reflect.DeepEqual(order, tc.order) {
+				t.Errorf("expected waves %v, got %v", tc.order, order)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected clusters %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_rolloutStrategyStatusFor(t *testing.T) {
+	waves := []rolloutWave{
+		{name: "canary", clusters: []fleetmanager.ClusterInterface{newAttachedCluster("canary", nil)}},
+		{name: "prod", clusters: []fleetmanager.ClusterInterface{newAttachedCluster("prod-1", nil)}},
+	}
+	app := &applicationapi.Application{ObjectMeta: metav1.ObjectMeta{Name: "app", Generation: 1}}
+
+	status := rolloutStrategyStatusFor(app, "app-0", waves)
+	if status.Phase != applicationapi.RolloutStrategyProgressing || status.CurrentWave != 0 || len(status.Waves) != 2 {
+		t.Fatalf("unexpected initial status %+v", status)
+	}
+	if !reflect.DeepEqual(status.Waves[1].Clusters, []string{"prod-1"}) {
+		t.Errorf("expected clusters of wave prod [prod-1], got %v", status.Waves[1].Clusters)
+	}
+
+	// the progress is kept in the same generation
+	status.CurrentWave = 1
+	status.Waves[0].Phase = applicationapi.RolloutWaveSucceeded
+	if got := rolloutStrategyStatusFor(app, "app-0", waves); got != status || got.CurrentWave != 1 {
+		t.Errorf("expected progress to be kept, got %+v", got)
+	}
+	if len(app.Status.RolloutStrategyStatus) != 1 {
+		t.Errorf("expected 1 status, got %d", len(app.Status.RolloutStrategyStatus))
+	}
+
+	// a new generation starts from the first wave
+	app.Generation = 2
+	got := rolloutStrategyStatusFor(app, "app-0", waves)
+	if got.CurrentWave != 0 || got.ObservedGeneration != 2 || got.Waves[0].Phase != applicationapi.RolloutWavePending {
+		t.Errorf("expected status to be reset, got %+v", got)
+	}
+
+	// the status of the removed policies is pruned
+	rolloutStrategyStatusFor(app, "app-1", waves)
+	app.Spec.SyncPolicies = []*applicationapi.ApplicationSyncPolicy{{RolloutStrategy: &applicationapi.RolloutStrategy{}}}
+	pruneRolloutStrategyStatus(app)
+	if len(app.Status.RolloutStrategyStatus) != 1 || app.Status.RolloutStrategyStatus[0].PolicyName != "app-0" {
+		t.Errorf("expected only the status of app-0, got %+v", app.Status.RolloutStrategyStatus)
+	}
+}
+
+func Test_fluxObjectHealth(t *testing.T) {
+	cases := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		conditions         []metav1.Condition
+		healthy            bool
+		failed             bool
+	}{
+		{
+			name:               "ready",
+			generation:         2,
+			observedGeneration: 2,
+			conditions:         []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue}},
+			healthy:            true,
+		},
+		{
+			name:               "ready with previous generation",
+			generation:         2,
+			observedGeneration: 1,
+			conditions:         []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue}},
+		},
+		{
+			name:               "not ready",
+			generation:         1,
+			observedGeneration: 1,
+			conditions:         []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse}},
+		},
+		{
+			name:       "no condition",
+			generation: 1,
+		},
+		{
+			name:               "stalled",
+			generation:         1,
+			observedGeneration: 1,
+			conditions: []metav1.Condition{
+				{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionFalse},
+				{Type: fluxmeta.StalledCondition, Status: metav1.ConditionTrue},
+			},
+			failed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			healthy, failed, _ := fluxObjectHealth(tc.generation, tc.observedGeneration, tc.conditions)
+			if healthy != tc.healthy || failed != tc.failed {
+				t.Errorf("expected healthy=%v failed=%v, got healthy=%v failed=%v", tc.healthy, tc.failed, healthy, failed)
+			}
+		})
+	}
+}
+
+func Test_canaryHealth(t *testing.T) {
+	cases := []struct {
+		name    string
+		canary  *flaggerv1b1.Canary
+		healthy bool
+		failed  bool
+	}{
+		{
+			name: "not found",
+		},
+		{
+			name:    "initialized",
+			canary:  &flaggerv1b1.Canary{Status: flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseInitialized, LastAppliedSpec: "a", LastPromotedSpec: "a"}},
+			healthy: true,
+		},
+		{
+			name:    "promoted",
+			canary:  &flaggerv1b1.Canary{Status: flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseSucceeded, LastAppliedSpec: "b", LastPromotedSpec: "b"}},
+			healthy: true,
+		},
+		{
+			name:   "new revision not analyzed yet",
+			canary: &flaggerv1b1.Canary{Status: flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseSucceeded, LastAppliedSpec: "b", LastPromotedSpec: "a"}},
+		},
+		{
+			name:   "progressing",
+			canary: &flaggerv1b1.Canary{Status: flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseProgressing, LastAppliedSpec: "b", LastPromotedSpec: "a"}},
+		},
+		{
+			name:   "failed",
+			canary: &flaggerv1b1.Canary{Status: flaggerv1b1.CanaryStatus{Phase: flaggerv1b1.CanaryPhaseFailed, LastAppliedSpec: "b", LastPromotedSpec: "a"}},
+			failed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			healthy, failed, _ := canaryHealth(tc.canary)
+			if healthy != tc.healthy || failed != tc.failed {
+				t.Errorf("expected healthy=%v failed=%v, got healthy=%v failed=%v", tc.healthy, tc.failed, healthy, failed)
+			}
+		})
+	}
+}
+
+func Test_waveTimedOut(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-5 * time.Minute))
+
+	if waveTimedOut(&applicationapi.RolloutStrategy{}, &applicationapi.RolloutWaveStatus{StartTime: &started}, now) {
+		t.Errorf("expected wave not timed out with the default timeout")
+	}
+	if !waveTimedOut(&applicationapi.RolloutStrategy{WaveTimeout: &metav1.Duration{Duration: time.Minute}}, &applicationapi.RolloutWaveStatus{StartTime: &started}, now) {
+		t.Errorf("expected wave timed out")
+	}
+	if waveTimedOut(&applicationapi.RolloutStrategy{}, &applicationapi.RolloutWaveStatus{}, now) {
+		t.Errorf("expected wave not started not timed out")
+	}
+}
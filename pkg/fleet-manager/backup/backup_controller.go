@@ -21,8 +21,10 @@ import (
 	"github.com/pkg/errors"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -119,7 +121,11 @@ func (b *BackupManager) reconcileBackupResources(ctx context.Context, backup *ba
 		// Handle scheduled backups
 		for clusterKey, clusterAccess := range destinationClusters {
 			veleroScheduleName := generateVeleroResourceName(clusterKey.Name, BackupKind, backup.Namespace, backup.Name)
-			veleroSchedule := buildVeleroScheduleInstance(&backup.Spec, backupLabel, veleroScheduleName)
+			veleroSchedule, err := withSnapshotMoveData(buildVeleroScheduleInstance(&backup.Spec, backupLabel, veleroScheduleName),
+				velerov1.SchemeGroupVersion.WithKind("Schedule"), backup.Spec.Policy, "spec", "template")
+			if err != nil {
+				return ctrl.Result{}, err
+			}
 			task := newSyncVeleroTaskFunc(ctx, clusterAccess, veleroSchedule)
 			tasks = append(tasks, task)
 		}
@@ -127,7 +133,11 @@ func (b *BackupManager) reconcileBackupResources(ctx context.Context, backup *ba
 		// Handle one time backups
 		for clusterKey, clusterAccess := range destinationClusters {
 			veleroBackupName := generateVeleroResourceName(clusterKey.Name, BackupKind, backup.Namespace, backup.Name)
-			veleroBackup := buildVeleroBackupInstance(&backup.Spec, backupLabel, veleroBackupName)
+			veleroBackup, err := withSnapshotMoveData(buildVeleroBackupInstance(&backup.Spec, backupLabel, veleroBackupName),
+				velerov1.SchemeGroupVersion.WithKind("Backup"), backup.Spec.Policy, "spec")
+			if err != nil {
+				return ctrl.Result{}, err
+			}
 			task := newSyncVeleroTaskFunc(ctx, clusterAccess, veleroBackup)
 			tasks = append(tasks, task)
 		}
@@ -157,7 +167,7 @@ func (b *BackupManager) reconcileBackupStatus(ctx context.Context, backup *backu
 		statusMap[key] = detail
 	}
 	if isScheduleBackup(backup) {
-		return b.reconcileScheduleBackupStatus(ctx, backup, destinationClusters, statusMap)
+		return b.reconcileScheduleBackupStatus(ctx, backup, destinationClusters)
 	} else {
 		return b.reconcileOneTimeBackupStatus(ctx, backup, destinationClusters, statusMap)
 	}
@@ -180,10 +190,17 @@ func (b *BackupManager) reconcileOneTimeBackupStatus(ctx context.Context, backup
 			return ctrl.Result{}, err
 		}
 
+		volumes, err := listVolumeBackupDetails(ctx, clusterAccess, veleroBackup.Name)
+		if err != nil {
+			log.Error(err, "failed to list volume backups for sync one time backup status")
+			return ctrl.Result{}, err
+		}
+
 		key := fmt.Sprintf("%s-%s-%s", clusterKey.Name, clusterKey.Kind, veleroBackup.Name)
 		if detail, exists := statusMap[key]; exists {
 			// If a matching entry is found, update the existing BackupDetails object with the new status.
 			detail.BackupStatusInCluster = &veleroBackup.Status
+			detail.Volumes = volumes
 		} else {
 			// If no matching entry is found, create a new BackupDetails object and append it to the backup's status details.
 			currentBackupDetails := &backupapi.BackupDetails{
@@ -191,15 +208,17 @@ func (b *BackupManager) reconcileOneTimeBackupStatus(ctx context.Context, backup
 				ClusterKind:           clusterKey.Kind,
 				BackupNameInCluster:   veleroBackup.Name,
 				BackupStatusInCluster: &veleroBackup.Status,
+				Volumes:               volumes,
 			}
 			backup.Status.Details = append(backup.Status.Details, currentBackupDetails)
 		}
 	}
 
-	// Determine whether to requeue the reconciliation based on the completion status of all Velero backup resources.
-	// If all backups are complete, exit directly without requeuing.
+	// Determine whether to requeue the reconciliation based on the phase aggregated from all Velero backup resources.
+	// If all backups are finished, exit directly without requeuing.
 	// Otherwise, requeue the reconciliation after a specified interval (StatusSyncInterval).
-	if allBackupsCompleted(backup.Status) {
+	updateBackupPhase(backup)
+	if isBackupFinished(backup.Status.Phase) {
 		return ctrl.Result{}, nil
 	} else {
 		return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
@@ -208,7 +227,7 @@ func (b *BackupManager) reconcileOneTimeBackupStatus(ctx context.Context, backup
 
 // reconcileScheduleBackupStatus manages the status synchronization for scheduled Backup objects.
 // If the backup type is "schedule", new backups will be continuously generated, hence the status synchronization will be executed continuously.
-func (b *BackupManager) reconcileScheduleBackupStatus(ctx context.Context, schedule *backupapi.Backup, destinationClusters map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster, statusMap map[string]*backupapi.BackupDetails) (ctrl.Result, error) {
+func (b *BackupManager) reconcileScheduleBackupStatus(ctx context.Context, schedule *backupapi.Backup, destinationClusters map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Loop through each target cluster to retrieve the status of Velero backup created by schedule resources using the client associated with the respective target cluster.
@@ -230,46 +249,84 @@ func (b *BackupManager) reconcileScheduleBackupStatus(ctx context.Context, sched
 			return ctrl.Result{}, listErr
 		}
 
-		// Fetch most recent completed backup
-		veleroBackup := MostRecentCompletedBackup(backupList.Items)
-		if len(veleroBackup.Name) == 0 {
+		// Prune the backups exceeding the count to keep
+		if schedule.Spec.Policy != nil && schedule.Spec.Policy.KeepLast != nil {
+			referenced, err := b.referencedVeleroBackups(ctx, schedule, clusterKey.Name, clusterAccess, backupList.Items)
+			if err != nil {
+				log.Error(err, "Unable to fetch the referenced velero backups for velero schedule", "scheduleName", veleroSchedule.Name)
+				return ctrl.Result{}, err
+			}
+			prune := backupsToPrune(backupList.Items, int(*schedule.Spec.Policy.KeepLast), referenced)
+			if err := pruneVeleroBackups(ctx, clusterAccess, prune); err != nil {
+				log.Error(err, "Unable to prune velero backups for velero schedule", "scheduleName", veleroSchedule.Name)
+				return ctrl.Result{}, err
+			}
+		}
+
+		// Fetch the latest backup and the most recent completed backup
+		veleroBackup := velerov1.Backup{}
+		if len(backupList.Items) != 0 {
+			sortBackupsByStartTime(backupList.Items)
+			veleroBackup = backupList.Items[0]
+		} else {
 			// If a schedule backup record cannot be found, the potential reasons are:
 			// 1. The backup task hasn't been triggered by schedule.
 			// 2. An issue occurred, but we can not get information directly from the status of schedules.velero.io
-			log.Info("No completed backups found for schedule", "scheduleName", veleroSchedule.Name)
+			log.Info("No backups found for schedule", "scheduleName", veleroSchedule.Name)
+		}
+		var lastSuccessfulBackupTime *metav1.Time
+		if completed := MostRecentCompletedBackup(backupList.Items); completed.Status.CompletionTimestamp != nil {
+			lastSuccessfulBackupTime = completed.Status.CompletionTimestamp
 		}
 
-		// Sync schedule backup status with most recent complete backup
-		key := fmt.Sprintf("%s-%s-%s", clusterKey.Name, clusterKey.Kind, veleroBackup.Name)
-		if detail, exists := statusMap[key]; exists {
-			// If a matching entry is found, update the existing BackupDetails object with the new status.
-			detail.BackupStatusInCluster = &veleroBackup.Status
-		} else {
-			// If no matching entry is found, create a new BackupDetails object and append it to the schedule's status details.
-			currentBackupDetails := &backupapi.BackupDetails{
-				ClusterName:           clusterKey.Name,
-				ClusterKind:           clusterKey.Kind,
-				BackupNameInCluster:   veleroBackup.Name,
-				BackupStatusInCluster: &veleroBackup.Status,
+		var volumes []*backupapi.VolumeBackupDetails
+		if len(veleroBackup.Name) != 0 {
+			volumes, err = listVolumeBackupDetails(ctx, clusterAccess, veleroBackup.Name)
+			if err != nil {
+				log.Error(err, "Unable to list volume backups", "backupName", veleroBackup.Name)
+				return ctrl.Result{}, err
 			}
-			schedule.Status.Details = append(schedule.Status.Details, currentBackupDetails)
 		}
+
+		// Sync schedule backup status with the latest backup, there is only one entry for each cluster.
+		detail := scheduleBackupDetails(schedule, clusterKey)
+		detail.BackupNameInCluster = veleroBackup.Name
+		detail.BackupStatusInCluster = &veleroBackup.Status
+		detail.LastSuccessfulBackupTime = lastSuccessfulBackupTime
+		detail.Volumes = volumes
 	}
 
-	if allBackupsCompleted(schedule.Status) {
+	updateBackupPhase(schedule)
+	if isBackupFinished(schedule.Status.Phase) {
 		// Get the next reconcile interval
 		cronInterval, err := GetCronInterval(schedule.Spec.Schedule)
 		if err != nil {
 			log.Error(err, "failed to get cron Interval of backup.spec.schedule", "backupName", schedule.Name, "cronExpression", schedule.Spec.Schedule)
 			return ctrl.Result{}, err
 		}
-		// If all backups are complete,requeue the reconciliation after a long cronInterval.
+		// If all the latest backups are finished, requeue the reconciliation after a long cronInterval.
 		return ctrl.Result{RequeueAfter: cronInterval}, nil
 	}
-	// If not all backups are complete, requeue the reconciliation after a short StatusSyncInterval.
+	// If not all backups are finished, requeue the reconciliation after a short StatusSyncInterval.
 	return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
 }
 
+// scheduleBackupDetails returns the backup details of the cluster for a scheduled backup, which is added if not found.
+func scheduleBackupDetails(schedule *backupapi.Backup, clusterKey fleetmanager.ClusterKey) *backupapi.BackupDetails {
+	for _, detail := range schedule.Status.Details {
+		if detail.ClusterName == clusterKey.Name && detail.ClusterKind == clusterKey.Kind {
+			return detail
+		}
+	}
+
+	detail := &backupapi.BackupDetails{
+		ClusterName: clusterKey.Name,
+		ClusterKind: clusterKey.Kind,
+	}
+	schedule.Status.Details = append(schedule.Status.Details, detail)
+	return detail
+}
+
 // reconcileDeleteBackup handles the deletion process of a Backup object.
 func (b *BackupManager) reconcileDeleteBackup(ctx context.Context, backup *backupapi.Backup) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -302,3 +359,24 @@ func (b *BackupManager) reconcileDeleteBackup(ctx context.Context, backup *backu
 	log.Info("Delete Backup successful")
 	return ctrl.Result{}, nil
 }
+
+// referencedVeleroBackups returns the names of the velero backups of the schedule in the cluster which are still in use,
+// they are selected by the Restores and BackupVerifications in the namespace of the schedule,
+// or being restored by the velero restores in the cluster, e.g. the ones created for Restores and Migrates.
+func (b *BackupManager) referencedVeleroBackups(ctx context.Context, schedule *backupapi.Backup, clusterName string,
+	clusterAccess *fleetmanager.FleetCluster, backups []velerov1.Backup) (sets.Set[string], error) {
+	restores := &backupapi.RestoreList{}
+	if err := b.Client.List(ctx, restores, client.InNamespace(schedule.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list restores: %w", err)
+	}
+	verifications := &backupapi.BackupVerificationList{}
+	if err := b.Client.List(ctx, verifications, client.InNamespace(schedule.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list backup verifications: %w", err)
+	}
+	veleroRestores := &velerov1.RestoreList{}
+	if err := clusterAccess.GetRuntimeClient().List(ctx, veleroRestores, client.InNamespace(VeleroNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list velero restores: %w", err)
+	}
+
+	return referencedBackupNames(schedule.Name, clusterName, backups, restores.Items, verifications.Items, veleroRestores.Items), nil
+}
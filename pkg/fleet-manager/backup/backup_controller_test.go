@@ -46,7 +46,7 @@ func setupTest(t *testing.T) *BackupManager {
 		t.Fatalf("Failed to add fleetapi to scheme: %v", err)
 	}
 
-	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	client := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&backupapi.Backup{}).Build()
 
 	mgr := &BackupManager{Client: client, Scheme: scheme}
 
@@ -23,6 +23,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -91,10 +92,51 @@ func (m *MigrateManager) reconcileMigrate(ctx context.Context, migrate *backupap
 	// Update the migrate phase
 	phase := migrate.Status.Phase
 	if len(phase) == 0 || phase == backupapi.MigratePhasePending {
+		if err := validateMigrateCutover(migrate); err != nil {
+			log.Error(err, "Invalid cutover policy of migrate")
+			migrate.Status.Phase = backupapi.MigratePhaseFailedValidation
+			migrate.Status.CutoverStatus = &backupapi.CutoverStatus{Message: err.Error()}
+			return ctrl.Result{}, nil
+		}
+		shared, err := m.validateMigrateBackupStorage(ctx, migrate)
+		if err != nil {
+			log.Error(err, "Failed to validate backup storage of migrate")
+			return ctrl.Result{}, err
+		}
+		if !shared {
+			if migrate.Status.Phase == backupapi.MigratePhaseFailedValidation {
+				return ctrl.Result{}, nil
+			}
+			// the velero may be not installed yet
+			migrate.Status.Phase = backupapi.MigratePhasePending
+			return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+		}
 		migrate.Status.Phase = backupapi.MigratePhaseBackupInProgress
 		log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseBackupInProgress)
 	}
 
+	switch phase {
+	case backupapi.MigratePhaseFailedValidation:
+		return ctrl.Result{}, nil
+	case backupapi.MigratePhaseCutoverInProgress, backupapi.MigratePhaseVerifying:
+		// the source workloads may be quiesced already, roll them back if the cutover policy is gone or broken
+		if migrateCutover(migrate) == nil {
+			return m.failCutover(ctx, migrate, "cutover policy is removed during the cutover")
+		}
+		if err := validateMigrateCutover(migrate); err != nil {
+			return m.failCutover(ctx, migrate, fmt.Sprintf("invalid cutover policy: %v", err))
+		}
+		if phase == backupapi.MigratePhaseVerifying {
+			return m.withCutoverTimeout(ctx, migrate)(m.reconcileMigrateVerify(ctx, migrate))
+		}
+		return m.withCutoverTimeout(ctx, migrate)(m.reconcileMigrateCutover(ctx, migrate))
+	case backupapi.MigratePhaseCompleted, backupapi.MigratePhaseFailed, backupapi.MigratePhaseRolledBack:
+		// the source workloads may be quiesced or deleted, so the migration is not synced again after the cutover
+		if migrateCutover(migrate) != nil {
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// The actual migration operation can be divided into two stages
 	// 1.the backup stage
 	result, err := m.reconcileMigrateBackup(ctx, migrate)
@@ -106,12 +148,58 @@ func (m *MigrateManager) reconcileMigrate(ctx context.Context, migrate *backupap
 	return m.reconcileMigrateRestore(ctx, migrate)
 }
 
+// validateMigrateBackupStorage ensures the velero of the target clusters shares the backup storage with the source cluster,
+// otherwise the backup of the source cluster would never be synced to the target clusters.
+// The migrate is marked FailedValidation if the backup storage mismatches.
+func (m *MigrateManager) validateMigrateBackupStorage(ctx context.Context, migrate *backupapi.Migrate) (bool, error) {
+	sourceClusterKey, sourceClusterAccess, err := m.fetchMigrateSourceCluster(ctx, migrate)
+	if err != nil {
+		return false, fmt.Errorf("fetching source cluster: %w", err)
+	}
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
+	if err != nil {
+		return false, fmt.Errorf("fetching target clusters: %w", err)
+	}
+
+	sourceLocation, err := defaultBackupStorageLocation(ctx, sourceClusterAccess.GetRuntimeClient())
+	if err != nil {
+		return false, err
+	}
+	if sourceLocation == nil {
+		conditions.MarkFalse(migrate, backupapi.BackupStorageSharedCondition, backupapi.BackupStorageNotFoundReason, capiv1.ConditionSeverityWarning,
+			"default backup storage location is not found in source cluster %s", sourceClusterKey.Name)
+		return false, nil
+	}
+	sourceStorage := backupStorageOf(sourceLocation)
+
+	for clusterKey, clusterAccess := range targetClusters {
+		location, err := defaultBackupStorageLocation(ctx, clusterAccess.GetRuntimeClient())
+		if err != nil {
+			return false, err
+		}
+		if location == nil {
+			conditions.MarkFalse(migrate, backupapi.BackupStorageSharedCondition, backupapi.BackupStorageNotFoundReason, capiv1.ConditionSeverityWarning,
+				"default backup storage location is not found in target cluster %s", clusterKey.Name)
+			return false, nil
+		}
+		if storage := backupStorageOf(location); storage != sourceStorage {
+			conditions.MarkFalse(migrate, backupapi.BackupStorageSharedCondition, backupapi.BackupStorageMismatchReason, capiv1.ConditionSeverityError,
+				"target cluster %s uses backup storage %s, which differs from %s of source cluster %s", clusterKey.Name, storage, sourceStorage, sourceClusterKey.Name)
+			migrate.Status.Phase = backupapi.MigratePhaseFailedValidation
+			return false, nil
+		}
+	}
+
+	conditions.MarkTrue(migrate, backupapi.BackupStorageSharedCondition)
+	return true, nil
+}
+
 // reconcileMigrateBackup reconcile the backup process during migration.
 func (m *MigrateManager) reconcileMigrateBackup(ctx context.Context, migrate *backupapi.Migrate) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Fetch details of the source cluster for migration
-	fleetClusters, err := fetchDestinationClusters(ctx, m.Client, migrate.Namespace, migrate.Spec.SourceCluster)
+	fleetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.SourceCluster)
 	if err != nil {
 		log.Error(err, "Failed to fetch source cluster for migration")
 		return ctrl.Result{}, fmt.Errorf("fetching source cluster: %w", err)
@@ -166,7 +254,7 @@ func (m *MigrateManager) reconcileMigrateBackup(ctx context.Context, migrate *ba
 func (m *MigrateManager) reconcileMigrateRestore(ctx context.Context, migrate *backupapi.Migrate) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	targetClusters, err := fetchDestinationClusters(ctx, m.Client, migrate.Namespace, migrate.Spec.TargetClusters)
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
 	if err != nil {
 		log.Error(err, "Failed to fetch target clusters for migration")
 		return ctrl.Result{}, fmt.Errorf("fetching target clusters: %w", err)
@@ -225,6 +313,19 @@ func (m *MigrateManager) reconcileMigrateRestore(ctx context.Context, migrate *b
 	}
 
 	if allRestoreCompleted(migrate.Status.TargetClustersStatus) {
+		if migrateCutover(migrate) != nil {
+			// the restored workloads are kept quiesced until the cutover verifies them,
+			// so that they do not run together with the source workloads
+			for clusterKey, clusterAccess := range targetClusters {
+				if _, err := quiesceWorkloads(ctx, clusterAccess.GetRuntimeClient(), targetResourceFilter(migrate.Spec.Policy)); err != nil {
+					log.Error(err, "Failed to quiesce restored workloads", "cluster", clusterKey.Name)
+					return ctrl.Result{}, fmt.Errorf("quiescing restored workloads: %w", err)
+				}
+			}
+			migrate.Status.Phase = backupapi.MigratePhaseCutoverInProgress
+			log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseCutoverInProgress)
+			return m.withCutoverTimeout(ctx, migrate)(m.reconcileMigrateCutover(ctx, migrate))
+		}
 		migrate.Status.Phase = backupapi.MigratePhaseCompleted
 		log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseCompleted)
 		return ctrl.Result{}, nil
@@ -245,13 +346,22 @@ func (m *MigrateManager) reconcileDeleteMigrate(ctx context.Context, migrate *ba
 	}()
 
 	// Fetch source clusters
-	sourceCluster, err := fetchDestinationClusters(ctx, m.Client, migrate.Namespace, migrate.Spec.SourceCluster)
+	sourceCluster, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.SourceCluster)
 	if err != nil {
 		log.Error(err, "Failed to fetch source clusters when delete migrate")
 		shouldRemoveFinalizer = true
 		return ctrl.Result{}, err
 	}
 
+	// Switch the workloads back to the source, as the interrupted cutover would never resume them
+	if phase := migrate.Status.Phase; (phase == backupapi.MigratePhaseCutoverInProgress || phase == backupapi.MigratePhaseVerifying) &&
+		migrate.Status.CutoverStatus != nil {
+		if err := m.rollbackCutover(ctx, migrate); err != nil {
+			log.Error(err, "Failed to roll back the cutover during migrate deletion")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Delete related velero backup instance
 	backupList := &velerov1.BackupList{}
 	err = deleteResourcesInClusters(ctx, VeleroNamespace, MigrateNameLabel, migrate.Name, sourceCluster, backupList)
@@ -261,7 +371,7 @@ func (m *MigrateManager) reconcileDeleteMigrate(ctx context.Context, migrate *ba
 	}
 
 	// Fetch target clusters
-	targetClusters, err := fetchDestinationClusters(ctx, m.Client, migrate.Namespace, migrate.Spec.TargetClusters)
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
 	if err != nil {
 		log.Error(err, "Failed to fetch target clusters when delete migrate")
 		shouldRemoveFinalizer = true
@@ -0,0 +1,593 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+	http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+	"kurator.dev/kurator/pkg/webhooks"
+)
+
+const (
+	// OriginalReplicasAnnotation records the replicas of a workload before it is quiesced by the cutover of a migrate.
+	// The annotation is carried to the target clusters by the final backup, and removed once the workload is scaled back.
+	OriginalReplicasAnnotation = "kurator.dev/migrate-original-replicas"
+
+	// finalBackupSuffix is the suffix of the velero backup and restores of the final incremental backup.
+	finalBackupSuffix = "-final"
+)
+
+// migrateCutover returns the cutover policy of the migrate, it is nil if the cutover is not enabled.
+func migrateCutover(migrate *backupapi.Migrate) *backupapi.MigrateCutover {
+	if migrate.Spec.Policy == nil {
+		return nil
+	}
+	return migrate.Spec.Policy.Cutover
+}
+
+// validateMigrateCutover ensures the cutover only touches the workloads in explicitly included namespaces.
+func validateMigrateCutover(migrate *backupapi.Migrate) error {
+	return webhooks.ValidateMigrateCutover(migrate.Spec.Policy, field.NewPath("spec", "policy")).ToAggregate()
+}
+
+// reconcileMigrateCutover quiesces the source workloads, then takes a final incremental backup and restores it into the target clusters.
+func (m *MigrateManager) reconcileMigrateCutover(ctx context.Context, migrate *backupapi.Migrate) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if migrate.Status.CutoverStatus == nil {
+		now := metav1.Now()
+		migrate.Status.CutoverStatus = &backupapi.CutoverStatus{StartTime: &now}
+	}
+	cutoverStatus := migrate.Status.CutoverStatus
+
+	sourceClusterKey, sourceClusterAccess, err := m.fetchMigrateSourceCluster(ctx, migrate)
+	if err != nil {
+		log.Error(err, "Failed to fetch source cluster for cutover")
+		return ctrl.Result{}, fmt.Errorf("fetching source cluster: %w", err)
+	}
+
+	// 1. quiesce the source workloads
+	workloads, err := quiesceWorkloads(ctx, sourceClusterAccess.GetRuntimeClient(), migrate.Spec.Policy.ResourceFilter)
+	if err != nil {
+		log.Error(err, "Failed to quiesce source workloads for cutover")
+		return ctrl.Result{}, fmt.Errorf("quiescing source workloads: %w", err)
+	}
+	cutoverStatus.QuiescedWorkloads = workloads
+	conditions.MarkTrue(migrate, backupapi.SourceQuiescedCondition)
+
+	// 2. take the final incremental backup of the quiesced source
+	migrateLabel := generateVeleroInstanceLabel(MigrateNameLabel, migrate.Name, migrate.Spec.SourceCluster.Fleet)
+	finalBackupName := generateVeleroResourceName(sourceClusterKey.Name, MigrateKind, migrate.Namespace, migrate.Name) + finalBackupSuffix
+	finalBackup := buildVeleroBackupFromMigrate(&migrate.Spec, migrateLabel, finalBackupName)
+	if err = syncVeleroObj(ctx, sourceClusterAccess, finalBackup); err != nil {
+		log.Error(err, "Failed to create final backup for cutover", "backupName", finalBackupName)
+		return ctrl.Result{}, fmt.Errorf("creating final backup: %w", err)
+	}
+	veleroBackup := &velerov1.Backup{}
+	if err = getResourceFromClusterClient(ctx, finalBackupName, VeleroNamespace, *sourceClusterAccess, veleroBackup); err != nil {
+		return ctrl.Result{}, fmt.Errorf("retrieving final backup status: %w", err)
+	}
+	cutoverStatus.FinalBackup = &backupapi.BackupDetails{
+		ClusterName:           sourceClusterKey.Name,
+		ClusterKind:           sourceClusterKey.Kind,
+		BackupNameInCluster:   veleroBackup.Name,
+		BackupStatusInCluster: &veleroBackup.Status,
+	}
+	switch backupPhaseOf(&veleroBackup.Status) {
+	case backupapi.BackupPhaseCompleted:
+	case backupapi.BackupPhaseFailed, backupapi.BackupPhasePartiallyFailed:
+		return m.failCutover(ctx, migrate, fmt.Sprintf("final backup %s is %s", finalBackupName, veleroBackup.Status.Phase))
+	default:
+		if cutoverTimedOut(migrate, time.Now()) {
+			return m.failCutover(ctx, migrate, fmt.Sprintf("timed out waiting for final backup %s", finalBackupName))
+		}
+		return ctrl.Result{RequeueAfter: fleetmanager.RequeueAfter}, nil
+	}
+
+	// 3. restore the final backup into the target clusters, updating the resources restored before
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
+	if err != nil {
+		log.Error(err, "Failed to fetch target clusters for cutover")
+		return ctrl.Result{}, fmt.Errorf("fetching target clusters: %w", err)
+	}
+	restoreLabel := generateVeleroInstanceLabel(MigrateNameLabel, migrate.Name, migrate.Spec.TargetClusters.Fleet)
+	var restoreDetails []*backupapi.RestoreDetails
+	for clusterKey, clusterAccess := range targetClusters {
+		// Ensure the final backup has been sync to current cluster
+		referredVeleroBackup := &velerov1.Backup{}
+		if err = getResourceFromClusterClient(ctx, finalBackupName, VeleroNamespace, *clusterAccess, referredVeleroBackup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ctrl.Result{RequeueAfter: fleetmanager.RequeueAfter}, nil
+			}
+			return ctrl.Result{}, err
+		}
+
+		veleroRestoreName := generateVeleroResourceName(clusterKey.Name, MigrateKind, migrate.Namespace, migrate.Name) + finalBackupSuffix
+		veleroRestore := buildVeleroRestoreFromMigrate(&migrate.Spec, restoreLabel, finalBackupName, veleroRestoreName)
+		veleroRestore.Spec.ExistingResourcePolicy = velerov1.PolicyTypeUpdate
+		if err = syncVeleroObj(ctx, clusterAccess, veleroRestore); err != nil {
+			log.Error(err, "Failed to create final restore for cutover", "restoreName", veleroRestoreName)
+			return ctrl.Result{}, fmt.Errorf("creating final restore: %w", err)
+		}
+		if err = getResourceFromClusterClient(ctx, veleroRestoreName, VeleroNamespace, *clusterAccess, veleroRestore); err != nil {
+			return ctrl.Result{}, fmt.Errorf("retrieving final restore status: %w", err)
+		}
+		restoreDetails = append(restoreDetails, &backupapi.RestoreDetails{
+			ClusterName:            clusterKey.Name,
+			ClusterKind:            clusterKey.Kind,
+			RestoreNameInCluster:   veleroRestore.Name,
+			RestoreStatusInCluster: &veleroRestore.Status,
+		})
+	}
+	sort.Slice(restoreDetails, func(i, j int) bool {
+		return restoreDetails[i].ClusterName < restoreDetails[j].ClusterName
+	})
+	cutoverStatus.FinalRestores = restoreDetails
+
+	finished, failure := finalRestoresFinished(restoreDetails)
+	if failure != "" {
+		return m.failCutover(ctx, migrate, failure)
+	}
+	if !finished {
+		if cutoverTimedOut(migrate, time.Now()) {
+			return m.failCutover(ctx, migrate, "timed out waiting for final restores")
+		}
+		return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+	}
+
+	migrate.Status.Phase = backupapi.MigratePhaseVerifying
+	log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseVerifying)
+	return m.reconcileMigrateVerify(ctx, migrate)
+}
+
+// reconcileMigrateVerify scales the target workloads to the original replicas and verifies they are ready.
+func (m *MigrateManager) reconcileMigrateVerify(ctx context.Context, migrate *backupapi.Migrate) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	cutoverStatus := migrate.Status.CutoverStatus
+
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
+	if err != nil {
+		log.Error(err, "Failed to fetch target clusters for cutover")
+		return ctrl.Result{}, fmt.Errorf("fetching target clusters: %w", err)
+	}
+
+	var checks []*backupapi.WorkloadCheck
+	for clusterKey, clusterAccess := range targetClusters {
+		for _, workload := range cutoverStatus.QuiescedWorkloads {
+			namespace := workload.Namespace
+			if mapped, ok := migrate.Spec.Policy.NamespaceMapping[namespace]; ok {
+				namespace = mapped
+			}
+			check, err := resumeWorkload(ctx, clusterAccess.GetRuntimeClient(), workload, namespace)
+			if err != nil {
+				log.Error(err, "Failed to resume target workload", "cluster", clusterKey.Name, "kind", workload.Kind, "name", workload.Name)
+				return ctrl.Result{}, err
+			}
+			check.Cluster = clusterKey.Name
+			checks = append(checks, check)
+		}
+	}
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Cluster < checks[j].Cluster
+	})
+	cutoverStatus.Checks = checks
+
+	var notReady []string
+	for _, check := range checks {
+		if !check.Ready {
+			notReady = append(notReady, fmt.Sprintf("%s %s/%s in cluster %s", check.Kind, check.Namespace, check.Name, check.Cluster))
+		}
+	}
+	if len(notReady) != 0 {
+		message := fmt.Sprintf("workloads are not ready: %s", strings.Join(notReady, ", "))
+		if cutoverTimedOut(migrate, time.Now()) {
+			return m.failCutover(ctx, migrate, message)
+		}
+		cutoverStatus.Message = message
+		return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+	}
+
+	if migrateCutover(migrate).DeleteSource {
+		_, sourceClusterAccess, err := m.fetchMigrateSourceCluster(ctx, migrate)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("fetching source cluster: %w", err)
+		}
+		if err := deleteQuiescedWorkloads(ctx, sourceClusterAccess.GetRuntimeClient(), cutoverStatus.QuiescedWorkloads); err != nil {
+			log.Error(err, "Failed to delete source workloads after cutover")
+			return ctrl.Result{}, err
+		}
+	}
+
+	cutoverStatus.Message = ""
+	conditions.MarkTrue(migrate, backupapi.TargetVerifiedCondition)
+	migrate.Status.Phase = backupapi.MigratePhaseCompleted
+	log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseCompleted)
+	return ctrl.Result{}, nil
+}
+
+// failCutover records the failure of the cutover, and switches the workloads back to the source if rollback is enabled.
+func (m *MigrateManager) failCutover(ctx context.Context, migrate *backupapi.Migrate, message string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if migrate.Status.CutoverStatus == nil {
+		migrate.Status.CutoverStatus = &backupapi.CutoverStatus{}
+	}
+	migrate.Status.CutoverStatus.Message = message
+	conditions.MarkFalse(migrate, backupapi.TargetVerifiedCondition, backupapi.CutoverFailedReason, capiv1.ConditionSeverityError, message)
+
+	// roll back by default, also when the cutover policy is removed during the cutover
+	if cutover := migrateCutover(migrate); cutover != nil && cutover.Rollback != nil && !*cutover.Rollback {
+		migrate.Status.Phase = backupapi.MigratePhaseFailed
+		log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseFailed, "reason", message)
+		return ctrl.Result{}, nil
+	}
+
+	if err := m.rollbackCutover(ctx, migrate); err != nil {
+		log.Error(err, "Failed to roll back the cutover")
+		return ctrl.Result{}, err
+	}
+	conditions.MarkFalse(migrate, backupapi.SourceQuiescedCondition, backupapi.CutoverRolledBackReason, capiv1.ConditionSeverityWarning, message)
+	migrate.Status.Phase = backupapi.MigratePhaseRolledBack
+	log.Info("Migrate Phase changes", "phase", backupapi.MigratePhaseRolledBack, "reason", message)
+	return ctrl.Result{}, nil
+}
+
+// rollbackCutover scales the migrated workloads in the target clusters to zero, then scales the source workloads back,
+// so that the workloads never run in both the source and the target clusters.
+func (m *MigrateManager) rollbackCutover(ctx context.Context, migrate *backupapi.Migrate) error {
+	sourceClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.SourceCluster)
+	if err != nil {
+		return fmt.Errorf("fetching source cluster: %w", err)
+	}
+	targetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.TargetClusters)
+	if err != nil {
+		return fmt.Errorf("fetching target clusters: %w", err)
+	}
+
+	var sources, targets []client.Client
+	for _, clusterAccess := range sourceClusters {
+		sources = append(sources, clusterAccess.GetRuntimeClient())
+	}
+	for _, clusterAccess := range targetClusters {
+		targets = append(targets, clusterAccess.GetRuntimeClient())
+	}
+	return rollbackCutoverWorkloads(ctx, sources, targets, migrate)
+}
+
+// rollbackCutoverWorkloads quiesces the migrated workloads in the target clusters and resumes the quiesced source workloads.
+func rollbackCutoverWorkloads(ctx context.Context, sources, targets []client.Client, migrate *backupapi.Migrate) error {
+	if migrate.Status.CutoverStatus == nil {
+		return nil
+	}
+	workloads := migrate.Status.CutoverStatus.QuiescedWorkloads
+
+	var namespaceMapping map[string]string
+	if migrate.Spec.Policy != nil {
+		namespaceMapping = migrate.Spec.Policy.NamespaceMapping
+	}
+	for _, c := range targets {
+		if err := quiesceMigratedWorkloads(ctx, c, workloads, namespaceMapping); err != nil {
+			return fmt.Errorf("quiescing target workloads: %w", err)
+		}
+	}
+	for _, c := range sources {
+		if err := rollbackQuiescedWorkloads(ctx, c, workloads); err != nil {
+			return fmt.Errorf("rolling back source workloads: %w", err)
+		}
+	}
+	return nil
+}
+
+// withCutoverTimeout returns a function bounding the result of a cutover step by the cutover timeout.
+// A step still waiting or failing after the timeout fails the cutover, so that the source workloads are never left quiesced.
+func (m *MigrateManager) withCutoverTimeout(ctx context.Context, migrate *backupapi.Migrate) func(ctrl.Result, error) (ctrl.Result, error) {
+	return func(result ctrl.Result, err error) (ctrl.Result, error) {
+		if err == nil && result.IsZero() {
+			return result, nil
+		}
+		if phase := migrate.Status.Phase; phase != backupapi.MigratePhaseCutoverInProgress && phase != backupapi.MigratePhaseVerifying {
+			return result, err
+		}
+		if !cutoverTimedOut(migrate, time.Now()) {
+			return result, err
+		}
+
+		message := "timed out waiting for the cutover"
+		if err != nil {
+			message = fmt.Sprintf("%s: %v", message, err)
+		}
+		return m.failCutover(ctx, migrate, message)
+	}
+}
+
+// fetchMigrateSourceCluster returns the only source cluster of the migrate.
+func (m *MigrateManager) fetchMigrateSourceCluster(ctx context.Context, migrate *backupapi.Migrate) (fleetmanager.ClusterKey, *fleetmanager.FleetCluster, error) {
+	fleetClusters, err := fetchMigrateClusters(ctx, m.Client, migrate, migrate.Spec.SourceCluster)
+	if err != nil {
+		return fleetmanager.ClusterKey{}, nil, err
+	}
+	// "migrate.Spec.SourceCluster" must contain one clusters, it is ensured by admission webhook
+	for key, value := range fleetClusters {
+		return key, value, nil
+	}
+	return fleetmanager.ClusterKey{}, nil, fmt.Errorf("source cluster of migrate %s is not found", migrate.Name)
+}
+
+func cutoverTimedOut(migrate *backupapi.Migrate, now time.Time) bool {
+	if migrate.Status.CutoverStatus == nil || migrate.Status.CutoverStatus.StartTime == nil {
+		return false
+	}
+	timeout := backupapi.DefaultMigrateCutoverTimeout
+	if cutover := migrateCutover(migrate); cutover != nil && cutover.Timeout != nil {
+		timeout = cutover.Timeout.Duration
+	}
+	return now.Sub(migrate.Status.CutoverStatus.StartTime.Time) > timeout
+}
+
+// finalRestoresFinished returns whether all the final restores are finished, and the failure if any of them failed.
+// A partially failed restore is tolerated, because the readiness of the target workloads is verified afterwards.
+func finalRestoresFinished(details []*backupapi.RestoreDetails) (bool, string) {
+	finished := true
+	for _, detail := range details {
+		if detail.RestoreStatusInCluster == nil {
+			finished = false
+			continue
+		}
+		switch detail.RestoreStatusInCluster.Phase {
+		case velerov1.RestorePhaseCompleted, velerov1.RestorePhasePartiallyFailed:
+		case velerov1.RestorePhaseFailed, velerov1.RestorePhaseFailedValidation:
+			return false, fmt.Sprintf("final restore %s in cluster %s is %s", detail.RestoreNameInCluster, detail.ClusterName, detail.RestoreStatusInCluster.Phase)
+		default:
+			finished = false
+		}
+	}
+	return finished, ""
+}
+
+// quiesceWorkloads scales the Deployments and StatefulSets selected by the resource filter to zero,
+// and records the original replicas in the OriginalReplicasAnnotation of each workload.
+// It is idempotent, the workloads quiesced before are returned with the recorded replicas.
+func quiesceWorkloads(ctx context.Context, c client.Client, filter *backupapi.ResourceFilter) ([]*backupapi.QuiescedWorkload, error) {
+	selector := labels.Everything()
+	if filter.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(filter.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+	excluded := sets.New[string](filter.ExcludedNamespaces...)
+
+	var workloads []*backupapi.QuiescedWorkload
+	for _, namespace := range filter.IncludedNamespaces {
+		if excluded.Has(namespace) {
+			continue
+		}
+
+		deployments := &appsv1.DeploymentList{}
+		if err := c.List(ctx, deployments, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			replicas, err := quiesceWorkload(ctx, c, deployment, &deployment.Spec.Replicas)
+			if err != nil {
+				return nil, err
+			}
+			workloads = append(workloads, &backupapi.QuiescedWorkload{Kind: "Deployment", Namespace: namespace, Name: deployment.Name, Replicas: replicas})
+		}
+
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := c.List(ctx, statefulSets, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for i := range statefulSets.Items {
+			statefulSet := &statefulSets.Items[i]
+			replicas, err := quiesceWorkload(ctx, c, statefulSet, &statefulSet.Spec.Replicas)
+			if err != nil {
+				return nil, err
+			}
+			workloads = append(workloads, &backupapi.QuiescedWorkload{Kind: "StatefulSet", Namespace: namespace, Name: statefulSet.Name, Replicas: replicas})
+		}
+	}
+	return workloads, nil
+}
+
+// quiesceWorkload scales the workload to zero and returns its original replicas.
+func quiesceWorkload(ctx context.Context, c client.Client, obj client.Object, replicas **int32) (int32, error) {
+	original, quiesced := originalReplicas(obj)
+	if !quiesced {
+		original = 1
+		if *replicas != nil {
+			original = **replicas
+		}
+	}
+	if quiesced && *replicas != nil && **replicas == 0 {
+		return original, nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[OriginalReplicasAnnotation] = strconv.Itoa(int(original))
+	obj.SetAnnotations(annotations)
+	zero := int32(0)
+	*replicas = &zero
+	return original, c.Update(ctx, obj)
+}
+
+// originalReplicas returns the replicas recorded in the OriginalReplicasAnnotation of the workload.
+func originalReplicas(obj client.Object) (int32, bool) {
+	value, ok := obj.GetAnnotations()[OriginalReplicasAnnotation]
+	if !ok {
+		return 0, false
+	}
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(replicas), true
+}
+
+// resumeWorkload scales the migrated workload in the namespace back to the original replicas, and checks whether it is ready.
+func resumeWorkload(ctx context.Context, c client.Client, workload *backupapi.QuiescedWorkload, namespace string) (*backupapi.WorkloadCheck, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: workload.Name}
+	notFound := &backupapi.WorkloadCheck{Kind: workload.Kind, Namespace: namespace, Name: workload.Name, Message: "workload is not found"}
+
+	switch workload.Kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, key, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				return notFound, nil
+			}
+			return nil, err
+		}
+		if err := scaleWorkload(ctx, c, deployment, &deployment.Spec.Replicas, workload.Replicas); err != nil {
+			return nil, err
+		}
+		return checkDeployment(deployment), nil
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		if err := c.Get(ctx, key, statefulSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return notFound, nil
+			}
+			return nil, err
+		}
+		if err := scaleWorkload(ctx, c, statefulSet, &statefulSet.Spec.Replicas, workload.Replicas); err != nil {
+			return nil, err
+		}
+		return checkStatefulSet(statefulSet), nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s", workload.Kind)
+	}
+}
+
+// targetResourceFilter returns the resource filter selecting the migrated workloads in the target clusters,
+// the namespaces are mapped by the namespace mapping of the migrate.
+func targetResourceFilter(policy *backupapi.MigratePolicy) *backupapi.ResourceFilter {
+	if policy.ResourceFilter == nil {
+		return &backupapi.ResourceFilter{}
+	}
+	filter := policy.ResourceFilter.DeepCopy()
+	mapNamespaces := func(namespaces []string) []string {
+		mapped := make([]string, 0, len(namespaces))
+		for _, namespace := range namespaces {
+			if target, ok := policy.NamespaceMapping[namespace]; ok {
+				namespace = target
+			}
+			mapped = append(mapped, namespace)
+		}
+		return mapped
+	}
+	filter.IncludedNamespaces = mapNamespaces(filter.IncludedNamespaces)
+	filter.ExcludedNamespaces = mapNamespaces(filter.ExcludedNamespaces)
+	return filter
+}
+
+// quiesceMigratedWorkloads scales the workloads migrated from the quiesced source workloads to zero,
+// the workloads not found are skipped.
+func quiesceMigratedWorkloads(ctx context.Context, c client.Client, workloads []*backupapi.QuiescedWorkload, namespaceMapping map[string]string) error {
+	for _, workload := range workloads {
+		namespace := workload.Namespace
+		if mapped, ok := namespaceMapping[namespace]; ok {
+			namespace = mapped
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: workload.Name}
+
+		var err error
+		switch workload.Kind {
+		case "Deployment":
+			deployment := &appsv1.Deployment{}
+			if err = c.Get(ctx, key, deployment); err == nil {
+				_, err = quiesceWorkload(ctx, c, deployment, &deployment.Spec.Replicas)
+			}
+		case "StatefulSet":
+			statefulSet := &appsv1.StatefulSet{}
+			if err = c.Get(ctx, key, statefulSet); err == nil {
+				_, err = quiesceWorkload(ctx, c, statefulSet, &statefulSet.Spec.Replicas)
+			}
+		default:
+			continue
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollbackQuiescedWorkloads scales the quiesced workloads back to the original replicas.
+func rollbackQuiescedWorkloads(ctx context.Context, c client.Client, workloads []*backupapi.QuiescedWorkload) error {
+	for _, workload := range workloads {
+		if _, err := resumeWorkload(ctx, c, workload, workload.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaleWorkload scales the workload to the replicas and removes the OriginalReplicasAnnotation.
+// The workload without the annotation has been scaled already, and is left untouched.
+func scaleWorkload(ctx context.Context, c client.Client, obj client.Object, replicas **int32, target int32) error {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[OriginalReplicasAnnotation]; !ok {
+		return nil
+	}
+
+	delete(annotations, OriginalReplicasAnnotation)
+	obj.SetAnnotations(annotations)
+	*replicas = &target
+	return c.Update(ctx, obj)
+}
+
+// deleteQuiescedWorkloads deletes the quiesced workloads in the source cluster.
+func deleteQuiescedWorkloads(ctx context.Context, c client.Client, workloads []*backupapi.QuiescedWorkload) error {
+	for _, workload := range workloads {
+		var obj client.Object
+		objectMeta := metav1.ObjectMeta{Namespace: workload.Namespace, Name: workload.Name}
+		switch workload.Kind {
+		case "Deployment":
+			obj = &appsv1.Deployment{ObjectMeta: objectMeta}
+		case "StatefulSet":
+			obj = &appsv1.StatefulSet{ObjectMeta: objectMeta}
+		default:
+			continue
+		}
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+	http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+func TestValidateMigrateCutover(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    *backupapi.MigratePolicy
+		expectErr bool
+	}{
+		{
+			name:   "no cutover",
+			policy: &backupapi.MigratePolicy{},
+		},
+		{
+			name:      "cutover without included namespaces",
+			policy:    &backupapi.MigratePolicy{Cutover: &backupapi.MigrateCutover{}},
+			expectErr: true,
+		},
+		{
+			name: "cutover with wildcard namespace",
+			policy: &backupapi.MigratePolicy{
+				ResourceFilter: &backupapi.ResourceFilter{IncludedNamespaces: []string{"*"}},
+				Cutover:        &backupapi.MigrateCutover{},
+			},
+			expectErr: true,
+		},
+		{
+			name: "cutover with included namespaces",
+			policy: &backupapi.MigratePolicy{
+				ResourceFilter: &backupapi.ResourceFilter{IncludedNamespaces: []string{"app"}},
+				Cutover:        &backupapi.MigrateCutover{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			migrate := &backupapi.Migrate{Spec: backupapi.MigrateSpec{Policy: tc.policy}}
+			err := validateMigrateCutover(migrate)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFinalRestoresFinished(t *testing.T) {
+	details := func(phases ...velerov1.RestorePhase) []*backupapi.RestoreDetails {
+		var res []*backupapi.RestoreDetails
+		for _, phase := range phases {
+			res = append(res, &backupapi.RestoreDetails{ClusterName: "member", RestoreStatusInCluster: &velerov1.RestoreStatus{Phase: phase}})
+		}
+		return res
+	}
+
+	finished, failure := finalRestoresFinished(details(velerov1.RestorePhaseCompleted, velerov1.RestorePhasePartiallyFailed))
+	assert.True(t, finished)
+	assert.Empty(t, failure)
+
+	finished, failure = finalRestoresFinished(details(velerov1.RestorePhaseCompleted, velerov1.RestorePhaseInProgress))
+	assert.False(t, finished)
+	assert.Empty(t, failure)
+
+	finished, failure = finalRestoresFinished(details(velerov1.RestorePhaseInProgress, velerov1.RestorePhaseFailed))
+	assert.False(t, finished)
+	assert.NotEmpty(t, failure)
+}
+
+func newCutoverTestClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestQuiesceAndRollbackWorkloads(t *testing.T) {
+	ctx := context.Background()
+	c := newCutoverTestClient(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web", Labels: map[string]string{"app": "demo"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(3)},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "db", Labels: map[string]string{"app": "demo"}},
+			Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(2)},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "other"},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1)},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns", Labels: map[string]string{"app": "demo"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+		},
+	)
+	filter := &backupapi.ResourceFilter{
+		IncludedNamespaces: []string{"app"},
+		LabelSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+	}
+
+	expected := []*backupapi.QuiescedWorkload{
+		{Kind: "Deployment", Namespace: "app", Name: "web", Replicas: 3},
+		{Kind: "StatefulSet", Namespace: "app", Name: "db", Replicas: 2},
+	}
+	workloads, err := quiesceWorkloads(ctx, c, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, workloads)
+
+	// quiescing again returns the original replicas recorded in the annotation
+	workloads, err = quiesceWorkloads(ctx, c, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, workloads)
+
+	web := &appsv1.Deployment{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "app", Name: "web"}, web))
+	assert.Equal(t, int32(0), *web.Spec.Replicas)
+	assert.Equal(t, "3", web.Annotations[OriginalReplicasAnnotation])
+
+	untouched := &appsv1.Deployment{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "kube-system", Name: "coredns"}, untouched))
+	assert.Equal(t, int32(2), *untouched.Spec.Replicas)
+
+	assert.NoError(t, rollbackQuiescedWorkloads(ctx, c, workloads))
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "app", Name: "web"}, web))
+	assert.Equal(t, int32(3), *web.Spec.Replicas)
+	assert.NotContains(t, web.Annotations, OriginalReplicasAnnotation)
+
+	db := &appsv1.StatefulSet{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "app", Name: "db"}, db))
+	assert.Equal(t, int32(2), *db.Spec.Replicas)
+}
+
+func TestResumeWorkload(t *testing.T) {
+	ctx := context.Background()
+	c := newCutoverTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app-target", Name: "web", Annotations: map[string]string{OriginalReplicasAnnotation: "3"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(0)},
+	})
+
+	workload := &backupapi.QuiescedWorkload{Kind: "Deployment", Namespace: "app", Name: "web", Replicas: 3}
+	check, err := resumeWorkload(ctx, c, workload, "app-target")
+	assert.NoError(t, err)
+	assert.False(t, check.Ready)
+	assert.Equal(t, "app-target", check.Namespace)
+
+	web := &appsv1.Deployment{}
+	assert.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "app-target", Name: "web"}, web))
+	assert.Equal(t, int32(3), *web.Spec.Replicas)
+	assert.NotContains(t, web.Annotations, OriginalReplicasAnnotation)
+
+	missing := &backupapi.QuiescedWorkload{Kind: "StatefulSet", Namespace: "app", Name: "db", Replicas: 1}
+	check, err = resumeWorkload(ctx, c, missing, "app-target")
+	assert.NoError(t, err)
+	assert.False(t, check.Ready)
+	assert.Equal(t, "workload is not found", check.Message)
+}
+
+func TestWithCutoverTimeout(t *testing.T) {
+	ctx := context.Background()
+	m := &MigrateManager{}
+	newMigrate := func(startTime time.Time) *backupapi.Migrate {
+		return &backupapi.Migrate{
+			Spec: backupapi.MigrateSpec{
+				Policy: &backupapi.MigratePolicy{
+					Cutover: &backupapi.MigrateCutover{Timeout: &metav1.Duration{Duration: time.Minute}, Rollback: pointer.Bool(false)},
+				},
+			},
+			Status: backupapi.MigrateStatus{
+				Phase:         backupapi.MigratePhaseCutoverInProgress,
+				CutoverStatus: &backupapi.CutoverStatus{StartTime: &metav1.Time{Time: startTime}},
+			},
+		}
+	}
+
+	// the waiting step is kept before the timeout
+	migrate := newMigrate(time.Now())
+	result, err := m.withCutoverTimeout(ctx, migrate)(ctrl.Result{RequeueAfter: time.Second}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, result.RequeueAfter)
+	assert.Equal(t, backupapi.MigratePhaseCutoverInProgress, migrate.Status.Phase)
+
+	// the failing step fails the cutover after the timeout
+	migrate = newMigrate(time.Now().Add(-time.Hour))
+	result, err = m.withCutoverTimeout(ctx, migrate)(ctrl.Result{}, errors.New("backup is not found"))
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+	assert.Equal(t, backupapi.MigratePhaseFailed, migrate.Status.Phase)
+	assert.Contains(t, migrate.Status.CutoverStatus.Message, "backup is not found")
+
+	// the finished step is never failed
+	migrate = newMigrate(time.Now().Add(-time.Hour))
+	migrate.Status.Phase = backupapi.MigratePhaseCompleted
+	_, err = m.withCutoverTimeout(ctx, migrate)(ctrl.Result{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, backupapi.MigratePhaseCompleted, migrate.Status.Phase)
+}
+
+func TestTargetResourceFilter(t *testing.T) {
+	policy := &backupapi.MigratePolicy{
+		ResourceFilter: &backupapi.ResourceFilter{
+			IncludedNamespaces: []string{"app", "web"},
+			ExcludedNamespaces: []string{"app-tmp"},
+			LabelSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+		},
+		NamespaceMapping: map[string]string{"app": "app-target", "app-tmp": "app-tmp-target"},
+	}
+
+	filter := targetResourceFilter(policy)
+	assert.Equal(t, []string{"app-target", "web"}, filter.IncludedNamespaces)
+	assert.Equal(t, []string{"app-tmp-target"}, filter.ExcludedNamespaces)
+	assert.Equal(t, policy.ResourceFilter.LabelSelector, filter.LabelSelector)
+	assert.Equal(t, []string{"app", "web"}, policy.ResourceFilter.IncludedNamespaces)
+}
+
+func TestRollbackCutoverWorkloadsAfterVerify(t *testing.T) {
+	ctx := context.Background()
+	// the source workload is quiesced, and the target workload has been resumed by the verification
+	source := newCutoverTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web", Annotations: map[string]string{OriginalReplicasAnnotation: "3"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(0)},
+	})
+	target := newCutoverTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app-target", Name: "web"},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(3)},
+	})
+	migrate := &backupapi.Migrate{
+		Spec: backupapi.MigrateSpec{
+			Policy: &backupapi.MigratePolicy{NamespaceMapping: map[string]string{"app": "app-target"}},
+		},
+		Status: backupapi.MigrateStatus{
+			Phase: backupapi.MigratePhaseVerifying,
+			CutoverStatus: &backupapi.CutoverStatus{
+				QuiescedWorkloads: []*backupapi.QuiescedWorkload{
+					{Kind: "Deployment", Namespace: "app", Name: "web", Replicas: 3},
+					{Kind: "StatefulSet", Namespace: "app", Name: "db", Replicas: 1},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, rollbackCutoverWorkloads(ctx, []client.Client{source}, []client.Client{target}, migrate))
+
+	web := &appsv1.Deployment{}
+	assert.NoError(t, target.Get(ctx, client.ObjectKey{Namespace: "app-target", Name: "web"}, web))
+	assert.Equal(t, int32(0), *web.Spec.Replicas)
+	assert.NoError(t, source.Get(ctx, client.ObjectKey{Namespace: "app", Name: "web"}, web))
+	assert.Equal(t, int32(3), *web.Spec.Replicas)
+	assert.NotContains(t, web.Annotations, OriginalReplicasAnnotation)
+}
@@ -197,7 +197,8 @@ func (r *RestoreManager) reconcileDeleteRestore(ctx context.Context, restore *ba
 
 // getBackupForRestore retrieves the name of the Velero backup associated with the provided restore.
 // If the referred backup is an immediate backup, it returns the generated Velero backup name.
-// If the referred backup is a scheduled backup, it fetches the name of the most recent completed backup.
+// If the referred backup is a scheduled backup, it fetches the name of the backup selected by the point in time of the restore,
+// or the most recent completed backup if the point in time is not set.
 func (r *RestoreManager) getBackupForRestore(ctx context.Context, restore *backupapi.Restore, referredBackup *backupapi.Backup, clusterAccess *fleetmanager.FleetCluster, clusterName, creatorKind, creatorNamespace, creatorName string) (string, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -216,6 +217,19 @@ func (r *RestoreManager) getBackupForRestore(ctx context.Context, restore *backu
 		return "", err
 	}
 
+	if pointInTime := restore.Spec.PointInTime; pointInTime != nil {
+		if name, ok := pointInTime.BackupNames[clusterName]; ok {
+			return selectCompletedBackup(backupList.Items, name)
+		}
+		if pointInTime.Timestamp != nil {
+			veleroBackup := mostRecentCompletedBackupBefore(backupList.Items, pointInTime.Timestamp.Time)
+			if veleroBackup.Name == "" {
+				return "", fmt.Errorf("no completed backups found before %s for referred schedule backup: %s", pointInTime.Timestamp, restore.Spec.BackupName)
+			}
+			return veleroBackup.Name, nil
+		}
+	}
+
 	// Return an error if no completed backups are found for the referred schedule.
 	veleroBackup := MostRecentCompletedBackup(backupList.Items)
 	if veleroBackup.Name == "" {
@@ -226,3 +240,17 @@ func (r *RestoreManager) getBackupForRestore(ctx context.Context, restore *backu
 
 	return veleroBackup.Name, nil
 }
+
+// selectCompletedBackup returns the name of the backup if it is a completed backup of the schedule.
+func selectCompletedBackup(backups []velerov1.Backup, name string) (string, error) {
+	for _, backup := range backups {
+		if backup.Name != name {
+			continue
+		}
+		if backup.Status.Phase != velerov1.BackupPhaseCompleted {
+			return "", fmt.Errorf("backup %s is %s, only completed backups can be restored", name, backup.Status.Phase)
+		}
+		return name, nil
+	}
+	return "", fmt.Errorf("backup %s is not found in the referred schedule backup", name)
+}
@@ -15,18 +15,28 @@ package backup
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/label"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,17 +54,21 @@ const (
 	RestoreNameLabel = "kurator.dev/restore-name"
 	// MigrateNameLabel is the label key used to identify a migrate by name.
 	MigrateNameLabel = "kurator.dev/migrate-name"
+	// VerificationNameLabel is the label key used to identify a backup verification by name.
+	VerificationNameLabel = "kurator.dev/verification-name"
 
-	BackupKind  = "backup"
-	RestoreKind = "restore"
-	MigrateKind = "migrate"
+	BackupKind       = "backup"
+	RestoreKind      = "restore"
+	MigrateKind      = "migrate"
+	VerificationKind = "verification"
 
 	// VeleroNamespace defines the default namespace where all Velero resources are created. It's a constant namespace used by Velero.
 	VeleroNamespace = "velero"
 
-	BackupFinalizer  = "backup.kurator.dev"
-	RestoreFinalizer = "restore.kurator.dev"
-	MigrateFinalizer = "migrate.kurator.dev"
+	BackupFinalizer       = "backup.kurator.dev"
+	RestoreFinalizer      = "restore.kurator.dev"
+	MigrateFinalizer      = "migrate.kurator.dev"
+	VerificationFinalizer = "verification.kurator.dev"
 
 	// StatusSyncInterval specifies the interval for requeueing when synchronizing status. It determines how frequently the status should be checked and updated.
 	StatusSyncInterval = 30 * time.Second
@@ -126,21 +140,79 @@ func buildVeleroBackupSpec(backupPolicy *backupapi.BackupPolicy) velerov1.Backup
 	if backupPolicy == nil {
 		return velerov1.BackupSpec{}
 	}
-	return velerov1.BackupSpec{
-		TTL:                              backupPolicy.TTL,
-		OrderedResources:                 backupPolicy.OrderedResources,
-		IncludedNamespaces:               backupPolicy.ResourceFilter.IncludedNamespaces,
-		ExcludedNamespaces:               backupPolicy.ResourceFilter.ExcludedNamespaces,
-		IncludedResources:                backupPolicy.ResourceFilter.IncludedResources,
-		ExcludedResources:                backupPolicy.ResourceFilter.ExcludedResources,
-		IncludeClusterResources:          backupPolicy.ResourceFilter.IncludeClusterResources,
-		IncludedClusterScopedResources:   backupPolicy.ResourceFilter.IncludedClusterScopedResources,
-		ExcludedClusterScopedResources:   backupPolicy.ResourceFilter.ExcludedClusterScopedResources,
-		IncludedNamespaceScopedResources: backupPolicy.ResourceFilter.IncludedNamespaceScopedResources,
-		ExcludedNamespaceScopedResources: backupPolicy.ResourceFilter.ExcludedNamespaceScopedResources,
-		LabelSelector:                    backupPolicy.ResourceFilter.LabelSelector,
-		OrLabelSelectors:                 backupPolicy.ResourceFilter.OrLabelSelectors,
+	spec := velerov1.BackupSpec{
+		TTL:                      backupPolicy.TTL,
+		OrderedResources:         backupPolicy.OrderedResources,
+		SnapshotVolumes:          backupPolicy.SnapshotVolumes,
+		DefaultVolumesToFsBackup: backupPolicy.DefaultVolumesToFsBackup,
+		VolumeSnapshotLocations:  backupPolicy.VolumeSnapshotLocations,
+		Hooks:                    buildVeleroBackupHooks(backupPolicy.Hooks),
+	}
+	if filter := backupPolicy.ResourceFilter; filter != nil {
+		spec.IncludedNamespaces = filter.IncludedNamespaces
+		spec.ExcludedNamespaces = filter.ExcludedNamespaces
+		spec.IncludedResources = filter.IncludedResources
+		spec.ExcludedResources = filter.ExcludedResources
+		spec.IncludeClusterResources = filter.IncludeClusterResources
+		spec.IncludedClusterScopedResources = filter.IncludedClusterScopedResources
+		spec.ExcludedClusterScopedResources = filter.ExcludedClusterScopedResources
+		spec.IncludedNamespaceScopedResources = filter.IncludedNamespaceScopedResources
+		spec.ExcludedNamespaceScopedResources = filter.ExcludedNamespaceScopedResources
+		spec.LabelSelector = filter.LabelSelector
+		spec.OrLabelSelectors = filter.OrLabelSelectors
+	}
+	return spec
+}
+
+// buildVeleroBackupHooks converts the backup hooks to the velero backup hooks on pods.
+func buildVeleroBackupHooks(hooks []*backupapi.BackupHook) velerov1.BackupHooks {
+	var veleroHooks velerov1.BackupHooks
+	for _, hook := range hooks {
+		veleroHooks.Resources = append(veleroHooks.Resources, velerov1.BackupResourceHookSpec{
+			Name:               hook.Name,
+			IncludedNamespaces: hook.IncludedNamespaces,
+			IncludedResources:  []string{"pods"},
+			LabelSelector:      hook.LabelSelector,
+			PreHooks:           buildVeleroBackupResourceHooks(hook.Pre),
+			PostHooks:          buildVeleroBackupResourceHooks(hook.Post),
+		})
+	}
+	return veleroHooks
+}
+
+func buildVeleroBackupResourceHooks(execHooks []*backupapi.ExecHook) []velerov1.BackupResourceHook {
+	var resourceHooks []velerov1.BackupResourceHook
+	for _, exec := range execHooks {
+		resourceHooks = append(resourceHooks, velerov1.BackupResourceHook{
+			Exec: &velerov1.ExecHook{
+				Container: exec.Container,
+				Command:   exec.Command,
+				OnError:   exec.OnError,
+				Timeout:   exec.Timeout,
+			},
+		})
+	}
+	return resourceHooks
+}
+
+// withSnapshotMoveData sets snapshotMoveData of the velero backup spec at the given fields of the velero object.
+// The field is introduced in velero v1.12, which is not in the velero API used by kurator, so the object is converted to unstructured.
+// The object is returned as is if snapshotMoveData is not set in the backup policy.
+func withSnapshotMoveData(obj client.Object, gvk schema.GroupVersionKind, backupPolicy *backupapi.BackupPolicy, fields ...string) (client.Object, error) {
+	if backupPolicy == nil || backupPolicy.SnapshotMoveData == nil {
+		return obj, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
 	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	if err := unstructured.SetNestedField(u.Object, *backupPolicy.SnapshotMoveData, append(fields, "snapshotMoveData")...); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 func newSyncVeleroTaskFunc(ctx context.Context, clusterAccess *fleetmanager.FleetCluster, obj client.Object) func() error {
@@ -162,15 +234,95 @@ func syncVeleroObj(ctx context.Context, cluster *fleetmanager.FleetCluster, vele
 	return syncErr
 }
 
-// allBackupsCompleted checks if all Velero backup statuses in the cluster have been collected and verifies if they have successfully completed.
-// If every BackupStatusInCluster.Phase is marked as completed, it indicates that all backups have been successfully completed.
-func allBackupsCompleted(status backupapi.BackupStatus) bool {
+// backupPhaseOf converts the phase of the velero backup to the phase of the Backup.
+func backupPhaseOf(status *velerov1.BackupStatus) backupapi.BackupPhase {
+	if status == nil {
+		return backupapi.BackupPhasePending
+	}
+
+	switch status.Phase {
+	case "", velerov1.BackupPhaseNew:
+		return backupapi.BackupPhasePending
+	case velerov1.BackupPhaseCompleted:
+		return backupapi.BackupPhaseCompleted
+	case velerov1.BackupPhasePartiallyFailed:
+		return backupapi.BackupPhasePartiallyFailed
+	case velerov1.BackupPhaseFailed, velerov1.BackupPhaseFailedValidation, velerov1.BackupPhaseDeleting:
+		return backupapi.BackupPhaseFailed
+	default:
+		// InProgress, WaitingForPluginOperations and Finalizing
+		return backupapi.BackupPhaseInProgress
+	}
+}
+
+// isBackupFinished returns whether the backups in all the clusters are finished.
+func isBackupFinished(phase backupapi.BackupPhase) bool {
+	return phase == backupapi.BackupPhaseCompleted || phase == backupapi.BackupPhasePartiallyFailed || phase == backupapi.BackupPhaseFailed
+}
+
+// updateBackupPhase aggregates the phase, the counters and the Ready condition of the Backup from the backups in all the clusters.
+func updateBackupPhase(backup *backupapi.Backup) {
+	status := &backup.Status
+	status.ItemsBackedUp, status.TotalItems, status.Warnings, status.Errors = 0, 0, 0, 0
+	status.Duration = nil
+
+	var pending, inProgress, partiallyFailed, failed []string
+	var start, completion *metav1.Time
 	for _, detail := range status.Details {
-		if detail.BackupStatusInCluster == nil || detail.BackupStatusInCluster.Phase != velerov1.BackupPhaseCompleted {
-			return false
+		switch backupPhaseOf(detail.BackupStatusInCluster) {
+		case backupapi.BackupPhasePending:
+			pending = append(pending, detail.ClusterName)
+		case backupapi.BackupPhaseInProgress:
+			inProgress = append(inProgress, detail.ClusterName)
+		case backupapi.BackupPhasePartiallyFailed:
+			partiallyFailed = append(partiallyFailed, detail.ClusterName)
+		case backupapi.BackupPhaseFailed:
+			failed = append(failed, detail.ClusterName)
+		}
+
+		clusterStatus := detail.BackupStatusInCluster
+		if clusterStatus == nil {
+			continue
+		}
+		if clusterStatus.Progress != nil {
+			status.ItemsBackedUp += clusterStatus.Progress.ItemsBackedUp
+			status.TotalItems += clusterStatus.Progress.TotalItems
+		}
+		status.Warnings += clusterStatus.Warnings
+		status.Errors += clusterStatus.Errors
+		if clusterStatus.StartTimestamp != nil && (start == nil || clusterStatus.StartTimestamp.Before(start)) {
+			start = clusterStatus.StartTimestamp
+		}
+		if clusterStatus.CompletionTimestamp != nil && (completion == nil || completion.Before(clusterStatus.CompletionTimestamp)) {
+			completion = clusterStatus.CompletionTimestamp
 		}
 	}
-	return true
+
+	switch {
+	case len(pending) != 0 && len(pending) == len(status.Details):
+		status.Phase = backupapi.BackupPhasePending
+		conditions.MarkFalse(backup, capiv1.ReadyCondition, backupapi.BackupInProgressReason, capiv1.ConditionSeverityInfo,
+			"backups in clusters %s are pending", strings.Join(pending, ","))
+	case len(pending) != 0 || len(inProgress) != 0:
+		status.Phase = backupapi.BackupPhaseInProgress
+		conditions.MarkFalse(backup, capiv1.ReadyCondition, backupapi.BackupInProgressReason, capiv1.ConditionSeverityInfo,
+			"backups in clusters %s are in progress", strings.Join(append(inProgress, pending...), ","))
+	case len(failed) != 0 && len(failed) == len(status.Details):
+		status.Phase = backupapi.BackupPhaseFailed
+		conditions.MarkFalse(backup, capiv1.ReadyCondition, backupapi.BackupFailedReason, capiv1.ConditionSeverityError,
+			"backups in clusters %s failed", strings.Join(failed, ","))
+	case len(failed) != 0 || len(partiallyFailed) != 0:
+		status.Phase = backupapi.BackupPhasePartiallyFailed
+		conditions.MarkFalse(backup, capiv1.ReadyCondition, backupapi.BackupPartiallyFailedReason, capiv1.ConditionSeverityWarning,
+			"backups in clusters %s failed or partially failed", strings.Join(append(failed, partiallyFailed...), ","))
+	default:
+		status.Phase = backupapi.BackupPhaseCompleted
+		conditions.MarkTrue(backup, capiv1.ReadyCondition)
+	}
+
+	if isBackupFinished(status.Phase) && start != nil && completion != nil {
+		status.Duration = &metav1.Duration{Duration: completion.Sub(start.Time)}
+	}
 }
 
 // deleteResourcesInClusters deletes instances of a Kubernetes resource based on the specified label key and value.
@@ -247,6 +399,35 @@ func generateVeleroResourceName(clusterName, creatorKind, creatorNamespace, crea
 // MostRecentCompletedBackup returns the most recent backup that's completed from a list of backups.
 // origin from https://github.com/vmware-tanzu/velero/blob/release-1.12/pkg/controller/restore_controller.go
 func MostRecentCompletedBackup(backups []velerov1.Backup) velerov1.Backup {
+	sortBackupsByStartTime(backups)
+
+	for _, backup := range backups {
+		if backup.Status.Phase == velerov1.BackupPhaseCompleted {
+			return backup
+		}
+	}
+
+	return velerov1.Backup{}
+}
+
+// mostRecentCompletedBackupBefore returns the most recent completed backup started at or before the given time.
+func mostRecentCompletedBackupBefore(backups []velerov1.Backup, t time.Time) velerov1.Backup {
+	sortBackupsByStartTime(backups)
+
+	for _, backup := range backups {
+		if backup.Status.Phase != velerov1.BackupPhaseCompleted || backup.Status.StartTimestamp == nil {
+			continue
+		}
+		if !backup.Status.StartTimestamp.After(t) {
+			return backup
+		}
+	}
+
+	return velerov1.Backup{}
+}
+
+// sortBackupsByStartTime sorts the backups from the newest to the oldest.
+func sortBackupsByStartTime(backups []velerov1.Backup) {
 	sort.Slice(backups, func(i, j int) bool {
 		var iStartTime, jStartTime time.Time
 		if backups[i].Status.StartTimestamp != nil {
@@ -257,14 +438,99 @@ func MostRecentCompletedBackup(backups []velerov1.Backup) velerov1.Backup {
 		}
 		return iStartTime.After(jStartTime)
 	})
+}
 
+// backupsToPrune returns the finished backups older than the most recent keepLast completed backups.
+// The backups in progress, being deleted or referenced are never pruned.
+func backupsToPrune(backups []velerov1.Backup, keepLast int, referenced sets.Set[string]) []velerov1.Backup {
+	sortBackupsByStartTime(backups)
+
+	var prune []velerov1.Backup
+	completed := 0
 	for _, backup := range backups {
-		if backup.Status.Phase == velerov1.BackupPhaseCompleted {
-			return backup
+		if completed < keepLast {
+			if backup.Status.Phase == velerov1.BackupPhaseCompleted {
+				completed++
+			}
+			continue
+		}
+		if referenced.Has(backup.Name) {
+			continue
+		}
+
+		switch backup.Status.Phase {
+		case velerov1.BackupPhaseCompleted, velerov1.BackupPhasePartiallyFailed, velerov1.BackupPhaseFailed, velerov1.BackupPhaseFailedValidation:
+			prune = append(prune, backup)
 		}
 	}
+	return prune
+}
 
-	return velerov1.Backup{}
+// referencedBackupNames returns the names of the backups of the schedule in the cluster which must not be pruned:
+// the backups selected by the point in time of the Restores of the schedule,
+// the backups being verified by the unfinished BackupVerifications of the schedule,
+// and the backups of the unfinished velero restores in the cluster.
+func referencedBackupNames(scheduleName, clusterName string, backups []velerov1.Backup, restores []backupapi.Restore,
+	verifications []backupapi.BackupVerification, veleroRestores []velerov1.Restore) sets.Set[string] {
+	referenced := sets.New[string]()
+	for _, restore := range restores {
+		if restore.Spec.BackupName != scheduleName || restore.DeletionTimestamp != nil || restore.Spec.PointInTime == nil {
+			continue
+		}
+		if name, ok := restore.Spec.PointInTime.BackupNames[clusterName]; ok {
+			referenced.Insert(name)
+		} else if restore.Spec.PointInTime.Timestamp != nil {
+			if backup := mostRecentCompletedBackupBefore(backups, restore.Spec.PointInTime.Timestamp.Time); backup.Name != "" {
+				referenced.Insert(backup.Name)
+			}
+		}
+	}
+
+	for _, verification := range verifications {
+		if verification.Spec.BackupName != scheduleName || verification.Status.BackupNameInCluster == "" {
+			continue
+		}
+		switch verification.Status.Phase {
+		case backupapi.VerificationPhasePassed, backupapi.VerificationPhaseFailed:
+		default:
+			referenced.Insert(verification.Status.BackupNameInCluster)
+		}
+	}
+
+	for _, restore := range veleroRestores {
+		switch restore.Status.Phase {
+		case velerov1.RestorePhaseCompleted, velerov1.RestorePhasePartiallyFailed, velerov1.RestorePhaseFailed, velerov1.RestorePhaseFailedValidation:
+		default:
+			referenced.Insert(restore.Spec.BackupName)
+		}
+	}
+
+	return referenced
+}
+
+// pruneVeleroBackups deletes the velero backups in the cluster with DeleteBackupRequests,
+// so that the backup data in the object storage is deleted as well.
+func pruneVeleroBackups(ctx context.Context, clusterAccess *fleetmanager.FleetCluster, backups []velerov1.Backup) error {
+	clusterClient := clusterAccess.GetRuntimeClient()
+	for _, backup := range backups {
+		deleteRequest := &velerov1.DeleteBackupRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backup.Name + "-prune",
+				Namespace: VeleroNamespace,
+				Labels: map[string]string{
+					velerov1.BackupNameLabel: label.GetValidName(backup.Name),
+					velerov1.BackupUIDLabel:  string(backup.UID),
+				},
+			},
+			Spec: velerov1.DeleteBackupRequestSpec{
+				BackupName: backup.Name,
+			},
+		}
+		if err := clusterClient.Create(ctx, deleteRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to delete velero backup %s: %w", backup.Name, err)
+		}
+	}
+	return nil
 }
 
 // GetCronInterval return the cron interval of a cron expression。
@@ -402,11 +668,51 @@ func buildVeleroRestoreInstance(restoreSpec *backupapi.RestoreSpec, labels map[s
 				ExcludedResources: restoreSpec.Policy.PreserveStatus.ExcludedResources,
 			}
 		}
+		veleroRestore.Spec.Hooks = buildVeleroRestoreHooks(restoreSpec.Policy.Hooks)
 	}
 
 	return veleroRestore
 }
 
+// buildVeleroRestoreHooks converts the restore hooks to the velero restore hooks on pods.
+func buildVeleroRestoreHooks(hooks []*backupapi.RestoreHook) velerov1.RestoreHooks {
+	var veleroHooks velerov1.RestoreHooks
+	for _, hook := range hooks {
+		var postHooks []velerov1.RestoreResourceHook
+		if hook.Init != nil {
+			initHook := &velerov1.InitRestoreHook{
+				Timeout: hook.Init.Timeout,
+			}
+			// velero keeps the init containers as raw objects, marshaling a container never fails
+			for _, container := range hook.Init.InitContainers {
+				raw, _ := json.Marshal(container)
+				initHook.InitContainers = append(initHook.InitContainers, runtime.RawExtension{Raw: raw})
+			}
+			postHooks = append(postHooks, velerov1.RestoreResourceHook{Init: initHook})
+		}
+		for _, exec := range hook.PostExec {
+			postHooks = append(postHooks, velerov1.RestoreResourceHook{
+				Exec: &velerov1.ExecRestoreHook{
+					Container:   exec.Container,
+					Command:     exec.Command,
+					OnError:     exec.OnError,
+					ExecTimeout: exec.ExecTimeout,
+					WaitTimeout: exec.WaitTimeout,
+				},
+			})
+		}
+
+		veleroHooks.Resources = append(veleroHooks.Resources, velerov1.RestoreResourceHookSpec{
+			Name:               hook.Name,
+			IncludedNamespaces: hook.IncludedNamespaces,
+			IncludedResources:  []string{"pods"},
+			LabelSelector:      hook.LabelSelector,
+			PostHooks:          postHooks,
+		})
+	}
+	return veleroHooks
+}
+
 // allRestoreCompleted checks if all restore operations are completed by inspecting the phase of each RestoreDetails instance in the provided slice.
 func allRestoreCompleted(clusterDetails []*backupapi.RestoreDetails) bool {
 	for _, detail := range clusterDetails {
@@ -459,6 +765,74 @@ func syncVeleroRestoreStatus(ctx context.Context, destinationClusters map[fleetm
 	return clusterDetails, nil
 }
 
+// fetchMigrateClusters retrieves the clusters of the migrate destination, whose fleet is in the namespace of the migrate if not specified.
+func fetchMigrateClusters(ctx context.Context, kubeClient client.Client, migrate *backupapi.Migrate, destination backupapi.MigrateDestination) (map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster, error) {
+	namespace := destination.Namespace
+	if namespace == "" {
+		namespace = migrate.Namespace
+	}
+	if namespace != migrate.Namespace {
+		// the fleet in another namespace must opt in, as the migrate can move workloads from or to its clusters
+		fleet := &fleetapi.Fleet{}
+		if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: destination.Fleet}, fleet); err != nil {
+			return nil, fmt.Errorf("failed to retrieve fleet instance '%s' in namespace '%s': %w", destination.Fleet, namespace, err)
+		}
+		if !fleet.AllowsMigrateFrom(migrate.Namespace) {
+			return nil, fmt.Errorf("fleet '%s' in namespace '%s' does not allow migrates from namespace '%s', see annotation %s",
+				destination.Fleet, namespace, migrate.Namespace, fleetapi.AllowedMigrateNamespacesAnnotation)
+		}
+	}
+	return fetchDestinationClusters(ctx, kubeClient, namespace, destination.Destination)
+}
+
+// defaultBackupStorageLocation returns the default backup storage location of velero in the cluster, it is nil if not found.
+// The location marked as default is preferred, otherwise the location named "default", which is the default of velero server.
+func defaultBackupStorageLocation(ctx context.Context, clusterClient client.Client) (*velerov1.BackupStorageLocation, error) {
+	locations := &velerov1.BackupStorageLocationList{}
+	if err := clusterClient.List(ctx, locations, client.InNamespace(VeleroNamespace)); err != nil {
+		return nil, err
+	}
+
+	var res *velerov1.BackupStorageLocation
+	for i := range locations.Items {
+		location := &locations.Items[i]
+		if location.Spec.Default {
+			return location, nil
+		}
+		if location.Name == "default" {
+			res = location
+		}
+	}
+	return res, nil
+}
+
+// backupStorageIdentityKeys are the config keys of the backup storage location identifying the object storage besides the bucket,
+// e.g. the storage account of Azure and the project of GCP, the endpoint of s3 compatible storages is described separately.
+var backupStorageIdentityKeys = []string{"project", "resourceGroup", "storageAccount", "subscriptionId"}
+
+// backupStorageOf describes the object storage of the backup storage location,
+// in the form of "provider:endpoint/bucket/prefix" followed by the identifying config, e.g. "?storageAccount=account".
+// The region is not included, because it is optional for most object storages and can be omitted on either side.
+func backupStorageOf(location *velerov1.BackupStorageLocation) string {
+	var bucket, prefix string
+	if location.Spec.ObjectStorage != nil {
+		bucket = location.Spec.ObjectStorage.Bucket
+		prefix = location.Spec.ObjectStorage.Prefix
+	}
+	storage := fmt.Sprintf("%s:%s/%s/%s", location.Spec.Provider, strings.TrimSuffix(location.Spec.Config["s3Url"], "/"), bucket, strings.Trim(prefix, "/"))
+
+	var identities []string
+	for _, key := range backupStorageIdentityKeys {
+		if value := location.Spec.Config[key]; value != "" {
+			identities = append(identities, key+"="+value)
+		}
+	}
+	if len(identities) != 0 {
+		storage += "?" + strings.Join(identities, "&")
+	}
+	return storage
+}
+
 // isMigrateSourceReady checks if the 'SourceReadyCondition' of a Migrate object is set to 'True'.
 func isMigrateSourceReady(migrate *backupapi.Migrate) bool {
 	for _, condition := range migrate.Status.Conditions {
@@ -495,3 +869,67 @@ func buildVeleroRestoreFromMigrate(migrateSpec *backupapi.MigrateSpec, labels ma
 	}
 	return buildVeleroRestoreInstance(restoreParam, labels, veleroBackupName, veleroRestoreName)
 }
+
+// dataUploadGVK is the velero DataUpload moving the data of CSI snapshots, which is introduced in velero v1.12.
+var dataUploadGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v2alpha1", Kind: "DataUploadList"}
+
+// listVolumeBackupDetails collects the progress of the volumes backed up by the velero backup in the cluster.
+func listVolumeBackupDetails(ctx context.Context, clusterAccess *fleetmanager.FleetCluster, backupName string) ([]*backupapi.VolumeBackupDetails, error) {
+	podVolumeBackups := &velerov1.PodVolumeBackupList{}
+	if err := listResourcesFromClusterClient(ctx, VeleroNamespace, velerov1.BackupNameLabel, label.GetValidName(backupName), *clusterAccess, podVolumeBackups); err != nil {
+		return nil, err
+	}
+	details := buildPodVolumeBackupDetails(podVolumeBackups.Items)
+
+	dataUploads := &unstructured.UnstructuredList{}
+	dataUploads.SetGroupVersionKind(dataUploadGVK)
+	if err := listResourcesFromClusterClient(ctx, VeleroNamespace, velerov1.BackupNameLabel, label.GetValidName(backupName), *clusterAccess, dataUploads); err != nil {
+		// DataUpload is not installed before velero v1.12
+		if apimeta.IsNoMatchError(err) {
+			return details, nil
+		}
+		return nil, err
+	}
+	return append(details, buildDataUploadDetails(dataUploads.Items)...), nil
+}
+
+// buildPodVolumeBackupDetails converts the velero PodVolumeBackups to the volume backup details.
+func buildPodVolumeBackupDetails(podVolumeBackups []velerov1.PodVolumeBackup) []*backupapi.VolumeBackupDetails {
+	var details []*backupapi.VolumeBackupDetails
+	for _, pvb := range podVolumeBackups {
+		details = append(details, &backupapi.VolumeBackupDetails{
+			Namespace:  pvb.Spec.Pod.Namespace,
+			Pod:        pvb.Spec.Pod.Name,
+			Volume:     pvb.Spec.Volume,
+			Method:     backupapi.VolumeBackupMethodFileSystem,
+			Phase:      string(pvb.Status.Phase),
+			BytesDone:  pvb.Status.Progress.BytesDone,
+			TotalBytes: pvb.Status.Progress.TotalBytes,
+			Message:    pvb.Status.Message,
+		})
+	}
+	return details
+}
+
+// buildDataUploadDetails converts the velero DataUploads to the volume backup details.
+func buildDataUploadDetails(dataUploads []unstructured.Unstructured) []*backupapi.VolumeBackupDetails {
+	var details []*backupapi.VolumeBackupDetails
+	for _, du := range dataUploads {
+		namespace, _, _ := unstructured.NestedString(du.Object, "spec", "sourceNamespace")
+		pvc, _, _ := unstructured.NestedString(du.Object, "spec", "sourcePVC")
+		phase, _, _ := unstructured.NestedString(du.Object, "status", "phase")
+		message, _, _ := unstructured.NestedString(du.Object, "status", "message")
+		bytesDone, _, _ := unstructured.NestedInt64(du.Object, "status", "progress", "bytesDone")
+		totalBytes, _, _ := unstructured.NestedInt64(du.Object, "status", "progress", "totalBytes")
+		details = append(details, &backupapi.VolumeBackupDetails{
+			Namespace:  namespace,
+			Volume:     pvc,
+			Method:     backupapi.VolumeBackupMethodDataMover,
+			Phase:      phase,
+			BytesDone:  bytesDone,
+			TotalBytes: totalBytes,
+			Message:    message,
+		})
+	}
+	return details
+}
@@ -14,6 +14,8 @@ limitations under the License.
 package backup
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"testing"
 	"time"
@@ -22,7 +24,13 @@ import (
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/yaml"
 
 	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
@@ -220,89 +228,109 @@ func getExpectedBackup(caseName string) ([]byte, error) {
 	return os.ReadFile(backupTestDataPath + caseName + ".yaml")
 }
 
-func TestAllBackupsCompleted(t *testing.T) {
+func TestUpdateBackupPhase(t *testing.T) {
+	start := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	completion := metav1.NewTime(start.Add(5 * time.Minute))
+
 	tests := []struct {
-		name     string
-		status   backupapi.BackupStatus
-		expected bool
+		name          string
+		details       []*backupapi.BackupDetails
+		expected      backupapi.BackupPhase
+		expectedReady corev1.ConditionStatus
 	}{
 		{
-			name: "No details",
-			status: backupapi.BackupStatus{
-				Details: nil,
+			name:          "No details",
+			details:       nil,
+			expected:      backupapi.BackupPhaseCompleted,
+			expectedReady: corev1.ConditionTrue,
+		},
+		{
+			name: "Backup not started",
+			details: []*backupapi.BackupDetails{
+				{ClusterName: "member1"},
 			},
-			expected: true,
+			expected:      backupapi.BackupPhasePending,
+			expectedReady: corev1.ConditionFalse,
 		},
 		{
-			name: "Backup not completed",
-			status: backupapi.BackupStatus{
-				Details: []*backupapi.BackupDetails{
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseInProgress,
-						},
-					},
-				},
+			name: "Multiple backups, one not completed",
+			details: []*backupapi.BackupDetails{
+				{ClusterName: "member1", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted}},
+				{ClusterName: "member2", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseInProgress}},
 			},
-			expected: false,
+			expected:      backupapi.BackupPhaseInProgress,
+			expectedReady: corev1.ConditionFalse,
 		},
 		{
-			name: "Backup completed",
-			status: backupapi.BackupStatus{
-				Details: []*backupapi.BackupDetails{
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseCompleted,
-						},
-					},
-				},
+			name: "Multiple backups, one failed",
+			details: []*backupapi.BackupDetails{
+				{ClusterName: "member1", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted}},
+				{ClusterName: "member2", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed}},
 			},
-			expected: true,
+			expected:      backupapi.BackupPhasePartiallyFailed,
+			expectedReady: corev1.ConditionFalse,
 		},
 		{
-			name: "Multiple backups, one not completed",
-			status: backupapi.BackupStatus{
-				Details: []*backupapi.BackupDetails{
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseCompleted,
-						},
-					},
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseInProgress,
-						},
-					},
-				},
+			name: "All backups failed",
+			details: []*backupapi.BackupDetails{
+				{ClusterName: "member1", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailedValidation}},
+				{ClusterName: "member2", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed}},
 			},
-			expected: false,
+			expected:      backupapi.BackupPhaseFailed,
+			expectedReady: corev1.ConditionFalse,
 		},
 		{
 			name: "Multiple backups, all completed",
-			status: backupapi.BackupStatus{
-				Details: []*backupapi.BackupDetails{
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseCompleted,
-						},
-					},
-					{
-						BackupStatusInCluster: &velerov1.BackupStatus{
-							Phase: velerov1.BackupPhaseCompleted,
-						},
-					},
-				},
+			details: []*backupapi.BackupDetails{
+				{ClusterName: "member1", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted}},
+				{ClusterName: "member2", BackupStatusInCluster: &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted}},
 			},
-			expected: true,
+			expected:      backupapi.BackupPhaseCompleted,
+			expectedReady: corev1.ConditionTrue,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := allBackupsCompleted(tt.status)
-			assert.Equal(t, tt.expected, result)
+			backup := &backupapi.Backup{Status: backupapi.BackupStatus{Details: tt.details}}
+			updateBackupPhase(backup)
+			assert.Equal(t, tt.expected, backup.Status.Phase)
+			assert.Equal(t, tt.expectedReady, conditions.Get(backup, capiv1.ReadyCondition).Status)
 		})
 	}
+
+	t.Run("Counters", func(t *testing.T) {
+		backup := &backupapi.Backup{Status: backupapi.BackupStatus{Details: []*backupapi.BackupDetails{
+			{
+				ClusterName: "member1",
+				BackupStatusInCluster: &velerov1.BackupStatus{
+					Phase:               velerov1.BackupPhaseCompleted,
+					Progress:            &velerov1.BackupProgress{ItemsBackedUp: 10, TotalItems: 10},
+					Warnings:            1,
+					StartTimestamp:      &start,
+					CompletionTimestamp: &completion,
+				},
+			},
+			{
+				ClusterName: "member2",
+				BackupStatusInCluster: &velerov1.BackupStatus{
+					Phase:               velerov1.BackupPhasePartiallyFailed,
+					Progress:            &velerov1.BackupProgress{ItemsBackedUp: 5, TotalItems: 6},
+					Errors:              2,
+					StartTimestamp:      &start,
+					CompletionTimestamp: &start,
+				},
+			},
+		}}}
+		updateBackupPhase(backup)
+		assert.Equal(t, backupapi.BackupPhasePartiallyFailed, backup.Status.Phase)
+		assert.Equal(t, 15, backup.Status.ItemsBackedUp)
+		assert.Equal(t, 16, backup.Status.TotalItems)
+		assert.Equal(t, 1, backup.Status.Warnings)
+		assert.Equal(t, 2, backup.Status.Errors)
+		assert.Equal(t, &metav1.Duration{Duration: 5 * time.Minute}, backup.Status.Duration)
+		assert.Equal(t, backupapi.BackupPartiallyFailedReason, conditions.GetReason(backup, capiv1.ReadyCondition))
+	})
 }
 
 func TestMostRecentCompletedBackup(t *testing.T) {
@@ -388,6 +416,429 @@ func TestMostRecentCompletedBackup(t *testing.T) {
 	}
 }
 
+func TestMostRecentCompletedBackupBefore(t *testing.T) {
+	now := time.Now()
+	time1 := metav1.NewTime(now)
+	time2 := metav1.NewTime(now.Add(-10 * time.Minute))
+	time3 := metav1.NewTime(now.Add(-20 * time.Minute))
+
+	backups := []velerov1.Backup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-1"},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted, StartTimestamp: &time1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-2"},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed, StartTimestamp: &time2},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-3"},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted, StartTimestamp: &time3},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{
+			name:     "After the latest backup",
+			time:     now.Add(time.Minute),
+			expected: "backup-1",
+		},
+		{
+			name:     "Exactly at the latest backup",
+			time:     now,
+			expected: "backup-1",
+		},
+		{
+			name:     "Skip the failed backup",
+			time:     now.Add(-5 * time.Minute),
+			expected: "backup-3",
+		},
+		{
+			name:     "Before all backups",
+			time:     now.Add(-time.Hour),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mostRecentCompletedBackupBefore(backups, tt.time)
+			assert.Equal(t, tt.expected, result.Name)
+		})
+	}
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	now := time.Now()
+	newBackup := func(name string, phase velerov1.BackupPhase, age time.Duration) velerov1.Backup {
+		start := metav1.NewTime(now.Add(-age))
+		return velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     velerov1.BackupStatus{Phase: phase, StartTimestamp: &start},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		backups    []velerov1.Backup
+		keepLast   int
+		referenced sets.Set[string]
+		expected   []string
+	}{
+		{
+			name: "Fewer backups than kept",
+			backups: []velerov1.Backup{
+				newBackup("backup-1", velerov1.BackupPhaseCompleted, time.Minute),
+			},
+			keepLast: 2,
+			expected: nil,
+		},
+		{
+			name: "Prune the oldest completed backups",
+			backups: []velerov1.Backup{
+				newBackup("backup-3", velerov1.BackupPhaseCompleted, 3*time.Hour),
+				newBackup("backup-1", velerov1.BackupPhaseCompleted, time.Hour),
+				newBackup("backup-2", velerov1.BackupPhaseCompleted, 2*time.Hour),
+			},
+			keepLast: 1,
+			expected: []string{"backup-2", "backup-3"},
+		},
+		{
+			name: "Failed backups are not counted",
+			backups: []velerov1.Backup{
+				newBackup("backup-1", velerov1.BackupPhaseFailed, time.Hour),
+				newBackup("backup-2", velerov1.BackupPhaseCompleted, 2*time.Hour),
+				newBackup("backup-3", velerov1.BackupPhasePartiallyFailed, 3*time.Hour),
+			},
+			keepLast: 1,
+			expected: []string{"backup-3"},
+		},
+		{
+			name: "Backups in progress or being deleted are kept",
+			backups: []velerov1.Backup{
+				newBackup("backup-1", velerov1.BackupPhaseCompleted, time.Hour),
+				newBackup("backup-2", velerov1.BackupPhaseInProgress, 2*time.Hour),
+				newBackup("backup-3", velerov1.BackupPhaseDeleting, 3*time.Hour),
+				newBackup("backup-4", velerov1.BackupPhaseCompleted, 4*time.Hour),
+			},
+			keepLast: 1,
+			expected: []string{"backup-4"},
+		},
+		{
+			name: "Referenced backups are kept",
+			backups: []velerov1.Backup{
+				newBackup("backup-1", velerov1.BackupPhaseCompleted, time.Hour),
+				newBackup("backup-2", velerov1.BackupPhaseCompleted, 2*time.Hour),
+				newBackup("backup-3", velerov1.BackupPhaseCompleted, 3*time.Hour),
+			},
+			keepLast:   1,
+			referenced: sets.New("backup-2"),
+			expected:   []string{"backup-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			for _, backup := range backupsToPrune(tt.backups, tt.keepLast, tt.referenced) {
+				result = append(result, backup.Name)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestReferencedBackupNames(t *testing.T) {
+	now := time.Now()
+	newBackup := func(name string, age time.Duration) velerov1.Backup {
+		start := metav1.NewTime(now.Add(-age))
+		return velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted, StartTimestamp: &start},
+		}
+	}
+	backups := []velerov1.Backup{
+		newBackup("backup-1", time.Hour),
+		newBackup("backup-2", 2*time.Hour),
+		newBackup("backup-3", 3*time.Hour),
+		newBackup("backup-4", 4*time.Hour),
+		newBackup("backup-5", 5*time.Hour),
+	}
+	timestamp := metav1.NewTime(now.Add(-90 * time.Minute))
+
+	restores := []backupapi.Restore{
+		{
+			// pinned backup in the cluster
+			Spec: backupapi.RestoreSpec{
+				BackupName:  "schedule",
+				PointInTime: &backupapi.PointInTime{BackupNames: map[string]string{"cluster1": "backup-4", "cluster2": "backup-5"}},
+			},
+		},
+		{
+			// backup selected by the timestamp
+			Spec: backupapi.RestoreSpec{
+				BackupName:  "schedule",
+				PointInTime: &backupapi.PointInTime{Timestamp: &timestamp},
+			},
+		},
+		{
+			// restore of other backups
+			Spec: backupapi.RestoreSpec{
+				BackupName:  "other",
+				PointInTime: &backupapi.PointInTime{BackupNames: map[string]string{"cluster1": "backup-5"}},
+			},
+		},
+	}
+	verifications := []backupapi.BackupVerification{
+		{
+			Spec:   backupapi.BackupVerificationSpec{BackupName: "schedule"},
+			Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhaseRestoring, BackupNameInCluster: "backup-1"},
+		},
+		{
+			Spec:   backupapi.BackupVerificationSpec{BackupName: "schedule"},
+			Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhasePassed, BackupNameInCluster: "backup-5"},
+		},
+	}
+	veleroRestores := []velerov1.Restore{
+		{
+			Spec:   velerov1.RestoreSpec{BackupName: "backup-3"},
+			Status: velerov1.RestoreStatus{Phase: velerov1.RestorePhaseInProgress},
+		},
+		{
+			Spec:   velerov1.RestoreSpec{BackupName: "backup-5"},
+			Status: velerov1.RestoreStatus{Phase: velerov1.RestorePhaseCompleted},
+		},
+	}
+
+	referenced := referencedBackupNames("schedule", "cluster1", backups, restores, verifications, veleroRestores)
+	assert.ElementsMatch(t, []string{"backup-1", "backup-2", "backup-3", "backup-4"}, sets.List(referenced))
+}
+
+func TestBuildVeleroBackupSpec(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	tests := []struct {
+		name     string
+		policy   *backupapi.BackupPolicy
+		expected velerov1.BackupSpec
+	}{
+		{
+			name:     "No policy",
+			policy:   nil,
+			expected: velerov1.BackupSpec{},
+		},
+		{
+			name: "Volume options without resource filter",
+			policy: &backupapi.BackupPolicy{
+				SnapshotVolumes:          &enabled,
+				DefaultVolumesToFsBackup: &disabled,
+				VolumeSnapshotLocations:  []string{"ebs"},
+			},
+			expected: velerov1.BackupSpec{
+				SnapshotVolumes:          &enabled,
+				DefaultVolumesToFsBackup: &disabled,
+				VolumeSnapshotLocations:  []string{"ebs"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildVeleroBackupSpec(tt.policy))
+		})
+	}
+}
+
+func TestBuildVeleroBackupHooks(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}}
+	hooks := []*backupapi.BackupHook{
+		{
+			Name:               "mysql",
+			IncludedNamespaces: []string{"app"},
+			LabelSelector:      selector,
+			Pre: []*backupapi.ExecHook{
+				{
+					Container: "mysql",
+					Command:   []string{"/bin/sh", "-c", "mysql -e 'FLUSH TABLES WITH READ LOCK'"},
+					OnError:   velerov1.HookErrorModeFail,
+					Timeout:   metav1.Duration{Duration: time.Minute},
+				},
+			},
+			Post: []*backupapi.ExecHook{
+				{
+					Container: "mysql",
+					Command:   []string{"/bin/sh", "-c", "mysql -e 'UNLOCK TABLES'"},
+				},
+			},
+		},
+	}
+
+	expected := velerov1.BackupHooks{
+		Resources: []velerov1.BackupResourceHookSpec{
+			{
+				Name:               "mysql",
+				IncludedNamespaces: []string{"app"},
+				IncludedResources:  []string{"pods"},
+				LabelSelector:      selector,
+				PreHooks: []velerov1.BackupResourceHook{
+					{
+						Exec: &velerov1.ExecHook{
+							Container: "mysql",
+							Command:   []string{"/bin/sh", "-c", "mysql -e 'FLUSH TABLES WITH READ LOCK'"},
+							OnError:   velerov1.HookErrorModeFail,
+							Timeout:   metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+				PostHooks: []velerov1.BackupResourceHook{
+					{
+						Exec: &velerov1.ExecHook{
+							Container: "mysql",
+							Command:   []string{"/bin/sh", "-c", "mysql -e 'UNLOCK TABLES'"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, buildVeleroBackupHooks(hooks))
+	assert.Equal(t, velerov1.BackupHooks{}, buildVeleroBackupHooks(nil))
+}
+
+func TestBuildVeleroRestoreHooks(t *testing.T) {
+	hooks := []*backupapi.RestoreHook{
+		{
+			Name:          "mysql",
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}},
+			Init: &backupapi.InitRestoreHook{
+				InitContainers: []corev1.Container{
+					{
+						Name:    "restore-dump",
+						Image:   "busybox",
+						Command: []string{"cp", "/backup/dump.sql", "/docker-entrypoint-initdb.d/"},
+					},
+				},
+				Timeout: metav1.Duration{Duration: 5 * time.Minute},
+			},
+			PostExec: []*backupapi.ExecRestoreHook{
+				{
+					Container:   "mysql",
+					Command:     []string{"/bin/sh", "-c", "mysqladmin ping"},
+					OnError:     velerov1.HookErrorModeContinue,
+					WaitTimeout: metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+	}
+
+	result := buildVeleroRestoreHooks(hooks)
+	assert.Len(t, result.Resources, 1)
+	spec := result.Resources[0]
+	assert.Equal(t, "mysql", spec.Name)
+	assert.Equal(t, []string{"pods"}, spec.IncludedResources)
+	assert.Len(t, spec.PostHooks, 2)
+
+	init := spec.PostHooks[0].Init
+	assert.NotNil(t, init)
+	assert.Equal(t, 5*time.Minute, init.Timeout.Duration)
+	assert.Len(t, init.InitContainers, 1)
+	container := corev1.Container{}
+	assert.NoError(t, json.Unmarshal(init.InitContainers[0].Raw, &container))
+	assert.Equal(t, hooks[0].Init.InitContainers[0], container)
+
+	assert.Equal(t, &velerov1.ExecRestoreHook{
+		Container:   "mysql",
+		Command:     []string{"/bin/sh", "-c", "mysqladmin ping"},
+		OnError:     velerov1.HookErrorModeContinue,
+		WaitTimeout: metav1.Duration{Duration: 10 * time.Minute},
+	}, spec.PostHooks[1].Exec)
+}
+
+func TestWithSnapshotMoveData(t *testing.T) {
+	enabled := true
+	gvk := velerov1.SchemeGroupVersion.WithKind("Schedule")
+	schedule := &velerov1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: VeleroNamespace},
+		Spec:       velerov1.ScheduleSpec{Schedule: "0 * * * *"},
+	}
+
+	obj, err := withSnapshotMoveData(schedule, gvk, &backupapi.BackupPolicy{}, "spec", "template")
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, obj)
+
+	obj, err = withSnapshotMoveData(schedule, gvk, &backupapi.BackupPolicy{SnapshotMoveData: &enabled}, "spec", "template")
+	assert.NoError(t, err)
+	u, ok := obj.(*unstructured.Unstructured)
+	assert.True(t, ok)
+	assert.Equal(t, gvk, u.GroupVersionKind())
+	assert.Equal(t, "schedule", u.GetName())
+	moveData, found, err := unstructured.NestedBool(u.Object, "spec", "template", "snapshotMoveData")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, moveData)
+}
+
+func TestBuildVolumeBackupDetails(t *testing.T) {
+	podVolumeBackups := []velerov1.PodVolumeBackup{
+		{
+			Spec: velerov1.PodVolumeBackupSpec{
+				Pod:    corev1.ObjectReference{Namespace: "app", Name: "mysql-0"},
+				Volume: "data",
+			},
+			Status: velerov1.PodVolumeBackupStatus{
+				Phase:    velerov1.PodVolumeBackupPhaseInProgress,
+				Progress: velerov1.PodVolumeOperationProgress{BytesDone: 512, TotalBytes: 1024},
+			},
+		},
+	}
+	dataUploads := []unstructured.Unstructured{
+		{
+			Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"sourceNamespace": "app",
+					"sourcePVC":       "data-mysql-0",
+				},
+				"status": map[string]interface{}{
+					"phase":   "Failed",
+					"message": "snapshot timeout",
+					"progress": map[string]interface{}{
+						"bytesDone":  int64(0),
+						"totalBytes": int64(2048),
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []*backupapi.VolumeBackupDetails{
+		{
+			Namespace:  "app",
+			Pod:        "mysql-0",
+			Volume:     "data",
+			Method:     backupapi.VolumeBackupMethodFileSystem,
+			Phase:      "InProgress",
+			BytesDone:  512,
+			TotalBytes: 1024,
+		},
+	}, buildPodVolumeBackupDetails(podVolumeBackups))
+	assert.Equal(t, []*backupapi.VolumeBackupDetails{
+		{
+			Namespace:  "app",
+			Volume:     "data-mysql-0",
+			Method:     backupapi.VolumeBackupMethodDataMover,
+			Phase:      "Failed",
+			TotalBytes: 2048,
+			Message:    "snapshot timeout",
+		},
+	}, buildDataUploadDetails(dataUploads))
+}
+
 func TestGetCronInterval(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -803,3 +1254,92 @@ func TestBuildVeleroRestoreFromMigrate(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestDefaultBackupStorageLocation(t *testing.T) {
+	newLocation := func(name string, isDefault bool, bucket string) *velerov1.BackupStorageLocation {
+		return &velerov1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: VeleroNamespace, Name: name},
+			Spec: velerov1.BackupStorageLocationSpec{
+				Provider:    "aws",
+				Default:     isDefault,
+				Config:      map[string]string{"s3Url": "http://minio.minio:9000/"},
+				StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: bucket}},
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	_ = velerov1.AddToScheme(scheme)
+
+	cases := []struct {
+		name            string
+		locations       []client.Object
+		expectedStorage string
+	}{
+		{
+			name: "not found",
+		},
+		{
+			name:            "named default",
+			locations:       []client.Object{newLocation("default", false, "velero"), newLocation("other", false, "other")},
+			expectedStorage: "aws:http://minio.minio:9000/velero/",
+		},
+		{
+			name:            "marked default",
+			locations:       []client.Object{newLocation("default", false, "velero"), newLocation("shared", true, "shared")},
+			expectedStorage: "aws:http://minio.minio:9000/shared/",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.locations...).Build()
+			location, err := defaultBackupStorageLocation(context.Background(), c)
+			assert.NoError(t, err)
+			if tc.expectedStorage == "" {
+				assert.Nil(t, location)
+				return
+			}
+			assert.Equal(t, tc.expectedStorage, backupStorageOf(location))
+		})
+	}
+}
+
+func TestBackupStorageOf(t *testing.T) {
+	newLocation := func(provider string, config map[string]string) *velerov1.BackupStorageLocation {
+		return &velerov1.BackupStorageLocation{
+			Spec: velerov1.BackupStorageLocationSpec{
+				Provider:    provider,
+				Config:      config,
+				StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "velero", Prefix: "/backups/"}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		location *velerov1.BackupStorageLocation
+		expected string
+	}{
+		{
+			name:     "s3 compatible",
+			location: newLocation("aws", map[string]string{"s3Url": "http://minio.minio:9000/", "region": "minio"}),
+			expected: "aws:http://minio.minio:9000/velero/backups",
+		},
+		{
+			name:     "azure",
+			location: newLocation("azure", map[string]string{"storageAccount": "account", "resourceGroup": "group", "subscriptionId": "subscription"}),
+			expected: "azure:/velero/backups?resourceGroup=group&storageAccount=account&subscriptionId=subscription",
+		},
+		{
+			name:     "gcp",
+			location: newLocation("gcp", map[string]string{"project": "project"}),
+			expected: "gcp:/velero/backups?project=project",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, backupStorageOf(tc.location))
+		})
+	}
+}
@@ -0,0 +1,583 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+	http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	fleetmanager "kurator.dev/kurator/pkg/fleet-manager"
+)
+
+const (
+	// defaultVerificationNamespacePrefix is the default prefix of the scratch namespaces.
+	defaultVerificationNamespacePrefix = "kurator-verify"
+	// defaultVerificationTimeout is the default timeout of the restore and the readiness checks of a verification.
+	defaultVerificationTimeout = 30 * time.Minute
+)
+
+// BackupVerificationManager reconciles a BackupVerification object
+type BackupVerificationManager struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (v *BackupVerificationManager) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&backupapi.BackupVerification{}).
+		WithOptions(options).
+		Complete(v)
+}
+
+func (v *BackupVerificationManager) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("backupVerification", req.NamespacedName)
+
+	verification := &backupapi.BackupVerification{}
+	if err := v.Client.Get(ctx, req.NamespacedName, verification); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("backup verification object not found")
+			return ctrl.Result{}, nil
+		}
+
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(verification, v.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to init patch helper for backup verification %s", req.NamespacedName)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, verification); err != nil {
+			reterr = utilerrors.NewAggregate([]error{reterr, errors.Wrapf(err, "failed to patch %s  %s", verification.Name, req.NamespacedName)})
+		}
+	}()
+
+	if !controllerutil.ContainsFinalizer(verification, VerificationFinalizer) {
+		controllerutil.AddFinalizer(verification, VerificationFinalizer)
+	}
+
+	// Handle deletion
+	if verification.GetDeletionTimestamp() != nil {
+		return v.reconcileDeleteVerification(ctx, verification)
+	}
+
+	// Handle the main reconcile logic
+	return v.reconcileVerification(ctx, verification)
+}
+
+// reconcileVerification drives a verification through the phases: Pending -> Restoring -> Verifying -> Passed or Failed.
+func (v *BackupVerificationManager) reconcileVerification(ctx context.Context, verification *backupapi.BackupVerification) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	targetCluster, err := v.fetchTargetCluster(ctx, verification)
+	if err != nil {
+		log.Error(err, "failed to fetch target cluster for backup verification")
+		return ctrl.Result{}, err
+	}
+
+	switch verification.Status.Phase {
+	case backupapi.VerificationPhaseRestoring:
+		return v.reconcileVerificationRestore(ctx, verification, targetCluster)
+	case backupapi.VerificationPhaseVerifying:
+		return v.reconcileVerificationChecks(ctx, verification, targetCluster)
+	default:
+		after, due, err := nextVerification(verification, time.Now())
+		if err != nil {
+			log.Error(err, "failed to get next verification time", "schedule", verification.Spec.Schedule)
+			return ctrl.Result{}, err
+		}
+		if !due {
+			return ctrl.Result{RequeueAfter: after}, nil
+		}
+		return v.startVerification(ctx, verification, targetCluster)
+	}
+}
+
+// startVerification selects the latest completed backup and starts restoring it into the scratch namespaces.
+func (v *BackupVerificationManager) startVerification(ctx context.Context, verification *backupapi.BackupVerification, targetCluster *verificationCluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	backup := &backupapi.Backup{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: verification.Namespace, Name: verification.Spec.BackupName}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return pendingVerification(verification, fmt.Sprintf("backup %s is not found", verification.Spec.BackupName)), nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	sourceCluster, err := v.fetchSourceCluster(ctx, verification, backup)
+	if err != nil {
+		log.Error(err, "failed to fetch source cluster for backup verification")
+		return ctrl.Result{}, err
+	}
+
+	veleroBackupName, err := latestCompletedVeleroBackup(ctx, backup, sourceCluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if veleroBackupName == "" {
+		return pendingVerification(verification, fmt.Sprintf("no completed backups found for backup %s in cluster %s", backup.Name, sourceCluster.key.Name)), nil
+	}
+
+	// the scratch namespaces of the last verification may be still terminating
+	for _, namespace := range scratchNamespaces(verification) {
+		ns := &corev1.Namespace{}
+		err := getResourceFromClusterClient(ctx, namespace, "", *targetCluster.access, ns)
+		if err == nil {
+			return pendingVerification(verification, fmt.Sprintf("waiting for scratch namespace %s to be deleted", namespace)), nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+
+	now := metav1.Now()
+	verification.Status.Phase = backupapi.VerificationPhaseRestoring
+	verification.Status.Message = ""
+	verification.Status.BackupNameInCluster = veleroBackupName
+	verification.Status.RestoreNameInCluster = generateVeleroResourceName(targetCluster.key.Name, VerificationKind, verification.Namespace, verification.Name) +
+		"-" + now.UTC().Format("20060102150405")
+	verification.Status.RestoreStatusInCluster = nil
+	verification.Status.StartTime = &now
+	verification.Status.Checks = nil
+	if verification.Status.LastResult == "" {
+		conditions.MarkFalse(verification, backupapi.BackupVerifiedCondition, backupapi.VerificationInProgressReason, capiv1.ConditionSeverityInfo, "")
+	}
+	log.Info("Start backup verification", "backupName", veleroBackupName, "restoreName", verification.Status.RestoreNameInCluster)
+
+	return v.reconcileVerificationRestore(ctx, verification, targetCluster)
+}
+
+// reconcileVerificationRestore restores the backup into the scratch namespaces and waits for the restore to be finished.
+func (v *BackupVerificationManager) reconcileVerificationRestore(ctx context.Context, verification *backupapi.BackupVerification, targetCluster *verificationCluster) (ctrl.Result, error) {
+	if verificationTimedOut(verification, time.Now()) {
+		return v.finishVerification(ctx, verification, targetCluster, backupapi.VerificationPhaseFailed, "timed out restoring the backup")
+	}
+
+	// Ensure the velero backup has been synced to the target cluster
+	veleroBackup := &velerov1.Backup{}
+	if err := getResourceFromClusterClient(ctx, verification.Status.BackupNameInCluster, VeleroNamespace, *targetCluster.access, veleroBackup); err != nil {
+		if apierrors.IsNotFound(err) {
+			verification.Status.Message = fmt.Sprintf("waiting for backup %s to be synced to cluster %s", verification.Status.BackupNameInCluster, targetCluster.key.Name)
+			return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	restoreLabel := generateVeleroInstanceLabel(VerificationNameLabel, verification.Name, verification.Spec.TargetCluster.Fleet)
+	veleroRestore := buildVeleroRestoreFromVerification(verification, restoreLabel)
+	if err := syncVeleroObj(ctx, targetCluster.access, veleroRestore); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create velero restore %s: %w", veleroRestore.Name, err)
+	}
+
+	if err := getResourceFromClusterClient(ctx, veleroRestore.Name, VeleroNamespace, *targetCluster.access, veleroRestore); err != nil {
+		return ctrl.Result{}, err
+	}
+	verification.Status.RestoreStatusInCluster = &veleroRestore.Status
+
+	switch veleroRestore.Status.Phase {
+	case velerov1.RestorePhaseCompleted, velerov1.RestorePhasePartiallyFailed:
+		// the restore errors are recorded in the restore status, the workloads are still checked
+		verification.Status.Phase = backupapi.VerificationPhaseVerifying
+		verification.Status.Message = ""
+		return v.reconcileVerificationChecks(ctx, verification, targetCluster)
+	case velerov1.RestorePhaseFailed, velerov1.RestorePhaseFailedValidation:
+		return v.finishVerification(ctx, verification, targetCluster, backupapi.VerificationPhaseFailed,
+			fmt.Sprintf("restore %s is %s", veleroRestore.Name, veleroRestore.Status.Phase))
+	default:
+		verification.Status.Message = fmt.Sprintf("restore %s is in progress", veleroRestore.Name)
+		return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+	}
+}
+
+// reconcileVerificationChecks checks the readiness of the restored workloads until all of them are ready or the verification times out.
+func (v *BackupVerificationManager) reconcileVerificationChecks(ctx context.Context, verification *backupapi.BackupVerification, targetCluster *verificationCluster) (ctrl.Result, error) {
+	checks, err := checkRestoredWorkloads(ctx, targetCluster.access, scratchNamespaces(verification))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	verification.Status.Checks = checks
+
+	var notReady []string
+	for _, check := range checks {
+		if !check.Ready {
+			notReady = append(notReady, fmt.Sprintf("%s %s/%s", check.Kind, check.Namespace, check.Name))
+		}
+	}
+	if len(notReady) == 0 {
+		return v.finishVerification(ctx, verification, targetCluster, backupapi.VerificationPhasePassed, "")
+	}
+
+	message := fmt.Sprintf("workloads are not ready: %s", strings.Join(notReady, ", "))
+	if verificationTimedOut(verification, time.Now()) {
+		return v.finishVerification(ctx, verification, targetCluster, backupapi.VerificationPhaseFailed, message)
+	}
+	verification.Status.Message = message
+	return ctrl.Result{RequeueAfter: StatusSyncInterval}, nil
+}
+
+// finishVerification cleans up the restored resources and records the result of the verification.
+func (v *BackupVerificationManager) finishVerification(ctx context.Context, verification *backupapi.BackupVerification, targetCluster *verificationCluster,
+	result backupapi.VerificationPhase, message string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if err := cleanupVerification(ctx, verification, targetCluster.access); err != nil {
+		log.Error(err, "failed to clean up backup verification")
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	verification.Status.Phase = result
+	verification.Status.LastResult = result
+	verification.Status.Message = message
+	verification.Status.LastVerificationTime = &now
+	if result == backupapi.VerificationPhasePassed {
+		verification.Status.LastPassedTime = &now
+		conditions.MarkTrue(verification, backupapi.BackupVerifiedCondition)
+	} else {
+		conditions.MarkFalse(verification, backupapi.BackupVerifiedCondition, backupapi.VerificationFailedReason, capiv1.ConditionSeverityError, message)
+	}
+	log.Info("Backup verification finished", "result", result, "message", message)
+
+	after, _, err := nextVerification(verification, now.Time)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: after}, nil
+}
+
+// reconcileDeleteVerification cleans up the verification in progress before removing the finalizer.
+func (v *BackupVerificationManager) reconcileDeleteVerification(ctx context.Context, verification *backupapi.BackupVerification) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	phase := verification.Status.Phase
+	if phase == backupapi.VerificationPhaseRestoring || phase == backupapi.VerificationPhaseVerifying {
+		targetCluster, err := v.fetchTargetCluster(ctx, verification)
+		if err != nil {
+			log.Error(err, "failed to fetch target cluster when delete backup verification")
+			controllerutil.RemoveFinalizer(verification, VerificationFinalizer)
+			return ctrl.Result{}, err
+		}
+		if err := cleanupVerification(ctx, verification, targetCluster.access); err != nil {
+			log.Error(err, "failed to clean up backup verification")
+			return ctrl.Result{}, err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(verification, VerificationFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// verificationCluster is a cluster of the fleet with its client.
+type verificationCluster struct {
+	key    fleetmanager.ClusterKey
+	access *fleetmanager.FleetCluster
+}
+
+// fetchTargetCluster returns the cluster where the backup is restored for verification.
+func (v *BackupVerificationManager) fetchTargetCluster(ctx context.Context, verification *backupapi.BackupVerification) (*verificationCluster, error) {
+	clusters, err := fetchDestinationClusters(ctx, v.Client, verification.Namespace, verification.Spec.TargetCluster)
+	if err != nil {
+		return nil, err
+	}
+	return singleCluster(clusters, "targetCluster")
+}
+
+// fetchSourceCluster returns the cluster of the backup to verify.
+func (v *BackupVerificationManager) fetchSourceCluster(ctx context.Context, verification *backupapi.BackupVerification, backup *backupapi.Backup) (*verificationCluster, error) {
+	destination := backup.Spec.Destination
+	if verification.Spec.SourceCluster != nil {
+		destination = backupapi.Destination{
+			Fleet:    backup.Spec.Destination.Fleet,
+			Clusters: []*corev1.ObjectReference{verification.Spec.SourceCluster},
+		}
+	}
+
+	clusters, err := fetchDestinationClusters(ctx, v.Client, backup.Namespace, destination)
+	if err != nil {
+		return nil, err
+	}
+	return singleCluster(clusters, "sourceCluster")
+}
+
+func singleCluster(clusters map[fleetmanager.ClusterKey]*fleetmanager.FleetCluster, field string) (*verificationCluster, error) {
+	if len(clusters) != 1 {
+		return nil, fmt.Errorf("%s must point to exactly one cluster, but got %d clusters", field, len(clusters))
+	}
+	for key, access := range clusters {
+		return &verificationCluster{key: key, access: access}, nil
+	}
+	return nil, nil
+}
+
+// latestCompletedVeleroBackup returns the name of the latest completed velero backup of the Backup in the cluster,
+// it is empty if there is no completed backup yet.
+func latestCompletedVeleroBackup(ctx context.Context, backup *backupapi.Backup, cluster *verificationCluster) (string, error) {
+	name := generateVeleroResourceName(cluster.key.Name, BackupKind, backup.Namespace, backup.Name)
+	if !isScheduleBackup(backup) {
+		veleroBackup := &velerov1.Backup{}
+		if err := getResourceFromClusterClient(ctx, name, VeleroNamespace, *cluster.access, veleroBackup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		if veleroBackup.Status.Phase != velerov1.BackupPhaseCompleted {
+			return "", nil
+		}
+		return veleroBackup.Name, nil
+	}
+
+	backupList := &velerov1.BackupList{}
+	if err := listResourcesFromClusterClient(ctx, VeleroNamespace, velerov1.ScheduleNameLabel, name, *cluster.access, backupList); err != nil {
+		return "", err
+	}
+	return MostRecentCompletedBackup(backupList.Items).Name, nil
+}
+
+// pendingVerification records the reason why the verification can not start yet.
+func pendingVerification(verification *backupapi.BackupVerification, message string) ctrl.Result {
+	verification.Status.Phase = backupapi.VerificationPhasePending
+	verification.Status.Message = message
+	if verification.Status.LastResult == "" {
+		conditions.MarkFalse(verification, backupapi.BackupVerifiedCondition, backupapi.VerificationInProgressReason, capiv1.ConditionSeverityInfo, message)
+	}
+	return ctrl.Result{RequeueAfter: StatusSyncInterval}
+}
+
+// nextVerification returns whether a verification is due, or the duration until the next one.
+// A one-time verification is not due once finished, and a scheduled one is due at the next time of the schedule after the last start.
+func nextVerification(verification *backupapi.BackupVerification, now time.Time) (time.Duration, bool, error) {
+	status := verification.Status
+	if status.Phase == "" || status.Phase == backupapi.VerificationPhasePending || status.StartTime == nil {
+		return 0, true, nil
+	}
+	if verification.Spec.Schedule == "" {
+		return 0, false, nil
+	}
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(verification.Spec.Schedule)
+	if err != nil {
+		return 0, false, err
+	}
+	next := schedule.Next(status.StartTime.Time)
+	if !next.After(now) {
+		return 0, true, nil
+	}
+	return next.Sub(now), false, nil
+}
+
+func verificationTimedOut(verification *backupapi.BackupVerification, now time.Time) bool {
+	if verification.Status.StartTime == nil {
+		return false
+	}
+	timeout := defaultVerificationTimeout
+	if verification.Spec.Timeout != nil {
+		timeout = verification.Spec.Timeout.Duration
+	}
+	return now.Sub(verification.Status.StartTime.Time) > timeout
+}
+
+// scratchNamespaceMapping maps the namespaces to verify to the scratch namespaces.
+func scratchNamespaceMapping(verification *backupapi.BackupVerification) map[string]string {
+	prefix := verification.Spec.NamespacePrefix
+	if prefix == "" {
+		prefix = defaultVerificationNamespacePrefix
+	}
+
+	mapping := make(map[string]string, len(verification.Spec.Namespaces))
+	for _, namespace := range verification.Spec.Namespaces {
+		mapping[namespace] = prefix + "-" + namespace
+	}
+	return mapping
+}
+
+func scratchNamespaces(verification *backupapi.BackupVerification) []string {
+	namespaces := make([]string, 0, len(verification.Spec.Namespaces))
+	mapping := scratchNamespaceMapping(verification)
+	for _, namespace := range verification.Spec.Namespaces {
+		namespaces = append(namespaces, mapping[namespace])
+	}
+	return namespaces
+}
+
+// buildVeleroRestoreFromVerification constructs the velero restore of the verified namespaces into the scratch namespaces.
+func buildVeleroRestoreFromVerification(verification *backupapi.BackupVerification, labels map[string]string) *velerov1.Restore {
+	restoreParam := &backupapi.RestoreSpec{
+		Policy: &backupapi.RestorePolicy{
+			ResourceFilter: &backupapi.ResourceFilter{
+				IncludedNamespaces: verification.Spec.Namespaces,
+			},
+			NamespaceMapping: scratchNamespaceMapping(verification),
+		},
+	}
+	return buildVeleroRestoreInstance(restoreParam, labels, verification.Status.BackupNameInCluster, verification.Status.RestoreNameInCluster)
+}
+
+// cleanupVerification deletes the velero restore and the scratch namespaces of the verification.
+func cleanupVerification(ctx context.Context, verification *backupapi.BackupVerification, cluster *fleetmanager.FleetCluster) error {
+	clusterClient := cluster.GetRuntimeClient()
+
+	if verification.Status.RestoreNameInCluster != "" {
+		veleroRestore := &velerov1.Restore{
+			ObjectMeta: metav1.ObjectMeta{Name: verification.Status.RestoreNameInCluster, Namespace: VeleroNamespace},
+		}
+		if err := clusterClient.Delete(ctx, veleroRestore); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, namespace := range scratchNamespaces(verification) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if err := clusterClient.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRestoredWorkloads checks the readiness of the workloads and the persistent volume claims restored into the scratch namespaces.
+func checkRestoredWorkloads(ctx context.Context, cluster *fleetmanager.FleetCluster, namespaces []string) ([]*backupapi.WorkloadCheck, error) {
+	clusterClient := cluster.GetRuntimeClient()
+
+	var checks []*backupapi.WorkloadCheck
+	for _, namespace := range namespaces {
+		deployments := &appsv1.DeploymentList{}
+		if err := clusterClient.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range deployments.Items {
+			checks = append(checks, checkDeployment(&deployments.Items[i]))
+		}
+
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := clusterClient.List(ctx, statefulSets, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range statefulSets.Items {
+			checks = append(checks, checkStatefulSet(&statefulSets.Items[i]))
+		}
+
+		daemonSets := &appsv1.DaemonSetList{}
+		if err := clusterClient.List(ctx, daemonSets, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range daemonSets.Items {
+			checks = append(checks, checkDaemonSet(&daemonSets.Items[i]))
+		}
+
+		pods := &corev1.PodList{}
+		if err := clusterClient.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range pods.Items {
+			// pods managed by controllers are covered by the checks of their owners
+			if len(pods.Items[i].OwnerReferences) != 0 {
+				continue
+			}
+			checks = append(checks, checkPod(&pods.Items[i]))
+		}
+
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		if err := clusterClient.List(ctx, pvcs, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		for i := range pvcs.Items {
+			checks = append(checks, checkPersistentVolumeClaim(&pvcs.Items[i]))
+		}
+	}
+	return checks, nil
+}
+
+func newWorkloadCheck(kind string, obj metav1.Object, ready bool, message string) *backupapi.WorkloadCheck {
+	return &backupapi.WorkloadCheck{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Ready:     ready,
+		Message:   message,
+	}
+}
+
+func checkDeployment(deployment *appsv1.Deployment) *backupapi.WorkloadCheck {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return newWorkloadCheck("Deployment", deployment, false, "waiting for the deployment spec update to be observed")
+	}
+	if deployment.Status.ReadyReplicas < replicas {
+		return newWorkloadCheck("Deployment", deployment, false, fmt.Sprintf("%d of %d replicas are ready", deployment.Status.ReadyReplicas, replicas))
+	}
+	return newWorkloadCheck("Deployment", deployment, true, "")
+}
+
+func checkStatefulSet(statefulSet *appsv1.StatefulSet) *backupapi.WorkloadCheck {
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return newWorkloadCheck("StatefulSet", statefulSet, false, fmt.Sprintf("%d of %d replicas are ready", statefulSet.Status.ReadyReplicas, replicas))
+	}
+	return newWorkloadCheck("StatefulSet", statefulSet, true, "")
+}
+
+func checkDaemonSet(daemonSet *appsv1.DaemonSet) *backupapi.WorkloadCheck {
+	if daemonSet.Status.NumberReady < daemonSet.Status.DesiredNumberScheduled {
+		return newWorkloadCheck("DaemonSet", daemonSet, false,
+			fmt.Sprintf("%d of %d pods are ready", daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled))
+	}
+	return newWorkloadCheck("DaemonSet", daemonSet, true, "")
+}
+
+func checkPod(pod *corev1.Pod) *backupapi.WorkloadCheck {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return newWorkloadCheck("Pod", pod, true, "")
+		}
+	}
+	return newWorkloadCheck("Pod", pod, false, fmt.Sprintf("pod is %s", pod.Status.Phase))
+}
+
+func checkPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) *backupapi.WorkloadCheck {
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return newWorkloadCheck("PersistentVolumeClaim", pvc, false, fmt.Sprintf("claim is %s", pvc.Status.Phase))
+	}
+	return newWorkloadCheck("PersistentVolumeClaim", pvc, true, "")
+}
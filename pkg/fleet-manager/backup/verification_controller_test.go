@@ -0,0 +1,221 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+	http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+func TestNextVerification(t *testing.T) {
+	now := time.Date(2024, 1, 7, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	cases := []struct {
+		name         string
+		verification *backupapi.BackupVerification
+		expectedDue  bool
+		expectedNext time.Duration
+		expectErr    bool
+	}{
+		{
+			name:         "never run",
+			verification: &backupapi.BackupVerification{},
+			expectedDue:  true,
+		},
+		{
+			name: "pending",
+			verification: &backupapi.BackupVerification{
+				Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhasePending, StartTime: &started},
+			},
+			expectedDue: true,
+		},
+		{
+			name: "one-time verification finished",
+			verification: &backupapi.BackupVerification{
+				Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhasePassed, StartTime: &started},
+			},
+			expectedDue: false,
+		},
+		{
+			name: "scheduled verification not due",
+			verification: &backupapi.BackupVerification{
+				Spec:   backupapi.BackupVerificationSpec{Schedule: "0 14 * * *"},
+				Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhaseFailed, StartTime: &started},
+			},
+			expectedDue:  false,
+			expectedNext: 2 * time.Hour,
+		},
+		{
+			name: "scheduled verification due",
+			verification: &backupapi.BackupVerification{
+				Spec:   backupapi.BackupVerificationSpec{Schedule: "0 11 * * *"},
+				Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhasePassed, StartTime: &started},
+			},
+			expectedDue: true,
+		},
+		{
+			name: "invalid schedule",
+			verification: &backupapi.BackupVerification{
+				Spec:   backupapi.BackupVerificationSpec{Schedule: "invalid"},
+				Status: backupapi.BackupVerificationStatus{Phase: backupapi.VerificationPhasePassed, StartTime: &started},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, due, err := nextVerification(tc.verification, now)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDue, due)
+			assert.Equal(t, tc.expectedNext, next)
+		})
+	}
+}
+
+func TestVerificationTimedOut(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-time.Hour))
+
+	verification := &backupapi.BackupVerification{}
+	assert.False(t, verificationTimedOut(verification, now))
+
+	verification.Status.StartTime = &started
+	assert.True(t, verificationTimedOut(verification, now))
+
+	verification.Spec.Timeout = &metav1.Duration{Duration: 2 * time.Hour}
+	assert.False(t, verificationTimedOut(verification, now))
+}
+
+func TestBuildVeleroRestoreFromVerification(t *testing.T) {
+	verification := &backupapi.BackupVerification{
+		Spec: backupapi.BackupVerificationSpec{
+			Namespaces: []string{"app", "db"},
+		},
+		Status: backupapi.BackupVerificationStatus{
+			BackupNameInCluster:  "kurator-member1-backup-default-schedule-20240107110000",
+			RestoreNameInCluster: "kurator-member2-verification-default-drill-20240107120000",
+		},
+	}
+
+	restore := buildVeleroRestoreFromVerification(verification, map[string]string{VerificationNameLabel: "drill"})
+	assert.Equal(t, "kurator-member2-verification-default-drill-20240107120000", restore.Name)
+	assert.Equal(t, "kurator-member1-backup-default-schedule-20240107110000", restore.Spec.BackupName)
+	assert.Equal(t, []string{"app", "db"}, restore.Spec.IncludedNamespaces)
+	assert.Equal(t, map[string]string{"app": "kurator-verify-app", "db": "kurator-verify-db"}, restore.Spec.NamespaceMapping)
+
+	verification.Spec.NamespacePrefix = "drill"
+	assert.Equal(t, []string{"drill-app", "drill-db"}, scratchNamespaces(verification))
+}
+
+func TestWorkloadChecks(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Namespace: "kurator-verify-app", Name: "demo", Generation: 2}
+
+	cases := []struct {
+		name     string
+		check    *backupapi.WorkloadCheck
+		expected bool
+	}{
+		{
+			name: "deployment ready",
+			check: checkDeployment(&appsv1.Deployment{
+				ObjectMeta: objectMeta,
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, ReadyReplicas: 2},
+			}),
+			expected: true,
+		},
+		{
+			name: "deployment generation not observed",
+			check: checkDeployment(&appsv1.Deployment{
+				ObjectMeta: objectMeta,
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, ReadyReplicas: 1},
+			}),
+			expected: false,
+		},
+		{
+			name: "deployment replicas not ready",
+			check: checkDeployment(&appsv1.Deployment{
+				ObjectMeta: objectMeta,
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(3)},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, ReadyReplicas: 1},
+			}),
+			expected: false,
+		},
+		{
+			name: "statefulset not ready",
+			check: checkStatefulSet(&appsv1.StatefulSet{
+				ObjectMeta: objectMeta,
+				Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(1)},
+			}),
+			expected: false,
+		},
+		{
+			name: "daemonset ready",
+			check: checkDaemonSet(&appsv1.DaemonSet{
+				ObjectMeta: objectMeta,
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3},
+			}),
+			expected: true,
+		},
+		{
+			name: "pod ready",
+			check: checkPod(&corev1.Pod{
+				ObjectMeta: objectMeta,
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}),
+			expected: true,
+		},
+		{
+			name:     "pod pending",
+			check:    checkPod(&corev1.Pod{ObjectMeta: objectMeta, Status: corev1.PodStatus{Phase: corev1.PodPending}}),
+			expected: false,
+		},
+		{
+			name: "pvc bound",
+			check: checkPersistentVolumeClaim(&corev1.PersistentVolumeClaim{
+				ObjectMeta: objectMeta,
+				Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+			}),
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.check.Ready)
+			assert.Equal(t, "kurator-verify-app", tc.check.Namespace)
+			assert.Equal(t, "demo", tc.check.Name)
+			if !tc.expected {
+				assert.NotEmpty(t, tc.check.Message)
+			}
+		})
+	}
+}
@@ -29,10 +29,13 @@ import (
 	"github.com/karmada-io/karmada/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubeclient "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -53,6 +56,9 @@ const (
 	AttachedClusterKind = "AttachedCluster"
 )
 
+// clusterProbeTimeout bounds the probe of a member cluster in every fleet reconcile.
+const clusterProbeTimeout = 5 * time.Second
+
 func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fleet) (ctrl.Result, error) {
 	controlplane := fleet.Annotations[fleetapi.ControlplaneAnnotation]
 	controlplaneSpecified := true
@@ -61,12 +67,33 @@ func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fl
 	}
 
 	log := ctrl.LoggerFrom(ctx)
-	var unreadyClusters int32
+	var readyClusters, unreadyClusters int32
 	var result ctrl.Result
-	var readyClusters []ClusterInterface
-	clusterMap := make(map[string]struct{}, len(fleet.Spec.Clusters))
+	var joinClusters []ClusterInterface
+
+	// Resolve the clusters belonging to other fleets as well to report the conflicts.
+	clusters, err := resolveFleetClusterRefs(ctx, f.Client, fleet)
+	if err != nil {
+		log.Error(err, "unable to resolve fleet clusters")
+		return result, err
+	}
+
+	clusterMap := make(map[string]struct{}, len(clusters))
+	clusterStatuses := make([]*fleetapi.FleetClusterStatus, 0, len(clusters))
+	joinClusterStatuses := make(map[string]*fleetapi.FleetClusterStatus, len(clusters))
+	var unreadyClusterNames, unreachableClusterNames, conflictClusterNames []string
 	// Loop over cluster, and add labels to the cluster
-	for _, cluster := range fleet.Spec.Clusters {
+	for _, cluster := range clusters {
+		kind := cluster.Kind
+		if kind == "" {
+			kind = ClusterKind
+		}
+		clusterStatus := &fleetapi.FleetClusterStatus{
+			Kind: kind,
+			Name: cluster.Name,
+		}
+		clusterStatuses = append(clusterStatuses, clusterStatus)
+
 		// cluster namespace can be not set, always use fleet namespace as a fleet can only include clusters in the same namespace.
 		clusterKey := types.NamespacedName{Name: cluster.Name, Namespace: fleet.Namespace}
 		currentCluster, err := getFleetClusterInterface(ctx, f.Client, cluster.Kind, clusterKey)
@@ -75,7 +102,17 @@ func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fl
 				log.Error(err, "unable to fetch cluster", "cluster", clusterKey, "kind", cluster.Kind)
 				return result, err
 			}
+			clusterStatus.Message = "cluster not found"
 			unreadyClusters++
+			unreadyClusterNames = append(unreadyClusterNames, cluster.Name)
+			continue
+		}
+
+		// A cluster can only be a member of one fleet, leave the clusters of other fleets untouched.
+		if owner, ok := ownedByOtherFleet(currentCluster.GetObject(), fleet); ok {
+			clusterStatus.Message = fmt.Sprintf("cluster belongs to fleet %s", owner)
+			unreadyClusters++
+			conflictClusterNames = append(conflictClusterNames, cluster.Name)
 			continue
 		}
 
@@ -94,16 +131,50 @@ func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fl
 				return ctrl.Result{}, err
 			}
 		}
-		// Register the ready cluster to the control plane
-		if currentCluster.IsReady() {
-			readyClusters = append(readyClusters, currentCluster)
-		} else {
+		// the cluster joins when it is registered to the control plane if it is specified.
+		clusterStatus.Joined = !controlplaneSpecified
+
+		if !currentCluster.IsReady() {
+			clusterStatus.Message = "cluster is not ready"
 			unreadyClusters++
+			unreadyClusterNames = append(unreadyClusterNames, cluster.Name)
+			continue
+		}
+
+		// Probe the ready cluster, a probe failure is reported in the status but does not block the registration to the control plane.
+		probeTime := metav1.Now()
+		clusterStatus.LastProbeTime = &probeTime
+		version, err := probeCluster(ctx, f.Client, fleet.Namespace, currentCluster)
+		if err != nil {
+			log.Error(err, "failed to probe cluster", "cluster", clusterKey)
+			clusterStatus.Message = fmt.Sprintf("failed to probe cluster: %v", err)
+			unreadyClusters++
+			unreachableClusterNames = append(unreachableClusterNames, cluster.Name)
+		} else {
+			clusterStatus.Ready = true
+			clusterStatus.KubernetesVersion = version
+			readyClusters++
 		}
+		joinClusters = append(joinClusters, currentCluster)
+		joinClusterStatuses[generateClusterNameInKarmada(currentCluster)] = clusterStatus
 	}
 
-	fleet.Status.ReadyClusters = int32(len(readyClusters))
+	fleet.Status.ReadyClusters = readyClusters
 	fleet.Status.UnReadyClusters = unreadyClusters
+	fleet.Status.Clusters = clusterStatuses
+	switch {
+	case len(conflictClusterNames) > 0:
+		conditions.MarkFalse(fleet, fleetapi.ClustersReadyCondition, fleetapi.ClustersConflictReason, capiv1beta1.ConditionSeverityWarning,
+			"clusters %s belong to other fleets", strings.Join(conflictClusterNames, ", "))
+	case len(unreadyClusterNames) > 0:
+		conditions.MarkFalse(fleet, fleetapi.ClustersReadyCondition, fleetapi.ClustersNotReadyReason, capiv1beta1.ConditionSeverityWarning,
+			"clusters %s are not ready", strings.Join(unreadyClusterNames, ", "))
+	case len(unreachableClusterNames) > 0:
+		conditions.MarkFalse(fleet, fleetapi.ClustersReadyCondition, fleetapi.ClustersUnreachableReason, capiv1beta1.ConditionSeverityWarning,
+			"clusters %s are unreachable", strings.Join(unreachableClusterNames, ", "))
+	default:
+		conditions.MarkTrue(fleet, fleetapi.ClustersReadyCondition)
+	}
 
 	var controlplaneRestConfig *restclient.Config
 	if controlplaneSpecified {
@@ -119,18 +190,21 @@ func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fl
 			log.Error(err, "build restconfig for controlplane failed")
 			return result, fmt.Errorf("build restconfig for controlplane failed %v", err)
 		}
-		for _, cluster := range readyClusters {
+		for _, cluster := range joinClusters {
+			clusterStatus := joinClusterStatuses[generateClusterNameInKarmada(cluster)]
 			err := f.joinCluster(ctx, controlplaneRestConfig, cluster)
 			if err != nil {
 				log.Error(err, "Join cluster failed")
+				clusterStatus.Message = err.Error()
 				return result, err
 			}
+			clusterStatus.Joined = true
 		}
 	}
 
 	// Handle cluster unjoin
 	var clusterList clusterv1alpha1.ClusterList
-	err := f.Client.List(ctx, &clusterList,
+	err = f.Client.List(ctx, &clusterList,
 		client.InNamespace(fleet.Namespace),
 		client.MatchingLabels{FleetLabel: fleet.Name})
 	if err != nil {
@@ -185,8 +259,15 @@ func (f *FleetManager) reconcileClusters(ctx context.Context, fleet *fleetapi.Fl
 func (f *FleetManager) reconcileClustersOnDelete(ctx context.Context, fleet *fleetapi.Fleet) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	var result ctrl.Result
-	// Loop over cluster, and add labels to the cluster
-	for _, cluster := range fleet.Spec.Clusters {
+
+	clusters, err := FleetClusterRefs(ctx, f.Client, fleet)
+	if err != nil {
+		log.Error(err, "unable to resolve fleet clusters")
+		return result, err
+	}
+
+	// Loop over cluster, and remove labels from the cluster
+	for _, cluster := range clusters {
 		// cluster namespace can be not set, always use fleet namespace as a fleet can only include clusters in the same namespace.
 		clusterKey := types.NamespacedName{Name: cluster.Name, Namespace: fleet.Namespace}
 		currentCluster, err := getFleetClusterInterface(ctx, f.Client, cluster.Kind, clusterKey)
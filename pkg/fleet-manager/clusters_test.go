@@ -0,0 +1,210 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+)
+
+func kubeconfigSecret(name, server string) *corev1.Secret {
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: %s
+contexts:
+- name: cluster
+  context:
+    cluster: cluster
+    user: user
+current-context: cluster
+users:
+- name: user
+  user: {}
+`, server)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       map[string][]byte{"kubeconfig": []byte(kubeconfig)},
+	}
+}
+
+func attachedCluster(name string, ready bool, labels map[string]string) *clusterv1alpha1.AttachedCluster {
+	return &clusterv1alpha1.AttachedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec:       clusterv1alpha1.AttachedClusterSpec{Kubeconfig: clusterv1alpha1.SecretKeyRef{Name: name, Key: "kubeconfig"}},
+		Status:     clusterv1alpha1.AttachedClusterStatus{Ready: ready},
+	}
+}
+
+func TestReconcileClusters(t *testing.T) {
+	reachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"28","gitVersion":"v1.28.0"}`))
+	}))
+	defer reachable.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	assert.NoError(t, clusterv1alpha1.AddToScheme(scheme))
+	assert.NoError(t, fleetapi.AddToScheme(scheme))
+
+	objs := []client.Object{
+		attachedCluster("ready", true, nil),
+		kubeconfigSecret("ready", reachable.URL),
+		attachedCluster("unreachable", true, nil),
+		kubeconfigSecret("unreachable", unreachable.URL),
+		attachedCluster("notready", false, nil),
+		attachedCluster("other", true, map[string]string{FleetLabel: "other"}),
+	}
+
+	cases := []struct {
+		name            string
+		clusters        []string
+		expected        []*fleetapi.FleetClusterStatus
+		readyClusters   int32
+		conditionTrue   bool
+		conditionReason string
+	}{
+		{
+			name:          "all clusters are ready",
+			clusters:      []string{"ready"},
+			readyClusters: 1,
+			expected: []*fleetapi.FleetClusterStatus{
+				{Kind: AttachedClusterKind, Name: "ready", Joined: true, Ready: true, KubernetesVersion: "v1.28.0"},
+			},
+			conditionTrue: true,
+		},
+		{
+			name:          "unreachable cluster still joins",
+			clusters:      []string{"ready", "unreachable"},
+			readyClusters: 1,
+			expected: []*fleetapi.FleetClusterStatus{
+				{Kind: AttachedClusterKind, Name: "ready", Joined: true, Ready: true, KubernetesVersion: "v1.28.0"},
+				{Kind: AttachedClusterKind, Name: "unreachable", Joined: true},
+			},
+			conditionReason: fleetapi.ClustersUnreachableReason,
+		},
+		{
+			name:          "not ready and missing clusters",
+			clusters:      []string{"unreachable", "notready", "missing"},
+			readyClusters: 0,
+			expected: []*fleetapi.FleetClusterStatus{
+				{Kind: AttachedClusterKind, Name: "unreachable", Joined: true},
+				{Kind: AttachedClusterKind, Name: "notready", Joined: true, Message: "cluster is not ready"},
+				{Kind: AttachedClusterKind, Name: "missing", Message: "cluster not found"},
+			},
+			conditionReason: fleetapi.ClustersNotReadyReason,
+		},
+		{
+			name:          "cluster of another fleet",
+			clusters:      []string{"ready", "other"},
+			readyClusters: 1,
+			expected: []*fleetapi.FleetClusterStatus{
+				{Kind: AttachedClusterKind, Name: "ready", Joined: true, Ready: true, KubernetesVersion: "v1.28.0"},
+				{Kind: AttachedClusterKind, Name: "other", Message: "cluster belongs to fleet other"},
+			},
+			conditionReason: fleetapi.ClustersConflictReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			f := &FleetManager{Client: c}
+
+			fleet := &fleetapi.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"}}
+			for _, name := range tc.clusters {
+				fleet.Spec.Clusters = append(fleet.Spec.Clusters, &corev1.ObjectReference{Kind: AttachedClusterKind, Name: name})
+			}
+
+			_, err := f.reconcileClusters(context.Background(), fleet)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.readyClusters, fleet.Status.ReadyClusters)
+			assert.Equal(t, int32(len(tc.clusters))-tc.readyClusters, fleet.Status.UnReadyClusters)
+			assert.Len(t, fleet.Status.Clusters, len(tc.expected))
+			for i, expected := range tc.expected {
+				got := fleet.Status.Clusters[i]
+				if got.Name == "unreachable" {
+					assert.Contains(t, got.Message, "failed to probe cluster")
+					got.Message = ""
+				}
+				if got.Ready || got.Name == "unreachable" {
+					assert.NotNil(t, got.LastProbeTime)
+					got.LastProbeTime = nil
+				}
+				assert.Equal(t, expected, got)
+			}
+
+			if tc.conditionTrue {
+				assert.True(t, conditions.IsTrue(fleet, fleetapi.ClustersReadyCondition))
+			} else {
+				assert.True(t, conditions.IsFalse(fleet, fleetapi.ClustersReadyCondition))
+				assert.Equal(t, tc.conditionReason, conditions.GetReason(fleet, fleetapi.ClustersReadyCondition))
+				assert.Equal(t, capiv1beta1.ConditionSeverityWarning, *conditions.GetSeverity(fleet, fleetapi.ClustersReadyCondition))
+			}
+
+			// the clusters of other fleets are never relabeled
+			for _, name := range tc.clusters {
+				cluster := &clusterv1alpha1.AttachedCluster{}
+				if err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, cluster); err != nil {
+					continue
+				}
+				if name == "other" {
+					assert.Equal(t, "other", cluster.Labels[FleetLabel])
+				} else {
+					assert.Equal(t, "fleet", cluster.Labels[FleetLabel])
+				}
+			}
+		})
+	}
+}
+
+func TestClusterStatusReady(t *testing.T) {
+	fleet := &fleetapi.Fleet{
+		Status: fleetapi.FleetStatus{
+			Clusters: []*fleetapi.FleetClusterStatus{
+				{Kind: ClusterKind, Name: "ready", Ready: true},
+				{Kind: AttachedClusterKind, Name: "unreachable"},
+			},
+		},
+	}
+
+	assert.True(t, clusterStatusReady(fleet, "", "ready"))
+	assert.False(t, clusterStatusReady(fleet, AttachedClusterKind, "unreachable"))
+	// the clusters not reconciled yet are considered ready
+	assert.True(t, clusterStatusReady(fleet, AttachedClusterKind, "new"))
+}
@@ -20,14 +20,18 @@ import (
 	"context"
 	"fmt"
 	"io/fs"
+	"sync"
 	"time"
 
+	hrapiv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apiserrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,6 +56,9 @@ type FleetManager struct {
 	client.Client
 	Scheme    *runtime.Scheme
 	Manifests fs.FS
+
+	// pluginUpgradeLock guards the plugin upgrade status of the fleet, which is updated by the plugins concurrently.
+	pluginUpgradeLock sync.Mutex
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -72,18 +79,25 @@ func (f *FleetManager) SetupWithManager(mgr ctrl.Manager) error {
 
 	if err := c.Watch(
 		source.Kind(mgr.GetCache(), &clusterv1alpha1.Cluster{}),
-		handler.EnqueueRequestsFromMapFunc(f.objectToFleetFunc),
+		handler.EnqueueRequestsFromMapFunc(f.clusterToFleetFunc),
 	); err != nil {
 		return fmt.Errorf("failed adding Watch for Cluster: %v", err)
 	}
 
 	if err := c.Watch(
 		source.Kind(mgr.GetCache(), &clusterv1alpha1.AttachedCluster{}),
-		handler.EnqueueRequestsFromMapFunc(f.objectToFleetFunc),
+		handler.EnqueueRequestsFromMapFunc(f.clusterToFleetFunc),
 	); err != nil {
 		return fmt.Errorf("failed adding Watch for AttachedCluster: %v", err)
 	}
 
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &hrapiv2b1.HelmRelease{}),
+		handler.EnqueueRequestsFromMapFunc(f.pluginResourceToFleetFunc),
+	); err != nil {
+		return fmt.Errorf("failed adding Watch for HelmRelease: %v", err)
+	}
+
 	return nil
 }
 
@@ -103,6 +117,63 @@ func (f *FleetManager) objectToFleetFunc(ctx context.Context, o client.Object) [
 	return nil
 }
 
+// pluginResourceToFleetFunc maps a plugin resource to the fleet it belongs to,
+// so that the plugin status of the fleet is updated as soon as the resource changes.
+func (f *FleetManager) pluginResourceToFleetFunc(ctx context.Context, o client.Object) []ctrl.Request {
+	labels := o.GetLabels()
+	if labels[ManagedByLabel] != ManagedByFleetManager || labels[FleetNameLabel] == "" {
+		return nil
+	}
+
+	return []ctrl.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: o.GetNamespace(),
+				Name:      labels[FleetNameLabel],
+			},
+		},
+	}
+}
+
+// clusterToFleetFunc maps a cluster to the fleet it has been registered to,
+// as well as the fleets whose cluster selector matches the cluster.
+func (f *FleetManager) clusterToFleetFunc(ctx context.Context, o client.Object) []ctrl.Request {
+	var kind string
+	switch o.(type) {
+	case *clusterv1alpha1.Cluster:
+		kind = ClusterKind
+	case *clusterv1alpha1.AttachedCluster:
+		kind = AttachedClusterKind
+	default:
+		return nil
+	}
+
+	requests := f.objectToFleetFunc(ctx, o)
+
+	fleetList := &fleetapi.FleetList{}
+	if err := f.List(ctx, fleetList, client.InNamespace(o.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list fleets", "namespace", o.GetNamespace())
+		return requests
+	}
+
+	for _, fleet := range fleetList.Items {
+		if o.GetLabels()[FleetLabel] == fleet.Name {
+			// already enqueued
+			continue
+		}
+		if isClusterSelected(fleet.Spec.ClusterSelector, kind, o.GetLabels()) {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: fleet.Namespace,
+					Name:      fleet.Name,
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
 func (f *FleetManager) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx).WithValues("fleet", req.NamespacedName)
 
@@ -121,6 +192,15 @@ func (f *FleetManager) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.
 	}
 
 	defer func() {
+		if fleet.DeletionTimestamp == nil {
+			conditions.SetSummary(fleet,
+				conditions.WithConditions(
+					fleetapi.ControlPlaneReadyCondition,
+					fleetapi.ClustersReadyCondition,
+					fleetapi.PluginsReadyCondition,
+				),
+			)
+		}
 		if err := patchHelper.Patch(ctx, fleet); err != nil {
 			reterr = utilerrors.NewAggregate([]error{reterr, errors.Wrapf(err, "failed to patch fleet %s", req.NamespacedName)})
 		}
@@ -153,18 +233,25 @@ func (f *FleetManager) reconcile(ctx context.Context, fleet *fleetapi.Fleet) (ct
 		log.Error(err, "controlplane reconcile failed")
 		fleet.Status.Phase = fleetapi.FailedPhase
 		fleet.Status.Reason = err.Error()
+		conditions.MarkFalse(fleet, fleetapi.ControlPlaneReadyCondition, fleetapi.ControlPlaneFailedReason, capiv1beta1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
 
 	if fleet.Status.Phase != fleetapi.ReadyPhase {
+		conditions.MarkFalse(fleet, fleetapi.ControlPlaneReadyCondition, fleetapi.ControlPlaneInstallingReason, capiv1beta1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
+	conditions.MarkTrue(fleet, fleetapi.ControlPlaneReadyCondition)
 
 	// Loop over all clusters and reconcile them.
 	res, err := f.reconcileClusters(ctx, fleet)
-	if err != nil || res.RequeueAfter > 0 {
+	if err != nil {
+		conditions.MarkFalse(fleet, fleetapi.ClustersReadyCondition, fleetapi.ClustersReconcileFailedReason, capiv1beta1.ConditionSeverityError, err.Error())
 		return res, err
 	}
+	if res.RequeueAfter > 0 {
+		return res, nil
+	}
 
 	fleetClusters, err := BuildFleetClusters(ctx, f.Client, fleet)
 	if err != nil {
@@ -184,6 +271,10 @@ func (f *FleetManager) reconcileDelete(ctx context.Context, fleet *fleetapi.Flee
 		return res, err
 	}
 
+	if err := f.cleanupPlugins(ctx, fleet); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Delete fleet control plane
 	if err := f.deleteControlPlane(ctx, fleet); err != nil {
 		return ctrl.Result{}, err
@@ -21,7 +21,12 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +40,7 @@ import (
 type FleetCluster struct {
 	Secret    string
 	SecretKey string
+	Labels    map[string]string
 	Client    *kclient.Client
 }
 
@@ -46,8 +52,13 @@ type ClusterKey struct {
 func BuildFleetClusters(ctx context.Context, client client.Client, fleet *fleetapi.Fleet) (map[ClusterKey]*FleetCluster, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	res := make(map[ClusterKey]*FleetCluster, len(fleet.Spec.Clusters))
-	for _, cluster := range fleet.Spec.Clusters {
+	clusters, err := FleetClusterRefs(ctx, client, fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[ClusterKey]*FleetCluster, len(clusters))
+	for _, cluster := range clusters {
 		clusterKey := types.NamespacedName{Namespace: fleet.Namespace, Name: cluster.Name}
 		clusterInterface, err := getFleetClusterInterface(ctx, client, cluster.Kind, clusterKey)
 		// TODO: should we make it work
@@ -60,6 +71,12 @@ func BuildFleetClusters(ctx context.Context, client client.Client, fleet *fleeta
 			continue
 		}
 
+		// Skip the clusters which failed to be probed in the last cluster reconcile.
+		if !clusterStatusReady(fleet, cluster.Kind, cluster.Name) {
+			log.V(4).Info("cluster is unreachable", "cluster", clusterKey)
+			continue
+		}
+
 		kclient, err := ClientForCluster(client, fleet.Namespace, clusterInterface)
 		if err != nil {
 			return nil, err
@@ -67,6 +84,7 @@ func BuildFleetClusters(ctx context.Context, client client.Client, fleet *fleeta
 		res[ClusterKey{Kind: cluster.Kind, Name: cluster.Name}] = &FleetCluster{
 			Secret:    clusterInterface.GetSecretName(),
 			SecretKey: clusterInterface.GetSecretKey(),
+			Labels:    clusterInterface.GetObject().GetLabels(),
 			Client:    kclient,
 		}
 	}
@@ -74,6 +92,136 @@ func BuildFleetClusters(ctx context.Context, client client.Client, fleet *fleeta
 	return res, nil
 }
 
+// FleetClusterRefs returns the references of the member clusters of the fleet,
+// which are the clusters listed in spec and the clusters matching the cluster selector,
+// except the clusters belonging to other fleets.
+func FleetClusterRefs(ctx context.Context, c client.Client, fleet *fleetapi.Fleet) ([]*corev1.ObjectReference, error) {
+	clusters, err := resolveFleetClusterRefs(ctx, c, fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*corev1.ObjectReference, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Kind != "" && cluster.Kind != ClusterKind && cluster.Kind != AttachedClusterKind {
+			// unsupported kinds are left to the callers
+			res = append(res, cluster)
+			continue
+		}
+
+		clusterKey := types.NamespacedName{Namespace: fleet.Namespace, Name: cluster.Name}
+		clusterInterface, err := getFleetClusterInterface(ctx, c, cluster.Kind, clusterKey)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// missing clusters are left to the callers
+				res = append(res, cluster)
+				continue
+			}
+			return nil, err
+		}
+		if _, ok := ownedByOtherFleet(clusterInterface.GetObject(), fleet); ok {
+			continue
+		}
+		res = append(res, cluster)
+	}
+
+	return res, nil
+}
+
+// resolveFleetClusterRefs returns the references of the clusters listed in spec and the clusters matching the cluster selector,
+// including the clusters belonging to other fleets.
+func resolveFleetClusterRefs(ctx context.Context, c client.Client, fleet *fleetapi.Fleet) ([]*corev1.ObjectReference, error) {
+	res := make([]*corev1.ObjectReference, 0, len(fleet.Spec.Clusters))
+	visited := make(map[ClusterKey]struct{}, len(fleet.Spec.Clusters))
+	for _, cluster := range fleet.Spec.Clusters {
+		key := ClusterKey{Kind: cluster.Kind, Name: cluster.Name}
+		if key.Kind == "" {
+			key.Kind = ClusterKind
+		}
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		res = append(res, cluster)
+	}
+
+	selector := fleet.Spec.ClusterSelector
+	if selector == nil {
+		return res, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector of fleet %s/%s: %w", fleet.Namespace, fleet.Name, err)
+	}
+
+	var selected []ClusterKey
+	if selector.Kind == "" || selector.Kind == ClusterKind {
+		clusterList := &clusterv1alpha1.ClusterList{}
+		if err := c.List(ctx, clusterList, client.InNamespace(fleet.Namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusterList.Items {
+			selected = append(selected, ClusterKey{Kind: ClusterKind, Name: cluster.Name})
+		}
+	}
+	if selector.Kind == "" || selector.Kind == AttachedClusterKind {
+		attachedClusterList := &clusterv1alpha1.AttachedClusterList{}
+		if err := c.List(ctx, attachedClusterList, client.InNamespace(fleet.Namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+			return nil, err
+		}
+		for _, attachedCluster := range attachedClusterList.Items {
+			selected = append(selected, ClusterKey{Kind: AttachedClusterKind, Name: attachedCluster.Name})
+		}
+	}
+
+	for _, key := range selected {
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		visited[key] = struct{}{}
+		res = append(res, &corev1.ObjectReference{Kind: key.Kind, Name: key.Name})
+	}
+
+	return res, nil
+}
+
+// clusterStatusReady checks whether the cluster is ready in the fleet status,
+// the clusters that have not been reconciled yet are considered ready.
+func clusterStatusReady(fleet *fleetapi.Fleet, kind, name string) bool {
+	if kind == "" {
+		kind = ClusterKind
+	}
+	for _, status := range fleet.Status.Clusters {
+		if status.Kind == kind && status.Name == name {
+			return status.Ready
+		}
+	}
+	return true
+}
+
+// ownedByOtherFleet returns the fleet owning the cluster if it is not the given fleet.
+func ownedByOtherFleet(cluster client.Object, fleet *fleetapi.Fleet) (string, bool) {
+	owner := cluster.GetLabels()[FleetLabel]
+	return owner, owner != "" && owner != fleet.Name
+}
+
+// isClusterSelected checks whether a cluster of the kind with the labels matches the cluster selector.
+func isClusterSelected(selector *fleetapi.ClusterSelector, kind string, clusterLabels map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	if selector.Kind != "" && selector.Kind != kind {
+		return false
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return labelSelector.Matches(labels.Set(clusterLabels))
+}
+
 func getFleetClusterInterface(ctx context.Context, client client.Client, kind string, nn types.NamespacedName) (ClusterInterface, error) {
 	switch kind {
 	case ClusterKind, "":
@@ -93,25 +241,56 @@ func getFleetClusterInterface(ctx context.Context, client client.Client, kind st
 	}
 }
 
-func ClientForCluster(client client.Client, ns string, cluster ClusterInterface) (*kclient.Client, error) {
-	secret := &corev1.Secret{}
-	nn := types.NamespacedName{Namespace: ns, Name: cluster.GetSecretName()}
-	if err := client.Get(context.Background(), nn, secret); err != nil {
-		return nil, err
+// probeCluster checks whether the cluster is reachable and returns its kubernetes version.
+// The probe is bounded by clusterProbeTimeout, so that an unreachable cluster does not stall the fleet reconcile.
+func probeCluster(ctx context.Context, client client.Client, ns string, cluster ClusterInterface) (string, error) {
+	rest, err := restConfigForKubeConfigSecret(ctx, client, ns, cluster.GetSecretName(), cluster.GetSecretKey())
+	if err != nil {
+		return "", err
 	}
+	rest.Timeout = clusterProbeTimeout
 
-	kubeconfig, ok := secret.Data[cluster.GetSecretKey()]
-	if !ok {
-		return nil, fmt.Errorf("key %q not found in secret %s/%s", cluster.GetSecretKey(), secret.Namespace, secret.Name)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(rest)
+	if err != nil {
+		return "", err
 	}
+	version, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+func ClientForCluster(client client.Client, ns string, cluster ClusterInterface) (*kclient.Client, error) {
+	return ClientForKubeConfigSecret(client, ns, cluster.GetSecretName(), cluster.GetSecretKey())
+}
 
-	rest, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+// ClientForKubeConfigSecret creates a client from the kubeconfig stored in the key of the secret.
+func ClientForKubeConfigSecret(client client.Client, ns, name, key string) (*kclient.Client, error) {
+	rest, err := restConfigForKubeConfigSecret(context.Background(), client, ns, name, key)
 	if err != nil {
 		return nil, err
 	}
 
 	return kclient.NewClient(kclient.NewRESTClientGetter(rest))
 }
+
+// restConfigForKubeConfigSecret builds the rest config from the kubeconfig stored in the key of the secret.
+func restConfigForKubeConfigSecret(ctx context.Context, client client.Client, ns, name, key string) (*rest.Config, error) {
+	secret := &corev1.Secret{}
+	nn := types.NamespacedName{Namespace: ns, Name: name}
+	if err := client.Get(ctx, nn, secret); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in secret %s/%s", key, secret.Namespace, secret.Name)
+	}
+
+	return clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+}
+
 func WrapClient(client client.Client) (*kclient.Client, error) {
 	rest, err := config.GetConfig()
 	if err != nil {
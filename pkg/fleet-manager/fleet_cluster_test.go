@@ -0,0 +1,168 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1alpha1 "kurator.dev/kurator/pkg/apis/cluster/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+)
+
+func TestFleetClusterRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clusterv1alpha1.AddToScheme(scheme))
+	assert.NoError(t, fleetapi.AddToScheme(scheme))
+
+	objs := []runtime.Object{
+		&clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "default", Labels: map[string]string{"env": "prod"}}},
+		&clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c2", Namespace: "default", Labels: map[string]string{"env": "dev"}}},
+		&clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c3", Namespace: "other", Labels: map[string]string{"env": "prod"}}},
+		&clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "a1", Namespace: "default", Labels: map[string]string{"env": "prod"}}},
+		&clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "a2", Namespace: "default", Labels: map[string]string{"env": "test", FleetLabel: "other"}}},
+		&clusterv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c4", Namespace: "default", Labels: map[string]string{"env": "test", FleetLabel: "fleet"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	cases := []struct {
+		name     string
+		spec     fleetapi.FleetSpec
+		expected []*corev1.ObjectReference
+	}{
+		{
+			name: "static clusters only",
+			spec: fleetapi.FleetSpec{
+				Clusters: []*corev1.ObjectReference{{Kind: ClusterKind, Name: "c2"}},
+			},
+			expected: []*corev1.ObjectReference{{Kind: ClusterKind, Name: "c2"}},
+		},
+		{
+			name: "selector of all kinds",
+			spec: fleetapi.FleetSpec{
+				ClusterSelector: &fleetapi.ClusterSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+			expected: []*corev1.ObjectReference{
+				{Kind: ClusterKind, Name: "c1"},
+				{Kind: AttachedClusterKind, Name: "a1"},
+			},
+		},
+		{
+			name: "selector with kind merged with static clusters",
+			spec: fleetapi.FleetSpec{
+				Clusters: []*corev1.ObjectReference{
+					{Kind: ClusterKind, Name: "c2"},
+					{Kind: AttachedClusterKind, Name: "a1"},
+				},
+				ClusterSelector: &fleetapi.ClusterSelector{
+					Kind:          AttachedClusterKind,
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+			},
+			expected: []*corev1.ObjectReference{
+				{Kind: ClusterKind, Name: "c2"},
+				{Kind: AttachedClusterKind, Name: "a1"},
+			},
+		},
+		{
+			name: "clusters of other fleets are skipped",
+			spec: fleetapi.FleetSpec{
+				Clusters: []*corev1.ObjectReference{
+					{Kind: AttachedClusterKind, Name: "a2"},
+					{Kind: ClusterKind, Name: "missing"},
+				},
+				ClusterSelector: &fleetapi.ClusterSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}},
+				},
+			},
+			expected: []*corev1.ObjectReference{
+				{Kind: ClusterKind, Name: "missing"},
+				{Kind: ClusterKind, Name: "c4"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fleet := &fleetapi.Fleet{
+				ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+			got, err := FleetClusterRefs(context.Background(), c, fleet)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestIsClusterSelected(t *testing.T) {
+	selector := &fleetapi.ClusterSelector{
+		Kind:          ClusterKind,
+		LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}
+
+	assert.True(t, isClusterSelected(selector, ClusterKind, map[string]string{"env": "prod"}))
+	assert.False(t, isClusterSelected(selector, AttachedClusterKind, map[string]string{"env": "prod"}))
+	assert.False(t, isClusterSelected(selector, ClusterKind, map[string]string{"env": "dev"}))
+	assert.False(t, isClusterSelected(nil, ClusterKind, map[string]string{"env": "prod"}))
+}
+
+func TestOwnedByOtherFleet(t *testing.T) {
+	fleet := &fleetapi.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"}}
+
+	cases := []struct {
+		name          string
+		labels        map[string]string
+		expectedOwner string
+		expected      bool
+	}{
+		{
+			name:     "no fleet label",
+			labels:   map[string]string{"env": "prod"},
+			expected: false,
+		},
+		{
+			name:          "owned by the fleet",
+			labels:        map[string]string{FleetLabel: "fleet"},
+			expectedOwner: "fleet",
+			expected:      false,
+		},
+		{
+			name:          "owned by another fleet",
+			labels:        map[string]string{FleetLabel: "other"},
+			expectedOwner: "other",
+			expected:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1alpha1.AttachedCluster{ObjectMeta: metav1.ObjectMeta{Name: "a1", Namespace: "default", Labels: tc.labels}}
+			owner, got := ownedByOtherFleet(cluster, fleet)
+			assert.Equal(t, tc.expectedOwner, owner)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -18,18 +18,27 @@ package fleet
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	hrapiv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
 	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
 	"helm.sh/helm/v3/pkg/kube"
 	"istio.io/istio/pkg/util/sets"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	"kurator.dev/kurator/pkg/fleet-manager/plugin"
 )
 
 const (
@@ -39,78 +48,255 @@ const (
 	NoneClusterIP = "None"
 )
 
-func (f *FleetManager) reconcilePlugins(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (ctrl.Result, error) {
+// the plugin names reported in the fleet status, which are the same as the fields of PluginConfig.
+const (
+	metricPlugin             = "metric"
+	grafanaPlugin            = "grafana"
+	kyvernoPlugin            = "kyverno"
+	backupPlugin             = "backup"
+	distributedStoragePlugin = "distributedStorage"
+	flaggerPlugin            = "flagger"
+	submarinerPlugin         = "submariner"
+	providerPlugin           = "provider"
+)
+
+// pluginOfLabel returns the plugin name of the `fleet.kurator.dev/plugin` label value of the plugin resources.
+func pluginOfLabel(label string) string {
+	if isRegisteredPlugin(label) {
+		return label
+	}
+
+	switch label {
+	case plugin.MetricPluginName:
+		return metricPlugin
+	case plugin.GrafanaPluginName:
+		return grafanaPlugin
+	case plugin.KyvernoPluginName:
+		return kyvernoPlugin
+	case plugin.BackupPluginName:
+		return backupPlugin
+	case plugin.StorageOperatorPluginName, plugin.ClusterStoragePluginName:
+		return distributedStoragePlugin
+	case plugin.FlaggerPluginName, plugin.PublicTestloaderName:
+		return flaggerPlugin
+	case plugin.SubMarinerBrokerPluginName, plugin.SubMarinerOperatorPluginName:
+		return submarinerPlugin
+	default:
+		// provider plugins are labeled with the provider name, e.g. istio, kuma, nginx
+		return providerPlugin
+	}
+}
+
+func (f *FleetManager) reconcilePlugins(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 	log = log.WithValues("fleet", types.NamespacedName{Name: fleet.Name, Namespace: fleet.Namespace})
 	var resources kube.ResourceList
 
-	if fleet.Spec.Plugin != nil {
-		type reconcileResult struct {
-			result     kube.ResourceList
-			ctrlResult ctrl.Result
-			err        error
+	// pluginErrs records the reconcile errors of each plugin, which will be reported in the plugin status.
+	pluginErrs := make(map[string]error)
+	defer func() {
+		if err := f.reconcilePluginStatus(ctx, fleet, pluginErrs); err != nil {
+			reterr = utilerrors.NewAggregate([]error{reterr, err})
 		}
+	}()
 
-		type reconcileFunc func(context.Context, *fleetapi.Fleet, map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error)
-
-		funcs := []reconcileFunc{
-			f.reconcileMetricPlugin,
-			f.reconcileGrafanaPlugin,
-			f.reconcileKyvernoPlugin,
-			f.reconcileBackupPlugin,
-			f.reconcileDistributedStoragePlugin,
-			f.reconcileFlaggerPlugin,
-			f.reconcileSubmarinerPlugin,
-			f.reconcileProviderPlugin,
-		}
+	type reconcileResult struct {
+		name       string
+		result     kube.ResourceList
+		ctrlResult ctrl.Result
+		err        error
+	}
+
+	var errs []error
+	funcs := f.pluginReconcileFuncs(fleet)
+	if err := validatePluginResourceNames(fleet, fleetClusters); err != nil {
+		// skip the custom plugins, so that they do not overwrite the HelmReleases of the other components
+		log.Error(err, "invalid plugin components", "plugin", plugin.CustomPluginName)
+		delete(funcs, plugin.CustomPluginName)
+		pluginErrs[plugin.CustomPluginName] = err
+		errs = append(errs, fmt.Errorf("plugin %s: %w", plugin.CustomPluginName, err))
+	}
+
+	resultsChannel := make(chan reconcileResult, len(funcs))
+	var wg sync.WaitGroup
+
+	for name, fn := range funcs {
+		wg.Add(1)
+		go func(name string, fn pluginReconcileFunc) {
+			defer wg.Done()
+			result, ctrlResult, err := fn(ctx, fleet, fleetClusters)
+			resultsChannel <- reconcileResult{name, result, ctrlResult, err}
+		}(name, fn)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsChannel)
+	}()
 
-		resultsChannel := make(chan reconcileResult, len(funcs))
-		var wg sync.WaitGroup
+	var ctrlResults []ctrl.Result
 
-		for _, fn := range funcs {
-			wg.Add(1)
-			go func(fn reconcileFunc) {
-				defer wg.Done()
-				result, ctrlResult, err := fn(ctx, fleet, fleetClusters)
-				resultsChannel <- reconcileResult{result, ctrlResult, err}
-			}(fn)
+	for res := range resultsChannel {
+		if res.err != nil {
+			log.Error(res.err, "failed to reconcile plugin", "plugin", res.name)
+			pluginErrs[res.name] = res.err
+			errs = append(errs, fmt.Errorf("plugin %s: %w", res.name, res.err))
 		}
+		if res.ctrlResult.Requeue || res.ctrlResult.RequeueAfter > 0 {
+			ctrlResults = append(ctrlResults, res.ctrlResult)
+		}
+		resources = append(resources, res.result...)
+	}
+
+	if len(errs) > 0 {
+		// Combine all errors into one error message
+		return ctrl.Result{}, fmt.Errorf("encountered multiple errors: %v", errs)
+	}
 
-		go func() {
-			wg.Wait()
-			close(resultsChannel)
-		}()
+	if len(ctrlResults) > 0 {
+		// Handle multiple ctrlResults
+		return ctrlResults[0], nil // TODO: if we need strategy about RequeueAfter
+	}
 
-		var errs []error
-		var ctrlResults []ctrl.Result
+	log.Info("All plugin Resources succeed")
+	if res, err := f.reconcilePluginResources(ctx, fleet, resources); err != nil || !res.IsZero() {
+		return res, err
+	}
 
-		for res := range resultsChannel {
-			if res.err != nil {
-				errs = append(errs, res.err)
-			}
-			if res.ctrlResult.Requeue || res.ctrlResult.RequeueAfter > 0 {
-				ctrlResults = append(ctrlResults, res.ctrlResult)
+	// continue the staged plugin upgrades in progress
+	return pluginUpgradeResult(fleet), nil
+}
+
+// reconcilePluginStatus reports the status of each plugin component in the fleet status,
+// based on the HelmReleases of the fleet and the reconcile errors of the plugins.
+func (f *FleetManager) reconcilePluginStatus(ctx context.Context, fleet *fleetapi.Fleet, pluginErrs map[string]error) error {
+	helmReleases := &hrapiv2b1.HelmReleaseList{}
+	if err := f.Client.List(ctx, helmReleases, client.InNamespace(fleet.Namespace), fleetResourceLabels(fleet.Name)); err != nil {
+		return fmt.Errorf("failed to list helm release: %w", err)
+	}
+
+	statuses := make([]*fleetapi.FleetPluginStatus, 0, len(helmReleases.Items))
+	for i := range helmReleases.Items {
+		hr := &helmReleases.Items[i]
+		if hr.DeletionTimestamp != nil {
+			continue
+		}
+		status := helmReleaseStatus(hr)
+		// the error is only reported in the status of the cluster where the plugin failed
+		if err, ok := pluginErrs[status.Name]; ok && clusterOfPluginError(err) == status.Cluster {
+			status.LastError = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+
+	// report the plugin errors of the clusters where no HelmRelease is created, e.g. the plugin failed to render
+	for name, err := range pluginErrs {
+		cluster := clusterOfPluginError(err)
+		found := false
+		for _, s := range statuses {
+			if s.Name == name && s.Cluster == cluster {
+				found = true
+				break
 			}
-			resources = append(resources, res.result...)
 		}
+		if !found {
+			statuses = append(statuses, &fleetapi.FleetPluginStatus{
+				Name:      name,
+				Cluster:   cluster,
+				LastError: err.Error(),
+			})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return pluginStatusKey(statuses[i]) < pluginStatusKey(statuses[j])
+	})
+	fleet.Status.Plugins = statuses
 
-		if len(errs) > 0 {
-			// Combine all errors into one error message
-			return ctrl.Result{}, fmt.Errorf("encountered multiple errors: %v", errs)
+	var failed, notReady []string
+	for _, s := range statuses {
+		switch {
+		case s.LastError != "":
+			failed = append(failed, pluginStatusKey(s))
+		case !s.Ready:
+			notReady = append(notReady, pluginStatusKey(s))
 		}
+	}
+	switch {
+	case len(failed) > 0:
+		conditions.MarkFalse(fleet, fleetapi.PluginsReadyCondition, fleetapi.PluginsReconcileFailedReason,
+			capiv1beta1.ConditionSeverityError, "plugins %s failed", strings.Join(failed, ","))
+	case len(notReady) > 0:
+		conditions.MarkFalse(fleet, fleetapi.PluginsReadyCondition, fleetapi.PluginsNotReadyReason,
+			capiv1beta1.ConditionSeverityWarning, "plugins %s are not ready", strings.Join(notReady, ","))
+	default:
+		conditions.MarkTrue(fleet, fleetapi.PluginsReadyCondition)
+	}
+
+	return nil
+}
+
+// pluginClusterError is the error of a plugin in one of the member clusters,
+// which is only reported in the plugin status of the cluster.
+type pluginClusterError struct {
+	cluster string
+	err     error
+}
+
+func newPluginClusterError(cluster string, err error) error {
+	return &pluginClusterError{cluster: cluster, err: err}
+}
+
+func (e *pluginClusterError) Error() string {
+	return fmt.Sprintf("cluster %s: %v", e.cluster, e.err)
+}
+
+func (e *pluginClusterError) Unwrap() error {
+	return e.err
+}
+
+// clusterOfPluginError returns the cluster where the plugin failed,
+// empty if the error is not specific to a member cluster.
+func clusterOfPluginError(err error) string {
+	var clusterErr *pluginClusterError
+	if errors.As(err, &clusterErr) {
+		return clusterErr.cluster
+	}
+	return ""
+}
 
-		if len(ctrlResults) > 0 {
-			// Handle multiple ctrlResults
-			return ctrlResults[0], nil // TODO: if we need strategy about RequeueAfter
+// helmReleaseStatus builds the plugin status from the labels and status of the HelmRelease.
+func helmReleaseStatus(hr *hrapiv2b1.HelmRelease) *fleetapi.FleetPluginStatus {
+	labels := hr.GetLabels()
+	status := &fleetapi.FleetPluginStatus{
+		Name:         pluginOfLabel(labels[FleetPluginName]),
+		Component:    labels[FleetComponentName],
+		Cluster:      labels[FleetClusterName],
+		ChartVersion: hr.Status.LastAppliedRevision,
+	}
+	if status.ChartVersion == "" && hr.Spec.Chart.Spec.Version != "" {
+		status.ChartVersion = hr.Spec.Chart.Spec.Version
+	}
+
+	if ready := apimeta.FindStatusCondition(hr.Status.Conditions, "Ready"); ready != nil {
+		status.Ready = ready.Status == metav1.ConditionTrue
+		if ready.Status == metav1.ConditionFalse {
+			status.LastError = ready.Message
 		}
+		lastTransitionTime := ready.LastTransitionTime
+		status.LastTransitionTime = &lastTransitionTime
 	}
 
-	log.Info("All plugin Resources succeed")
-	return f.reconcilePluginResources(ctx, fleet, resources)
+	return status
+}
+
+func pluginStatusKey(s *fleetapi.FleetPluginStatus) string {
+	return strings.Join([]string{s.Name, s.Component, s.Cluster}, "/")
 }
 
 // reconcilePluginResources delete redundant HelmRelease and HelmRepository resources,
 // for example, disable metric plugin will try to delete metric plugin resources.
+// The secrets generated for the disabled plugins are deleted as well.
 func (f *FleetManager) reconcilePluginResources(ctx context.Context, fleet *fleetapi.Fleet, resources kube.ResourceList) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log = log.WithValues("fleet", types.NamespacedName{Name: fleet.Name, Namespace: fleet.Namespace})
@@ -159,6 +345,10 @@ func (f *FleetManager) reconcilePluginResources(ctx context.Context, fleet *flee
 			}
 		}
 	}
+
+	if err := f.deleteThanosObjStoreSecret(ctx, fleet); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -175,11 +365,16 @@ func (f *FleetManager) helmReleaseReady(ctx context.Context, fleet *fleetapi.Fle
 				Namespace: fleet.Namespace,
 				Name:      res.Name,
 			}, hr); err != nil {
+				log.Info("failed to get helm release", "helm release", res.Name, "error", err.Error())
 				return false
 			}
 
 			if !isReady(hr.Status.Conditions) {
-				log.Info("helm release is not ready", "helm release", hr.Name)
+				reason := "no ready condition"
+				if ready := apimeta.FindStatusCondition(hr.Status.Conditions, "Ready"); ready != nil {
+					reason = fmt.Sprintf("%s: %s", ready.Reason, ready.Message)
+				}
+				log.Info("helm release is not ready", "helm release", hr.Name, "reason", reason)
 				return false
 			}
 		default:
@@ -16,6 +16,7 @@ package fleet
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,17 +30,34 @@ import (
 
 	"kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 	"kurator.dev/kurator/pkg/fleet-manager/plugin"
-	"kurator.dev/kurator/pkg/infra/util"
 )
 
 const (
 	AccessKey = "access-key"
 	SecretKey = "secret-key"
 
-	AWS         = "aws"
-	HuaWeiCloud = "huaweicloud"
-	GCP         = "gcp"
-	Azure       = "azure"
+	// GCPServiceAccountKey is the key of the service account key file in the GCP credential secret.
+	GCPServiceAccountKey = "service-account-key"
+
+	// keys of the Azure credential secret, either the service principal or the storage account access key is required.
+	AzureSubscriptionID    = "subscription-id"
+	AzureTenantID          = "tenant-id"
+	AzureClientID          = "client-id"
+	AzureClientSecret      = "client-secret"
+	AzureResourceGroup     = "resource-group"
+	AzureCloudName         = "cloud-name"
+	AzureStorageAccountKey = "storage-account-key"
+
+	// AzureStorageAccountKeyEnvVar is the variable of the storage account access key in the Azure credential file,
+	// which should be set as the `storageAccountKeyEnvVar` in the location config.
+	AzureStorageAccountKeyEnvVar = "AZURE_STORAGE_ACCOUNT_ACCESS_KEY"
+	defaultAzureCloudName        = "AzurePublicCloud"
+
+	AWS          = "aws"
+	HuaWeiCloud  = "huaweicloud"
+	GCP          = "gcp"
+	Azure        = "azure"
+	S3Compatible = "s3compatible"
 
 	AWSObjStoreSecretNameSuffix         = "-velero-s3"
 	HuaWeiCloudObjStoreSecretNameSuffix = "-velero-obs"
@@ -49,6 +67,12 @@ const (
 	ObjStoreSecretNamespace = "velero"
 )
 
+func init() {
+	registerBuiltinPlugin(backupPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileBackupPlugin
+	})
+}
+
 // reconcileBackupPlugin reconciles the backup plugin configuration and installation across multiple clusters.
 // It generates and applies Velero Helm configurations based on the specified backup plugin settings in the fleet specification.
 func (f *FleetManager) reconcileBackupPlugin(ctx context.Context, fleet *v1alpha1.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
@@ -66,6 +90,13 @@ func (f *FleetManager) reconcileBackupPlugin(ctx context.Context, fleet *v1alpha
 		Name:      fleet.Name,
 	}
 
+	if err := validateBackupStorage(veleroCfg.Storage); err != nil {
+		return nil, ctrl.Result{}, fmt.Errorf("invalid backup storage: %w", err)
+	}
+	if err := validateVolumeSnapshotLocations(veleroCfg.Storage, veleroCfg.VolumeSnapshotLocations); err != nil {
+		return nil, ctrl.Result{}, fmt.Errorf("invalid volume snapshot locations: %w", err)
+	}
+
 	// handle provider-specific details
 	objStoreProvider := veleroCfg.Storage.Location.Provider
 	// newSecret is a variable used to store the newly created secret object which contains the necessary credentials for the object storage provider. The specific structure and content of the secret vary depending on the provider.
@@ -79,32 +110,34 @@ func (f *FleetManager) reconcileBackupPlugin(ctx context.Context, fleet *v1alpha
 	var resources kube.ResourceList
 
 	// Iterating through each fleet cluster to generate and apply Velero helm configurations.
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 	for key, cluster := range fleetClusters {
 		// generate Velero helm config for each fleet cluster
 		b, err := plugin.RenderVelero(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, veleroCfg, newSecret.Name)
 		if err != nil {
-			err = fmt.Errorf("error rendering Velero for fleet cluster %s: %w", key.Name, err)
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, fmt.Errorf("error rendering Velero: %w", err))
 		}
 
 		// create a new secret in the current fleet cluster before initializing the backup plugin.
 		if err := createNewSecretInFleetCluster(ctx, cluster, newSecret); err != nil {
-			err = fmt.Errorf("error creating new secret in fleet cluster %s: %w", key.Name, err)
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, fmt.Errorf("error creating new secret: %w", err))
 		}
 
-		// apply Velero helm resources
-		veleroResources, err := util.PatchResources(b)
-		if err != nil {
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, veleroResources...)
+		rendered[key] = b
 	}
 
+	// apply Velero helm resources
+	veleroResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	resources = append(resources, veleroResources...)
+
 	log.V(4).Info("wait for velero helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
 		// wait for HelmRelease to be ready
@@ -119,8 +152,7 @@ func (f *FleetManager) reconcileBackupPlugin(ctx context.Context, fleet *v1alpha
 	// preventing orphaned resources and maintaining the cleanliness of the cluster.
 	for key, cluster := range fleetClusters {
 		if err := f.updateNewSecretOwnerReference(ctx, key.Name, cluster, newSecret); err != nil {
-			err = fmt.Errorf("error updating owner reference for secret in cluster %s: %w", key.Name, err)
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, fmt.Errorf("error updating owner reference for secret: %w", err))
 		}
 	}
 
@@ -135,12 +167,14 @@ func (f *FleetManager) buildNewSecret(ctx context.Context, secretName, objStoreP
 	switch objStoreProvider {
 	case AWS:
 		newSecret, err = f.buildAWSSecret(ctx, secretName, fleetNN, fleetNN.Name+AWSObjStoreSecretNameSuffix)
+	case S3Compatible:
+		newSecret, err = f.buildAWSSecret(ctx, secretName, fleetNN, fleetNN.Name+AWSObjStoreSecretNameSuffix)
 	case HuaWeiCloud:
 		newSecret, err = f.buildHuaWeiCloudSecret(ctx, secretName, fleetNN, fleetNN.Name+HuaWeiCloudObjStoreSecretNameSuffix)
 	case GCP:
-		newSecret, err = f.buildGCPSecret(ctx, secretName, fleetNN)
+		newSecret, err = f.buildGCPSecret(ctx, secretName, fleetNN, fleetNN.Name+GCPObjStoreSecretNameSuffix)
 	case Azure:
-		newSecret, err = f.buildAzureSecret(ctx, secretName, fleetNN)
+		newSecret, err = f.buildAzureSecret(ctx, secretName, fleetNN, fleetNN.Name+AzureObjStoreSecretNameSuffix)
 	default:
 		return nil, fmt.Errorf("unknown objStoreProvider: %v", objStoreProvider)
 	}
@@ -157,9 +191,134 @@ func (f *FleetManager) buildAWSSecret(ctx context.Context, secretName string, fl
 	}
 
 	// build an S3 secret for Velero using the accessKey and secretKey
-	newSecret := &corev1.Secret{
+	cloud := fmt.Sprintf("[default]\naws_access_key_id=%s\naws_secret_access_key=%s", accessKey, secretKey)
+	return newObjStoreSecret(s3SecretName, []byte(cloud)), nil
+}
+
+func (f *FleetManager) buildHuaWeiCloudSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName, huaweiyunSecretName string) (*corev1.Secret, error) {
+	return f.buildAWSSecret(ctx, secretName, fleetNN, huaweiyunSecretName)
+}
+
+// buildGCPSecret constructs a secret for GCP with the service account key file.
+func (f *FleetManager) buildGCPSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName, gcsSecretName string) (*corev1.Secret, error) {
+	secret, err := getObjStoreSecret(ctx, f.Client, fleetNN.Namespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccountKey := secret.Data[GCPServiceAccountKey]
+	if len(serviceAccountKey) == 0 {
+		return nil, fmt.Errorf("key %s is required in secret %s for gcp", GCPServiceAccountKey, secretName)
+	}
+
+	return newObjStoreSecret(gcsSecretName, serviceAccountKey), nil
+}
+
+// buildAzureSecret constructs a secret for Azure in the format of the credential file of the velero azure plugin,
+// see https://github.com/vmware-tanzu/velero-plugin-for-microsoft-azure#set-permissions-for-velero
+func (f *FleetManager) buildAzureSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName, absSecretName string) (*corev1.Secret, error) {
+	secret, err := getObjStoreSecret(ctx, f.Client, fleetNN.Namespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudName := string(secret.Data[AzureCloudName])
+	if cloudName == "" {
+		cloudName = defaultAzureCloudName
+	}
+
+	var cloud strings.Builder
+	if storageAccountKey := secret.Data[AzureStorageAccountKey]; len(storageAccountKey) != 0 {
+		fmt.Fprintf(&cloud, "%s=%s\n", AzureStorageAccountKeyEnvVar, storageAccountKey)
+	} else {
+		for _, key := range []string{AzureSubscriptionID, AzureTenantID, AzureClientID, AzureClientSecret} {
+			if len(secret.Data[key]) == 0 {
+				return nil, fmt.Errorf("key %s or %s is required in secret %s for azure", key, AzureStorageAccountKey, secretName)
+			}
+		}
+		fmt.Fprintf(&cloud, "AZURE_SUBSCRIPTION_ID=%s\n", secret.Data[AzureSubscriptionID])
+		fmt.Fprintf(&cloud, "AZURE_TENANT_ID=%s\n", secret.Data[AzureTenantID])
+		fmt.Fprintf(&cloud, "AZURE_CLIENT_ID=%s\n", secret.Data[AzureClientID])
+		fmt.Fprintf(&cloud, "AZURE_CLIENT_SECRET=%s\n", secret.Data[AzureClientSecret])
+		if resourceGroup := secret.Data[AzureResourceGroup]; len(resourceGroup) != 0 {
+			// the resource group of the cluster, which is only required by the volume snapshots
+			fmt.Fprintf(&cloud, "AZURE_RESOURCE_GROUP=%s\n", resourceGroup)
+		}
+	}
+	fmt.Fprintf(&cloud, "AZURE_CLOUD_NAME=%s\n", cloudName)
+
+	return newObjStoreSecret(absSecretName, []byte(cloud.String())), nil
+}
+
+// validateBackupStorage rejects the storage that velero is not able to use.
+func validateBackupStorage(storage v1alpha1.BackupStorage) error {
+	location := storage.Location
+	if location.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+	if storage.SecretName == "" {
+		return fmt.Errorf("secretName is required")
+	}
+
+	switch location.Provider {
+	case AWS, HuaWeiCloud, S3Compatible:
+		if location.Endpoint == "" {
+			return fmt.Errorf("endpoint is required for %s", location.Provider)
+		}
+	case GCP:
+		if location.Endpoint != "" || location.Region != "" || location.S3Compatible != nil {
+			return fmt.Errorf("endpoint, region and s3Compatible are not supported for gcp")
+		}
+	case Azure:
+		if location.Endpoint != "" || location.Region != "" || location.S3Compatible != nil {
+			return fmt.Errorf("endpoint, region and s3Compatible are not supported for azure")
+		}
+		if location.Config["storageAccount"] == "" {
+			return fmt.Errorf("config storageAccount is required for azure")
+		}
+		// the resource group of the storage account is required unless the storage account access key is used
+		if location.Config["resourceGroup"] == "" && location.Config["storageAccountKeyEnvVar"] == "" {
+			return fmt.Errorf("config resourceGroup or storageAccountKeyEnvVar is required for azure")
+		}
+	default:
+		return fmt.Errorf("unknown objStoreProvider: %v", location.Provider)
+	}
+
+	return nil
+}
+
+// validateVolumeSnapshotLocations rejects the volume snapshot locations that can not be accessed with the credentials of the backup storage.
+func validateVolumeSnapshotLocations(storage v1alpha1.BackupStorage, locations []*v1alpha1.VolumeSnapshotLocation) error {
+	// the credentials of the backup storage are shared by the volume snapshot locations,
+	// so the snapshots must be taken in the same cloud as the backup storage.
+	expected := storage.Location.Provider
+	if expected == HuaWeiCloud || expected == S3Compatible {
+		expected = AWS
+	}
+
+	names := make(map[string]bool, len(locations))
+	for _, location := range locations {
+		if location.Name == "" {
+			return fmt.Errorf("name is required")
+		}
+		if names[location.Name] {
+			return fmt.Errorf("duplicate name %s", location.Name)
+		}
+		names[location.Name] = true
+
+		if location.Provider != expected {
+			return fmt.Errorf("provider %s of %s does not match the backup storage, which requires %s", location.Provider, location.Name, expected)
+		}
+	}
+
+	return nil
+}
+
+// newObjStoreSecret builds the secret used as the credential file of velero.
+func newObjStoreSecret(name string, cloud []byte) *corev1.Secret {
+	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      s3SecretName,
+			Name:      name,
 			Namespace: ObjStoreSecretNamespace,
 			Labels: map[string]string{
 				FleetPluginName: plugin.BackupPluginName,
@@ -167,33 +326,23 @@ func (f *FleetManager) buildAWSSecret(ctx context.Context, secretName string, fl
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: map[string][]byte{
-			"cloud": []byte(fmt.Sprintf("[default]\naws_access_key_id=%s\naws_secret_access_key=%s", accessKey, secretKey)),
+			"cloud": cloud,
 		},
 	}
-	return newSecret, nil
 }
 
-func (f *FleetManager) buildHuaWeiCloudSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName, huaweiyunSecretName string) (*corev1.Secret, error) {
-	return f.buildAWSSecret(ctx, secretName, fleetNN, huaweiyunSecretName)
-}
-
-// TODO： accomplish those function after investigation
-func (f *FleetManager) buildGCPSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName) (*corev1.Secret, error) {
-	return nil, nil
-}
-func (f *FleetManager) buildAzureSecret(ctx context.Context, secretName string, fleetNN types.NamespacedName) (*corev1.Secret, error) {
-	return nil, nil
-}
-
-func getObjStoreCredentials(ctx context.Context, client client.Client, namespace, secretName string) (accessKey, secretKey string, err error) {
+func getObjStoreSecret(ctx context.Context, client client.Client, namespace, secretName string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
-	SecretNN := types.NamespacedName{
-		Namespace: namespace,
-		Name:      secretName,
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster secret %s in namespace %s", secretName, namespace)
 	}
+	return secret, nil
+}
 
-	if err := client.Get(ctx, SecretNN, secret); err != nil {
-		return "", "", errors.Wrapf(err, "failed to get cluster secret %s in namespace %s", secretName, namespace)
+func getObjStoreCredentials(ctx context.Context, client client.Client, namespace, secretName string) (accessKey, secretKey string, err error) {
+	secret, err := getObjStoreSecret(ctx, client, namespace, secretName)
+	if err != nil {
+		return "", "", err
 	}
 
 	accessKey = string(secret.Data[AccessKey])
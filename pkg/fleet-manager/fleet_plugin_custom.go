@@ -0,0 +1,202 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	"kurator.dev/kurator/pkg/fleet-manager/plugin"
+)
+
+func init() {
+	RegisterPlugin(plugin.CustomPluginName, func(f *FleetManager) Plugin {
+		return &customPlugin{HelmPlugin: HelmPlugin{Manager: f}}
+	})
+}
+
+// customPlugin installs the arbitrary helm charts configured in `PluginConfig.Custom`.
+type customPlugin struct {
+	HelmPlugin
+}
+
+// customPluginConfig is a custom plugin with its parsed cluster selector.
+type customPluginConfig struct {
+	config   *fleetapi.CustomPluginConfig
+	selector labels.Selector
+}
+
+func (p *customPlugin) Name() string {
+	return plugin.CustomPluginName
+}
+
+func (p *customPlugin) Decode(fleet *fleetapi.Fleet) (interface{}, error) {
+	if fleet.Spec.Plugin == nil || len(fleet.Spec.Plugin.Custom) == 0 {
+		return nil, nil
+	}
+
+	customs := make([]*customPluginConfig, 0, len(fleet.Spec.Plugin.Custom))
+	names := make(map[string]struct{}, len(fleet.Spec.Plugin.Custom))
+	for _, c := range fleet.Spec.Plugin.Custom {
+		if _, ok := names[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate custom plugin %s", c.Name)
+		}
+		names[c.Name] = struct{}{}
+
+		if c.Chart.Repository == "" || c.Chart.Name == "" || c.Chart.Version == "" {
+			return nil, fmt.Errorf("chart repository, name and version of custom plugin %s are required", c.Name)
+		}
+
+		selector := labels.Everything()
+		if c.ClusterSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(c.ClusterSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cluster selector of custom plugin %s: %w", c.Name, err)
+			}
+		}
+		customs = append(customs, &customPluginConfig{config: c, selector: selector})
+	}
+
+	return customs, nil
+}
+
+func (p *customPlugin) Render(ctx context.Context, fleet *fleetapi.Fleet, cfg interface{}, cluster *PluginCluster) ([]byte, error) {
+	fleetNN := types.NamespacedName{
+		Namespace: fleet.Namespace,
+		Name:      fleet.Name,
+	}
+
+	var kubeConfig *plugin.KubeConfigSecretRef
+	if cluster != nil {
+		kubeConfig = &cluster.KubeConfig
+	}
+
+	var out bytes.Buffer
+	for _, c := range cfg.([]*customPluginConfig) {
+		if c.config.FleetScoped != (cluster == nil) {
+			continue
+		}
+		if cluster != nil && !c.selector.Matches(labels.Set(cluster.Labels)) {
+			continue
+		}
+
+		b, err := plugin.RenderCustom(p.Manager.Manifests, fleetNN, ownerReference(fleet), kubeConfig, c.config)
+		if err != nil {
+			return nil, err
+		}
+		appendManifest(&out, b)
+	}
+
+	return out.Bytes(), nil
+}
+
+// builtinPluginComponents returns the components of the built-in plugins.
+func builtinPluginComponents() []string {
+	components := []string{
+		plugin.ThanosComponentName,
+		plugin.PrometheusComponentName,
+		plugin.GrafanaComponentName,
+		plugin.KyvernoComponentName,
+		plugin.KyvernoPolicyComponentName,
+		plugin.VeleroComponentName,
+		plugin.RookOperatorComponentName,
+		plugin.RookClusterComponentName,
+		plugin.FlaggerComponentName,
+		plugin.TestloaderComponentName,
+		plugin.SubMarinerBrokerComponentName,
+		plugin.SubMarinerOperatorComponentName,
+	}
+	// the provider plugins are named after the traffic routing providers
+	for provider := range plugin.ProviderNamespace {
+		components = append(components, string(provider))
+	}
+	return components
+}
+
+// validatePluginResourceNames checks that the resources of the custom plugin components do not conflict
+// with each other or with the built-in plugin components, as they are named `<component>` in the fleet scope
+// and `<component>-<cluster>` in the clusters.
+// The custom plugins are checked against all the clusters of the fleet regardless of their cluster selectors,
+// so that relabeling a cluster never makes a valid fleet invalid.
+// It returns all the conflicts found.
+func validatePluginResourceNames(fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) error {
+	if fleet.Spec.Plugin == nil {
+		return nil
+	}
+
+	clusterNames := make([]string, 0, len(fleetClusters))
+	seen := make(map[string]struct{}, len(fleetClusters))
+	for key := range fleetClusters {
+		if _, ok := seen[key.Name]; ok {
+			continue
+		}
+		seen[key.Name] = struct{}{}
+		clusterNames = append(clusterNames, key.Name)
+	}
+	sort.Strings(clusterNames)
+
+	resourceNames := func(component string, fleetScoped bool) []string {
+		if fleetScoped {
+			return []string{component}
+		}
+		names := make([]string, 0, len(clusterNames))
+		for _, cluster := range clusterNames {
+			names = append(names, component+"-"+cluster)
+		}
+		return names
+	}
+
+	owners := make(map[string]string)
+	for _, component := range builtinPluginComponents() {
+		owner := "built-in component " + component
+		owners[component] = owner
+		for _, name := range resourceNames(component, false) {
+			owners[name] = owner
+		}
+	}
+
+	var errs []error
+	for _, c := range fleet.Spec.Plugin.Custom {
+		owner := fmt.Sprintf("%s plugin %s", plugin.CustomPluginName, c.Name)
+		for _, name := range resourceNames(c.Name, c.FleetScoped) {
+			if o, ok := owners[name]; ok {
+				errs = append(errs, fmt.Errorf("resource name %s of %s conflicts with %s", name, owner, o))
+				continue
+			}
+			owners[name] = owner
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// appendManifest appends the rendered manifest to the multi-document YAML output.
+func appendManifest(out *bytes.Buffer, b []byte) {
+	if out.Len() > 0 {
+		out.WriteString("\n---\n")
+	}
+	out.Write(b)
+}
@@ -23,9 +23,14 @@ import (
 
 	"kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 	"kurator.dev/kurator/pkg/fleet-manager/plugin"
-	"kurator.dev/kurator/pkg/infra/util"
 )
 
+func init() {
+	registerBuiltinPlugin(distributedStoragePlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileDistributedStoragePlugin
+	})
+}
+
 func (f *FleetManager) reconcileDistributedStoragePlugin(ctx context.Context, fleet *v1alpha1.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -45,23 +50,27 @@ func (f *FleetManager) reconcileDistributedStoragePlugin(ctx context.Context, fl
 	var resources kube.ResourceList
 
 	// First install rook-operator for the specified multicluster.
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 	for key, cluster := range fleetClusters {
 		b, err := plugin.RenderStorageOperator(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, distributedStorageCfg)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 
-		// apply Rook helm resources
-		rookResources, err := util.PatchResources(b)
-		if err != nil {
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, rookResources...)
+		rendered[key] = b
+	}
+
+	// apply Rook helm resources
+	rookResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
 	}
+	resources = append(resources, rookResources...)
 
 	log.V(4).Info("wait for rook helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
@@ -74,22 +83,26 @@ func (f *FleetManager) reconcileDistributedStoragePlugin(ctx context.Context, fl
 
 	// After Rook operator are created, starts to install rook-ceph
 	if distributedStorageCfg.Storage != nil {
+		rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 		for key, cluster := range fleetClusters {
 			b, err := plugin.RenderClusterStorage(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 				Name:       key.Name,
 				SecretName: cluster.Secret,
 				SecretKey:  cluster.SecretKey,
+				Labels:     cluster.Labels,
 			}, distributedStorageCfg)
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 			}
 
-			rookCephResources, err := util.PatchResources(b)
-			if err != nil {
-				return nil, ctrl.Result{}, err
-			}
-			resources = append(resources, rookCephResources...)
+			rendered[key] = b
+		}
+
+		rookCephResources, err := f.applyClusterResources(ctx, fleet, rendered)
+		if err != nil {
+			return nil, ctrl.Result{}, err
 		}
+		resources = append(resources, rookCephResources...)
 	}
 
 	if !f.helmReleaseReady(ctx, fleet, resources) {
@@ -26,6 +26,12 @@ import (
 	"kurator.dev/kurator/pkg/infra/util"
 )
 
+func init() {
+	registerBuiltinPlugin(flaggerPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileFlaggerPlugin
+	})
+}
+
 // reconcileFlaggerPlugin reconciles the Flagger plugin.
 // The fleetClusters parameter is currently unused, but is included to match the function signature of other functions in reconcilePlugins.
 func (f *FleetManager) reconcileFlaggerPlugin(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
@@ -46,22 +52,19 @@ func (f *FleetManager) reconcileFlaggerPlugin(ctx context.Context, fleet *fleeta
 	fleetOwnerRef := ownerReference(fleet)
 	var resources kube.ResourceList
 
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 	for key, cluster := range fleetClusters {
 		b, err := plugin.RenderFlagger(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, flaggerCfg)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 
-		// apply flagger helm resources
-		flaggerResources, err := util.PatchResources(b)
-		if err != nil {
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, flaggerResources...)
+		rendered[key] = b
 
 		// install public testloader if needed
 		if flaggerCfg.PublicTestloader {
@@ -69,20 +72,28 @@ func (f *FleetManager) reconcileFlaggerPlugin(ctx context.Context, fleet *fleeta
 				Name:       key.Name,
 				SecretName: cluster.Secret,
 				SecretKey:  cluster.SecretKey,
+				Labels:     cluster.Labels,
 			}, flaggerCfg)
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 			}
 
 			// apply flagger helm resources
 			testloaderResources, err := util.PatchResources(b)
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 			}
 			resources = append(resources, testloaderResources...)
 		}
 	}
 
+	// apply flagger helm resources
+	flaggerResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	resources = append(resources, flaggerResources...)
+
 	log.V(4).Info("wait for flagger helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
 		// wait for HelmRelease to be ready
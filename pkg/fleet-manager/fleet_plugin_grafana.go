@@ -30,6 +30,12 @@ import (
 	"kurator.dev/kurator/pkg/infra/util"
 )
 
+func init() {
+	registerBuiltinPlugin(grafanaPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileGrafanaPlugin
+	})
+}
+
 // reconcileGrafanaPlugin reconciles the Grafana plugin.
 // The fleetClusters parameter is currently unused, but is included to match the function signature of other functions in reconcilePlugins.
 func (f *FleetManager) reconcileGrafanaPlugin(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
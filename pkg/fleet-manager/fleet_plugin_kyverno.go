@@ -26,9 +26,14 @@ import (
 
 	fleetv1a1 "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 	"kurator.dev/kurator/pkg/fleet-manager/plugin"
-	"kurator.dev/kurator/pkg/infra/util"
 )
 
+func init() {
+	registerBuiltinPlugin(kyvernoPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileKyvernoPlugin
+	})
+}
+
 func (f *FleetManager) reconcileKyvernoPlugin(ctx context.Context, fleet *fleetv1a1.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -47,23 +52,27 @@ func (f *FleetManager) reconcileKyvernoPlugin(ctx context.Context, fleet *fleetv
 
 	fleetOwnerRef := ownerReference(fleet)
 	var resources kube.ResourceList
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 	for key, cluster := range fleetClusters {
 		b, err := plugin.RenderKyverno(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, fleet.Spec.Plugin.Policy.Kyverno)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 
-		// apply kyverno resources
-		kyvernoResources, err := util.PatchResources(b)
-		if err != nil {
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, kyvernoResources...)
+		rendered[key] = b
+	}
+
+	// apply kyverno resources
+	kyvernoResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
 	}
+	resources = append(resources, kyvernoResources...)
 
 	log.V(4).Info("wait for kyverno helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
@@ -76,23 +85,28 @@ func (f *FleetManager) reconcileKyvernoPlugin(ctx context.Context, fleet *fleetv
 
 	// After CRDs are created, start to install pod security policy
 	if kyvernoCfg.PodSecurity != nil {
+		rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 		for key, cluster := range fleetClusters {
 			// generate policies for pod security admission
 			b, err := plugin.RenderKyvernoPolicy(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 				Name:       key.Name,
 				SecretName: cluster.Secret,
 				SecretKey:  cluster.SecretKey,
+				Labels:     cluster.Labels,
 			}, kyvernoCfg)
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 			}
 
-			kyvernoPolicyResources, err := util.PatchResources(b)
-			if err != nil {
-				return nil, ctrl.Result{}, err
-			}
-			resources = append(resources, kyvernoPolicyResources...)
+			rendered[key] = b
+		}
+
+		// policies are rolled out in the same batches as kyverno
+		kyvernoPolicyResources, err := f.applyClusterResources(ctx, fleet, rendered)
+		if err != nil {
+			return nil, ctrl.Result{}, err
 		}
+		resources = append(resources, kyvernoPolicyResources...)
 	}
 
 	if !f.helmReleaseReady(ctx, fleet, resources) {
@@ -33,6 +33,7 @@ import (
 	"k8s.io/utils/pointer"
 	capiutil "sigs.k8s.io/cluster-api/util"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 	"kurator.dev/kurator/pkg/fleet-manager/plugin"
@@ -40,23 +41,18 @@ import (
 )
 
 func (f *FleetManager) reconcileObjStoreSecretOwnerReference(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) error {
-	for _, cluster := range fleet.Spec.Clusters {
-		fleetCluster, ok := fleetClusters[ClusterKey{cluster.Kind, cluster.Name}]
-		if !ok {
-			// can happen when the cluster is not present
-			continue
-		}
-
+	for key, fleetCluster := range fleetClusters {
 		// reconcile objstore secret's owner reference
 		// a statefulset named prometheus-prometheus-prometheus is created by HelmRelease in each cluster
 		sts, err := fleetCluster.Client.KubeClient().AppsV1().StatefulSets(MonitoringNamespace).Get(ctx, "prometheus-prometheus-prometheus", metav1.GetOptions{})
 		if err != nil {
-			return err
+			return newPluginClusterError(key.Name, err)
 		}
 
-		secret, err := fleetCluster.Client.KubeClient().CoreV1().Secrets(MonitoringNamespace).Get(ctx, fleet.Spec.Plugin.Metric.Thanos.ObjectStoreConfig.SecretName, metav1.GetOptions{})
+		secretName := plugin.ThanosObjStoreSecretName(fleet.Name, fleet.Spec.Plugin.Metric.Thanos.ObjectStoreConfig)
+		secret, err := fleetCluster.Client.KubeClient().CoreV1().Secrets(MonitoringNamespace).Get(ctx, secretName, metav1.GetOptions{})
 		if err != nil {
-			return err
+			return newPluginClusterError(key.Name, err)
 		}
 
 		stsOwnerReference := metav1.OwnerReference{
@@ -68,7 +64,7 @@ func (f *FleetManager) reconcileObjStoreSecretOwnerReference(ctx context.Context
 		if !capiutil.HasOwnerRef(secret.OwnerReferences, stsOwnerReference) {
 			secret.OwnerReferences = append(secret.OwnerReferences, stsOwnerReference)
 			if _, err := fleetCluster.Client.KubeClient().CoreV1().Secrets(MonitoringNamespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
-				return err
+				return newPluginClusterError(key.Name, err)
 			}
 		}
 	}
@@ -82,16 +78,10 @@ func (f *FleetManager) reconcileSidecarRemoteService(ctx context.Context, fleet
 	log = log.WithValues("fleet", types.NamespacedName{Name: fleet.Name, Namespace: fleet.Namespace})
 
 	endpoints := sets.New[string]()
-	for _, cluster := range fleet.Spec.Clusters {
-		fleetCluster, ok := fleetClusters[ClusterKey{cluster.Kind, cluster.Name}]
-		if !ok {
-			// it can happen when the cluster is not present
-			continue
-		}
-
+	for key, fleetCluster := range fleetClusters {
 		svc, err := fleetCluster.Client.KubeClient().CoreV1().Services(MonitoringNamespace).Get(ctx, PrometheusThanosServiceName, metav1.GetOptions{})
 		if err != nil {
-			return err
+			return newPluginClusterError(key.Name, err)
 		}
 
 		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
@@ -232,6 +222,84 @@ func (f *FleetManager) syncObjStoreSecret(ctx context.Context, fleetCluster *Fle
 	return nil
 }
 
+// reconcileThanosObjStoreSecret returns the objstore secret of thanos in the fleet namespace.
+// For the s3 compatible storage, the object store configuration is generated from the credentials of the secret.
+func (f *FleetManager) reconcileThanosObjStoreSecret(ctx context.Context, fleet *fleetapi.Fleet) (*corev1.Secret, error) {
+	objStoreCfg := fleet.Spec.Plugin.Metric.Thanos.ObjectStoreConfig
+	objSecret := &corev1.Secret{}
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: fleet.Namespace, Name: objStoreCfg.SecretName}, objSecret); err != nil {
+		return nil, err
+	}
+	if objStoreCfg.S3Compatible == nil {
+		return objSecret, nil
+	}
+
+	accessKey, secretKey := string(objSecret.Data[AccessKey]), string(objSecret.Data[SecretKey])
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("keys %s and %s are required in secret %s for s3 compatible storage", AccessKey, SecretKey, objStoreCfg.SecretName)
+	}
+	config, err := plugin.RenderThanosObjStoreConfig(objStoreCfg.S3Compatible, accessKey, secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plugin.ThanosObjStoreSecretName(fleet.Name, objStoreCfg),
+			Namespace: fleet.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, f.Client, generated, func() error {
+		generated.Labels = fleetMetricResourceLabels(fleet.Name)
+		generated.OwnerReferences = []metav1.OwnerReference{*ownerReference(fleet)}
+		generated.Data = map[string][]byte{
+			plugin.ThanosObjStoreConfigKey: config,
+		}
+		if len(objStoreCfg.S3Compatible.CABundle) != 0 {
+			generated.Data[plugin.ThanosObjStoreCAKey] = objStoreCfg.S3Compatible.CABundle
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to sync thanos objstore secret: %w", err)
+	}
+
+	return generated, nil
+}
+
+// deleteThanosObjStoreSecret deletes the objstore secret generated for the s3 compatible storage,
+// when the metric plugin is disabled or the s3 compatible storage is no longer used.
+func (f *FleetManager) deleteThanosObjStoreSecret(ctx context.Context, fleet *fleetapi.Fleet) error {
+	if p := fleet.Spec.Plugin; p != nil && p.Metric != nil && p.Metric.Thanos.ObjectStoreConfig.S3Compatible != nil {
+		return nil
+	}
+
+	generated := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: fleet.Namespace, Name: plugin.GeneratedThanosObjStoreSecretName(fleet.Name)}
+	if err := f.Client.Get(ctx, key, generated); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// never delete the secret provided by the user with the same name
+	for k, v := range fleetMetricResourceLabels(fleet.Name) {
+		if generated.Labels[k] != v {
+			return nil
+		}
+	}
+
+	if err := f.Client.Delete(ctx, generated); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete thanos objstore secret: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	registerBuiltinPlugin(metricPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileMetricPlugin
+	})
+}
+
 func (f *FleetManager) reconcileMetricPlugin(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -260,8 +328,8 @@ func (f *FleetManager) reconcileMetricPlugin(ctx context.Context, fleet *fleetap
 	resources = append(resources, thanosResources...)
 
 	// prepare objstore secret for fleet cluster
-	objSecret := &corev1.Secret{}
-	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: fleet.Namespace, Name: metricCfg.Thanos.ObjectStoreConfig.SecretName}, objSecret); err != nil {
+	objSecret, err := f.reconcileThanosObjStoreSecret(ctx, fleet)
+	if err != nil {
 		return nil, ctrl.Result{}, err
 	}
 	promSecret := &corev1.Secret{
@@ -274,34 +342,32 @@ func (f *FleetManager) reconcileMetricPlugin(ctx context.Context, fleet *fleetap
 	}
 
 	log.V(4).Info("start to reconcile prometheus plugin for every cluster in fleet")
-	for _, c := range fleet.Spec.Clusters {
-		fleetCluster, ok := fleetClusters[ClusterKey{c.Kind, c.Name}]
-		if !ok {
-			// could happen when cluster not present
-			continue
-		}
-
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
+	for c, fleetCluster := range fleetClusters {
 		// TODO: find a better way to sync objstore secret to member clusters
 		if err := f.syncObjStoreSecret(ctx, fleetCluster, promSecret); err != nil {
-			return nil, ctrl.Result{}, fmt.Errorf("failed to reconcile objstore secret for cluster %s: %w", c.Name, err)
+			return nil, ctrl.Result{}, newPluginClusterError(c.Name, fmt.Errorf("failed to reconcile objstore secret: %w", err))
 		}
 
 		b, err := plugin.RenderPrometheus(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       c.Name,
 			SecretName: fleetCluster.Secret,
 			SecretKey:  fleetCluster.SecretKey,
+			Labels:     fleetCluster.Labels,
 		}, metricCfg)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(c.Name, err)
 		}
 
-		// apply HelmRepository and HelmRelease for prometheus per cluster
-		prometheusResources, err := util.PatchResources(b)
-		if err != nil {
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, prometheusResources...)
+		rendered[c] = b
+	}
+
+	// apply HelmRepository and HelmRelease for prometheus per cluster
+	prometheusResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
 	}
+	resources = append(resources, prometheusResources...)
 
 	log.V(4).Info("wait for helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
@@ -26,12 +26,19 @@ import (
 	"kurator.dev/kurator/pkg/infra/util"
 )
 
+func init() {
+	registerBuiltinPlugin(providerPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileProviderPlugin
+	})
+}
+
 // reconcileProviderPlugin reconciles the Provider plugin.
 func (f *FleetManager) reconcileProviderPlugin(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	flaggerCfg := fleet.Spec.Plugin.Flagger
-	if flaggerCfg == nil || flaggerCfg.TrafficRoutingProvider == fleetapi.Istio {
+	// istio and the Gateway API implementation are installed by the user, there is nothing to install for them.
+	if flaggerCfg == nil || flaggerCfg.TrafficRoutingProvider == fleetapi.Istio || flaggerCfg.TrafficRoutingProvider == fleetapi.GatewayAPI {
 		// reconcilePluginResources will delete all resources if plugin is nil
 		return nil, ctrl.Result{}, nil
 	}
@@ -49,15 +56,16 @@ func (f *FleetManager) reconcileProviderPlugin(ctx context.Context, fleet *fleet
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, flaggerCfg)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 
 		// apply provider helm resources
 		providerResources, err := util.PatchResources(b)
 		if err != nil {
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 		resources = append(resources, providerResources...)
 	}
@@ -0,0 +1,237 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"helm.sh/helm/v3/pkg/kube"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	"kurator.dev/kurator/pkg/fleet-manager/plugin"
+	"kurator.dev/kurator/pkg/infra/util"
+)
+
+// Plugin is a fleet add-on installed by the fleet manager.
+// Plugins are registered with RegisterPlugin, usually in the init function of the package implementing it,
+// so an add-on can be shipped by importing its package in the fleet manager binary.
+type Plugin interface {
+	// Name returns the name of the plugin, which is used as the `fleet.kurator.dev/plugin` label
+	// of the rendered resources and reported in the plugin status of the fleet.
+	Name() string
+	// Decode returns the configuration of the plugin from the fleet,
+	// a nil configuration means the plugin is disabled in the fleet.
+	Decode(fleet *fleetapi.Fleet) (interface{}, error)
+	// Render renders the resources of the plugin with the decoded configuration.
+	// It is called once with a nil cluster for the fleet-wide resources, and once for each cluster of the fleet,
+	// an empty output means there is nothing to install.
+	Render(ctx context.Context, fleet *fleetapi.Fleet, cfg interface{}, cluster *PluginCluster) ([]byte, error)
+	// Ready reports whether the rendered resources are ready.
+	Ready(ctx context.Context, fleet *fleetapi.Fleet, resources kube.ResourceList) (bool, error)
+	// Cleanup cleans up what the plugin created outside of the rendered resources.
+	// It is called on every reconciliation while the plugin is disabled and when the fleet is deleted, so it must be idempotent.
+	// The rendered HelmReleases and HelmRepositories are garbage collected by the fleet manager.
+	Cleanup(ctx context.Context, fleet *fleetapi.Fleet) error
+}
+
+// PluginCluster is a cluster of the fleet that the plugin is rendered for.
+type PluginCluster struct {
+	ClusterKey
+	// Labels is the labels of the cluster.
+	Labels map[string]string
+	// KubeConfig is the secret reference of the kubeconfig of the cluster, which is used by the rendered HelmReleases.
+	KubeConfig plugin.KubeConfigSecretRef
+}
+
+// PluginFactory creates the plugin for the fleet manager.
+type PluginFactory func(f *FleetManager) Plugin
+
+// HelmPlugin provides the default Ready and Cleanup implementations for the plugins only rendering helm charts.
+type HelmPlugin struct {
+	Manager *FleetManager
+}
+
+// Ready reports whether all the HelmReleases of the resources are ready.
+func (p HelmPlugin) Ready(ctx context.Context, fleet *fleetapi.Fleet, resources kube.ResourceList) (bool, error) {
+	return p.Manager.helmReleaseReady(ctx, fleet, resources), nil
+}
+
+// Cleanup does nothing, as the helm resources are garbage collected by the fleet manager.
+func (p HelmPlugin) Cleanup(ctx context.Context, fleet *fleetapi.Fleet) error {
+	return nil
+}
+
+// pluginReconcileFunc reconciles a plugin and returns the resources of the plugin to be kept.
+type pluginReconcileFunc func(context.Context, *fleetapi.Fleet, map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error)
+
+type pluginRegistration struct {
+	// factory is set for the plugins registered with RegisterPlugin
+	factory PluginFactory
+	// builtin is set for the in-tree plugins configured in PluginConfig, which reconcile by themselves.
+	builtin func(f *FleetManager) pluginReconcileFunc
+}
+
+var (
+	pluginRegistryLock sync.RWMutex
+	pluginRegistry     = map[string]pluginRegistration{}
+)
+
+// RegisterPlugin registers a plugin to the fleet manager, it panics if the name is already registered.
+func RegisterPlugin(name string, factory PluginFactory) {
+	register(name, pluginRegistration{factory: factory})
+}
+
+// registerBuiltinPlugin registers an in-tree plugin reconciled by its own reconcile function.
+func registerBuiltinPlugin(name string, builtin func(f *FleetManager) pluginReconcileFunc) {
+	register(name, pluginRegistration{builtin: builtin})
+}
+
+func register(name string, r pluginRegistration) {
+	pluginRegistryLock.Lock()
+	defer pluginRegistryLock.Unlock()
+
+	if _, ok := pluginRegistry[name]; ok {
+		panic(fmt.Sprintf("fleet plugin %s is already registered", name))
+	}
+	pluginRegistry[name] = r
+}
+
+func isRegisteredPlugin(name string) bool {
+	pluginRegistryLock.RLock()
+	defer pluginRegistryLock.RUnlock()
+
+	_, ok := pluginRegistry[name]
+	return ok
+}
+
+// pluginReconcileFuncs returns the reconcile functions of the registered plugins keyed by the plugin names.
+func (f *FleetManager) pluginReconcileFuncs(fleet *fleetapi.Fleet) map[string]pluginReconcileFunc {
+	pluginRegistryLock.RLock()
+	defer pluginRegistryLock.RUnlock()
+
+	funcs := make(map[string]pluginReconcileFunc, len(pluginRegistry))
+	for name, r := range pluginRegistry {
+		if r.builtin != nil {
+			if fleet.Spec.Plugin == nil {
+				// reconcilePluginResources will delete all resources if plugin is nil
+				continue
+			}
+			funcs[name] = r.builtin(f)
+			continue
+		}
+
+		p := r.factory(f)
+		funcs[name] = func(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
+			return f.reconcilePlugin(ctx, p, fleet, fleetClusters)
+		}
+	}
+	return funcs
+}
+
+// reconcilePlugin installs the plugin registered with RegisterPlugin.
+func (f *FleetManager) reconcilePlugin(ctx context.Context, p Plugin, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	cfg, err := p.Decode(fleet)
+	if err != nil {
+		return nil, ctrl.Result{}, fmt.Errorf("failed to decode config of plugin %s: %w", p.Name(), err)
+	}
+	if cfg == nil {
+		// reconcilePluginResources will delete all resources if plugin is disabled
+		return nil, ctrl.Result{}, p.Cleanup(ctx, fleet)
+	}
+
+	var resources kube.ResourceList
+	b, err := p.Render(ctx, fleet, cfg, nil)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	if len(bytes.TrimSpace(b)) != 0 {
+		pluginResources, err := util.PatchResources(b)
+		if err != nil {
+			return nil, ctrl.Result{}, err
+		}
+		resources = append(resources, pluginResources...)
+	}
+
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
+	for key, cluster := range fleetClusters {
+		b, err := p.Render(ctx, fleet, cfg, &PluginCluster{
+			ClusterKey: key,
+			Labels:     cluster.Labels,
+			KubeConfig: plugin.KubeConfigSecretRef{
+				Name:       key.Name,
+				SecretName: cluster.Secret,
+				SecretKey:  cluster.SecretKey,
+				Labels:     cluster.Labels,
+			},
+		})
+		if err != nil {
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
+		}
+		if len(bytes.TrimSpace(b)) != 0 {
+			rendered[key] = b
+		}
+	}
+
+	// the chart upgrades in the clusters are rolled out with the upgrade strategy of the fleet
+	clusterResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	resources = append(resources, clusterResources...)
+
+	log.V(4).Info("wait for plugin to be ready", "plugin", p.Name())
+	ready, err := p.Ready(ctx, fleet, resources)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	if !ready {
+		return nil, ctrl.Result{
+			// HelmRelease check interval is 1m, so we set 30s here
+			RequeueAfter: 30 * time.Second,
+		}, nil
+	}
+
+	return resources, ctrl.Result{}, nil
+}
+
+// cleanupPlugins cleans up the plugins registered with RegisterPlugin when the fleet is deleted.
+func (f *FleetManager) cleanupPlugins(ctx context.Context, fleet *fleetapi.Fleet) error {
+	pluginRegistryLock.RLock()
+	factories := make(map[string]PluginFactory, len(pluginRegistry))
+	for name, r := range pluginRegistry {
+		if r.factory != nil {
+			factories[name] = r.factory
+		}
+	}
+	pluginRegistryLock.RUnlock()
+
+	var errs []error
+	for name, factory := range factories {
+		if err := factory(f).Cleanup(ctx, fleet); err != nil {
+			errs = append(errs, fmt.Errorf("failed to cleanup plugin %s: %w", name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -69,6 +69,12 @@ func getBrokerConfig(ctx context.Context, clusterName string, cluster *FleetClus
 	return brokerCfg, nil
 }
 
+func init() {
+	registerBuiltinPlugin(submarinerPlugin, func(f *FleetManager) pluginReconcileFunc {
+		return f.reconcileSubmarinerPlugin
+	})
+}
+
 // reconcileSubmarinerPlugin reconciles the Submariner plugin.
 // The fleetClusters parameter is currently unused, but is included to match the function signature of other functions in reconcilePlugins.
 func (f *FleetManager) reconcileSubmarinerPlugin(ctx context.Context, fleet *fleetapi.Fleet, fleetClusters map[ClusterKey]*FleetCluster) (kube.ResourceList, ctrl.Result, error) {
@@ -99,8 +105,20 @@ func (f *FleetManager) reconcileSubmarinerPlugin(ctx context.Context, fleet *fle
 
 	if smOperatorCfg.BrokerCluster == "" {
 		// Install broker in the first member cluster
-		brokerClusterName = fleet.Spec.Clusters[0].Name
-		log.V(4).Info("broker cluster not specified, using the first member cluster", "brokerClusterName", brokerClusterName)
+		clusters, err := FleetClusterRefs(ctx, f.Client, fleet)
+		if err != nil {
+			return nil, ctrl.Result{}, err
+		}
+		for _, cluster := range clusters {
+			if _, ok := fleetClusters[ClusterKey{Kind: cluster.Kind, Name: cluster.Name}]; ok {
+				brokerClusterName = cluster.Name
+				break
+			}
+		}
+		if brokerClusterName == "" {
+			return nil, ctrl.Result{}, errors.New("no ready member cluster to install the submariner broker")
+		}
+		log.V(4).Info("broker cluster not specified, using the first ready member cluster", "brokerClusterName", brokerClusterName)
 	}
 
 	for key, cluster := range fleetClusters {
@@ -110,14 +128,15 @@ func (f *FleetManager) reconcileSubmarinerPlugin(ctx context.Context, fleet *fle
 				Name:       brokerClusterName,
 				SecretName: brokerCluster.Secret,
 				SecretKey:  brokerCluster.SecretKey,
+				Labels:     brokerCluster.Labels,
 			})
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(brokerClusterName, err)
 			}
 
 			brokerResources, err := util.PatchResources(b)
 			if err != nil {
-				return nil, ctrl.Result{}, err
+				return nil, ctrl.Result{}, newPluginClusterError(brokerClusterName, err)
 			}
 			resources = append(resources, brokerResources...)
 
@@ -142,24 +161,28 @@ func (f *FleetManager) reconcileSubmarinerPlugin(ctx context.Context, fleet *fle
 	}
 
 	// Install operator in all member clusters
+	rendered := make(map[ClusterKey][]byte, len(fleetClusters))
 	for key, cluster := range fleetClusters {
 		b, err := plugin.RenderSubmarinerOperator(f.Manifests, fleetNN, fleetOwnerRef, plugin.KubeConfigSecretRef{
 			Name:       key.Name,
 			SecretName: cluster.Secret,
 			SecretKey:  cluster.SecretKey,
+			Labels:     cluster.Labels,
 		}, smOperatorCfg, brokerCfg)
 		if err != nil {
 			log.V(4).Error(err, "failed to render submariner operator")
-			return nil, ctrl.Result{}, err
+			return nil, ctrl.Result{}, newPluginClusterError(key.Name, err)
 		}
 
-		operatorResources, err := util.PatchResources(b)
-		if err != nil {
-			log.V(4).Error(err, "failed to render submariner operator")
-			return nil, ctrl.Result{}, err
-		}
-		resources = append(resources, operatorResources...)
+		rendered[key] = b
+	}
+
+	operatorResources, err := f.applyClusterResources(ctx, fleet, rendered)
+	if err != nil {
+		log.V(4).Error(err, "failed to apply submariner operator")
+		return nil, ctrl.Result{}, err
 	}
+	resources = append(resources, operatorResources...)
 
 	log.V(4).Info("wait for submariner operator helm release to be reconciled")
 	if !f.helmReleaseReady(ctx, fleet, resources) {
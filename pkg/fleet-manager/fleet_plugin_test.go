@@ -0,0 +1,639 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	hrapiv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	"kurator.dev/kurator/pkg/fleet-manager/manifests"
+	"kurator.dev/kurator/pkg/fleet-manager/plugin"
+)
+
+func newPluginHelmRelease(name, pluginName, component, cluster string, ready metav1.ConditionStatus) *hrapiv2b1.HelmRelease {
+	labels := map[string]string{
+		ManagedByLabel:     ManagedByFleetManager,
+		FleetNameLabel:     "fleet",
+		FleetPluginName:    pluginName,
+		FleetComponentName: component,
+	}
+	if cluster != "" {
+		labels[FleetClusterName] = cluster
+	}
+	hr := &hrapiv2b1.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+	}
+	hr.Spec.Chart.Spec.Version = "1.0.0"
+	hr.Status.Conditions = []metav1.Condition{
+		{Type: "Ready", Status: ready, Reason: "test", Message: "install failed"},
+	}
+	return hr
+}
+
+func TestReconcilePluginStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, hrapiv2b1.AddToScheme(scheme))
+	assert.NoError(t, fleetapi.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		newPluginHelmRelease("prometheus-c1", "metric", "prometheus", "c1", metav1.ConditionTrue),
+		newPluginHelmRelease("prometheus-c2", "metric", "prometheus", "c2", metav1.ConditionTrue),
+		newPluginHelmRelease("velero-c1", "backup", "velero", "c1", metav1.ConditionFalse),
+		newPluginHelmRelease("rook-c1", "storage-operator", "rook", "c1", metav1.ConditionTrue),
+	).Build()
+	f := &FleetManager{Client: c}
+
+	fleet := &fleetapi.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"}}
+	err := f.reconcilePluginStatus(context.Background(), fleet, map[string]error{
+		grafanaPlugin: errors.New("chart not found"),
+		metricPlugin:  newPluginClusterError("c2", errors.New("secret not found")),
+		kyvernoPlugin: newPluginClusterError("c3", errors.New("render failed")),
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, fleet.Status.Plugins, 6)
+	statuses := make(map[string]*fleetapi.FleetPluginStatus)
+	for _, s := range fleet.Status.Plugins {
+		statuses[pluginStatusKey(s)] = s
+	}
+	metricC1 := statuses["metric/prometheus/c1"]
+	assert.True(t, metricC1.Ready)
+	assert.Equal(t, "1.0.0", metricC1.ChartVersion)
+	assert.NotNil(t, metricC1.LastTransitionTime)
+	// the error of the plugin is only reported in the cluster where it failed
+	assert.Empty(t, metricC1.LastError)
+	assert.Equal(t, "cluster c2: secret not found", statuses["metric/prometheus/c2"].LastError)
+	assert.False(t, statuses["backup/velero/c1"].Ready)
+	assert.Equal(t, "install failed", statuses["backup/velero/c1"].LastError)
+	assert.True(t, statuses["distributedStorage/rook/c1"].Ready)
+	assert.Equal(t, "chart not found", statuses["grafana//"].LastError)
+	assert.Equal(t, "cluster c3: render failed", statuses["kyverno//c3"].LastError)
+	assert.True(t, conditions.IsFalse(fleet, fleetapi.PluginsReadyCondition))
+}
+
+func TestCustomPluginDecode(t *testing.T) {
+	chart := fleetapi.ChartConfig{Repository: "https://charts.example.com", Name: "foo", Version: "1.0.0"}
+	cases := []struct {
+		name      string
+		plugin    *fleetapi.PluginConfig
+		expectCfg bool
+		expectErr bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:      "enabled",
+			plugin:    &fleetapi.PluginConfig{Custom: []*fleetapi.CustomPluginConfig{{Name: "foo", Chart: chart}}},
+			expectCfg: true,
+		},
+		{
+			name: "duplicate name",
+			plugin: &fleetapi.PluginConfig{Custom: []*fleetapi.CustomPluginConfig{
+				{Name: "foo", Chart: chart},
+				{Name: "foo", Chart: chart},
+			}},
+			expectErr: true,
+		},
+		{
+			name:      "chart version missing",
+			plugin:    &fleetapi.PluginConfig{Custom: []*fleetapi.CustomPluginConfig{{Name: "foo", Chart: fleetapi.ChartConfig{Name: "foo"}}}},
+			expectErr: true,
+		},
+	}
+
+	p := &customPlugin{}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := p.Decode(&fleetapi.Fleet{Spec: fleetapi.FleetSpec{Plugin: tc.plugin}})
+			assert.Equal(t, tc.expectErr, err != nil)
+			assert.Equal(t, tc.expectCfg, cfg != nil)
+		})
+	}
+}
+
+func TestPluginOfLabel(t *testing.T) {
+	assert.Equal(t, metricPlugin, pluginOfLabel("metric"))
+	assert.Equal(t, distributedStoragePlugin, pluginOfLabel("cluster-storage"))
+	assert.Equal(t, submarinerPlugin, pluginOfLabel("submariner-operator"))
+	assert.Equal(t, "custom", pluginOfLabel("custom"))
+	assert.Equal(t, providerPlugin, pluginOfLabel("kuma"))
+}
+
+func TestCustomPluginRender(t *testing.T) {
+	fleet := &fleetapi.Fleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+		Spec: fleetapi.FleetSpec{
+			Plugin: &fleetapi.PluginConfig{
+				Custom: []*fleetapi.CustomPluginConfig{
+					{
+						Name:  "cert-manager",
+						Chart: fleetapi.ChartConfig{Repository: "https://charts.jetstack.io", Name: "cert-manager", Version: "v1.14.4"},
+					},
+					{
+						Name:  "external-dns",
+						Chart: fleetapi.ChartConfig{Repository: "https://kubernetes-sigs.github.io/external-dns", Name: "external-dns", Version: "1.14.3"},
+						ClusterSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "prod"},
+						},
+					},
+					{
+						Name:        "trust-manager",
+						Chart:       fleetapi.ChartConfig{Repository: "https://charts.jetstack.io", Name: "trust-manager", Version: "v0.9.0"},
+						FleetScoped: true,
+					},
+				},
+			},
+		},
+	}
+
+	p := &customPlugin{HelmPlugin: HelmPlugin{Manager: &FleetManager{Manifests: manifests.BuiltinOrDir("")}}}
+	cfg, err := p.Decode(fleet)
+	assert.NoError(t, err)
+
+	out, err := p.Render(context.Background(), fleet, cfg, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `name: "trust-manager"`)
+	assert.NotContains(t, string(out), "cert-manager")
+
+	cluster := func(name string, labels map[string]string) *PluginCluster {
+		return &PluginCluster{
+			ClusterKey: ClusterKey{Kind: AttachedClusterKind, Name: name},
+			Labels:     labels,
+			KubeConfig: plugin.KubeConfigSecretRef{Name: name, SecretName: name, SecretKey: "kubeconfig"},
+		}
+	}
+
+	out, err = p.Render(context.Background(), fleet, cfg, cluster("c1", map[string]string{"env": "prod"}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "cert-manager-c1")
+	assert.Contains(t, string(out), "external-dns-c1")
+	assert.NotContains(t, string(out), "trust-manager")
+
+	out, err = p.Render(context.Background(), fleet, cfg, cluster("c2", map[string]string{"env": "dev"}))
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "cert-manager-c2")
+	assert.NotContains(t, string(out), "external-dns")
+}
+
+func TestValidatePluginResourceNames(t *testing.T) {
+	chart := fleetapi.ChartConfig{Repository: "https://charts.example.com", Name: "foo", Version: "1.0.0"}
+	fleetClusters := map[ClusterKey]*FleetCluster{
+		{Kind: AttachedClusterKind, Name: "c1"}:      {},
+		{Kind: AttachedClusterKind, Name: "adapter"}: {},
+	}
+	cases := []struct {
+		name         string
+		plugin       *fleetapi.PluginConfig
+		expectErrors []string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "unique components",
+			plugin: &fleetapi.PluginConfig{
+				Custom: []*fleetapi.CustomPluginConfig{
+					{Name: "external-dns", Chart: chart},
+					{Name: "cert-manager", Chart: chart, ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+				},
+			},
+		},
+		{
+			name: "fleet scoped custom plugin conflicts with cluster scoped custom plugin",
+			plugin: &fleetapi.PluginConfig{
+				Custom: []*fleetapi.CustomPluginConfig{
+					{Name: "foo-c1", Chart: chart, FleetScoped: true},
+					{Name: "foo", Chart: chart},
+				},
+			},
+			expectErrors: []string{"resource name foo-c1 of custom plugin foo conflicts with custom plugin foo-c1"},
+		},
+		{
+			name: "all conflicts are reported",
+			plugin: &fleetapi.PluginConfig{
+				Custom: []*fleetapi.CustomPluginConfig{
+					{Name: "grafana", Chart: chart, FleetScoped: true},
+					{Name: "prometheus-adapter", Chart: chart, FleetScoped: true},
+					{Name: "velero", Chart: chart},
+				},
+			},
+			expectErrors: []string{
+				"resource name grafana of custom plugin grafana conflicts with built-in component grafana",
+				"resource name prometheus-adapter of custom plugin prometheus-adapter conflicts with built-in component prometheus",
+				"resource name velero-adapter of custom plugin velero conflicts with built-in component velero",
+				"resource name velero-c1 of custom plugin velero conflicts with built-in component velero",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePluginResourceNames(&fleetapi.Fleet{Spec: fleetapi.FleetSpec{Plugin: tc.plugin}}, fleetClusters)
+			if len(tc.expectErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var agg utilerrors.Aggregate
+			assert.ErrorAs(t, err, &agg)
+			var got []string
+			for _, e := range agg.Errors() {
+				got = append(got, e.Error())
+			}
+			assert.Equal(t, tc.expectErrors, got)
+		})
+	}
+}
+
+func TestRolloutComponent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, hrapiv2b1.AddToScheme(scheme))
+
+	// c1 is upgraded and ready, c2 and c3 are still running the old version
+	upgraded := newPluginHelmRelease("prometheus-c1", "metric", "prometheus", "c1", metav1.ConditionTrue)
+	upgraded.Spec.Chart.Spec.Version = "2.0.0"
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		upgraded,
+		newPluginHelmRelease("prometheus-c2", "metric", "prometheus", "c2", metav1.ConditionTrue),
+		newPluginHelmRelease("prometheus-c3", "metric", "prometheus", "c3", metav1.ConditionTrue),
+	).Build()
+	f := &FleetManager{Client: c}
+
+	clusters := make(map[ClusterKey]kube.ResourceList)
+	for _, name := range []string{"c1", "c2", "c3", "c4"} {
+		hr := newPluginHelmRelease("prometheus-"+name, "metric", "prometheus", name, metav1.ConditionTrue)
+		hr.Spec.Chart.Spec.Version = "2.0.0"
+		clusters[ClusterKey{Kind: AttachedClusterKind, Name: name}] = kube.ResourceList{{
+			Name:      hr.Name,
+			Namespace: hr.Namespace,
+			Object:    hr,
+			Mapping:   &apimeta.RESTMapping{GroupVersionKind: hrapiv2b1.GroupVersion.WithKind(hrapiv2b1.HelmReleaseKind)},
+		}}
+	}
+
+	strategy := &fleetapi.PluginUpgradeStrategy{
+		BatchSize:           &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+		PauseBetweenBatches: &metav1.Duration{Duration: time.Hour},
+	}
+	fleet := &fleetapi.Fleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+		Spec:       fleetapi.FleetSpec{Plugin: &fleetapi.PluginConfig{UpgradeStrategy: strategy}},
+	}
+
+	names := func(resources kube.ResourceList) []string {
+		var out []string
+		for _, res := range resources {
+			out = append(out, res.Name)
+		}
+		return out
+	}
+
+	// c1 is kept, c2 is the next batch and c4 is a new installation
+	target, err := f.rolloutComponent(context.Background(), fleet, strategy, "prometheus", clusters)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"prometheus-c1", "prometheus-c2", "prometheus-c4"}, names(target))
+	status := pluginUpgradeStatus(fleet, "prometheus")
+	assert.Equal(t, fleetapi.PluginUpgradeProgressing, status.Phase)
+	assert.Equal(t, "2.0.0", status.Version)
+	assert.Equal(t, []string{"c1", "c2"}, status.UpdatedClusters)
+	assert.Equal(t, []string{"c3"}, status.PendingClusters)
+	assert.Equal(t, upgradeCheckInterval, pluginUpgradeResult(fleet).RequeueAfter)
+
+	// the next batch waits for the pause
+	target, err = f.rolloutComponent(context.Background(), fleet, strategy, "prometheus", clusters)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"prometheus-c1", "prometheus-c4"}, names(target))
+	assert.Equal(t, "pausing before the next batch", status.Message)
+
+	// a failed cluster stops the upgrade
+	failed := &hrapiv2b1.HelmRelease{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(upgraded), failed))
+	failed.Status.Conditions[0].Status = metav1.ConditionFalse
+	assert.NoError(t, c.Update(context.Background(), failed))
+	status.LastBatchTime = nil
+	_, err = f.rolloutComponent(context.Background(), fleet, strategy, "prometheus", clusters)
+	assert.NoError(t, err)
+	assert.Equal(t, fleetapi.PluginUpgradeFailed, status.Phase)
+	assert.Zero(t, pluginUpgradeResult(fleet).RequeueAfter)
+}
+
+func TestUpgradeBatchSize(t *testing.T) {
+	cases := []struct {
+		name      string
+		batchSize *intstr.IntOrString
+		total     int
+		expected  int
+	}{
+		{name: "default", total: 5, expected: 1},
+		{name: "int", batchSize: &intstr.IntOrString{Type: intstr.Int, IntVal: 2}, total: 5, expected: 2},
+		{name: "percent rounded up", batchSize: &intstr.IntOrString{Type: intstr.String, StrVal: "30%"}, total: 5, expected: 2},
+		{name: "zero", batchSize: &intstr.IntOrString{Type: intstr.String, StrVal: "0%"}, total: 5, expected: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, upgradeBatchSize(&fleetapi.PluginUpgradeStrategy{BatchSize: tc.batchSize}, tc.total))
+		})
+	}
+}
+
+func TestBuildObjStoreSecret(t *testing.T) {
+	newSecret := func(name string, data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string][]byte{},
+		}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		newSecret("gcp", map[string]string{GCPServiceAccountKey: `{"type": "service_account"}`}),
+		newSecret("azure-sp", map[string]string{
+			AzureSubscriptionID: "sub",
+			AzureTenantID:       "tenant",
+			AzureClientID:       "client",
+			AzureClientSecret:   "secret",
+		}),
+		newSecret("azure-key", map[string]string{AzureStorageAccountKey: "key", AzureCloudName: "AzureChinaCloud"}),
+		newSecret("minio", map[string]string{AccessKey: "ak", SecretKey: "sk"}),
+		newSecret("invalid", map[string]string{AccessKey: "ak"}),
+	).Build()
+	f := &FleetManager{Client: c}
+	fleetNN := types.NamespacedName{Namespace: "default", Name: "fleet"}
+
+	cases := []struct {
+		name      string
+		provider  string
+		secret    string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "gcp",
+			provider: GCP,
+			secret:   "gcp",
+			expected: `{"type": "service_account"}`,
+		},
+		{
+			name:     "s3compatible",
+			provider: S3Compatible,
+			secret:   "minio",
+			expected: "[default]\naws_access_key_id=ak\naws_secret_access_key=sk",
+		},
+		{
+			name:      "gcp without service account key",
+			provider:  GCP,
+			secret:    "invalid",
+			expectErr: true,
+		},
+		{
+			name:     "azure service principal",
+			provider: Azure,
+			secret:   "azure-sp",
+			expected: "AZURE_SUBSCRIPTION_ID=sub\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_SECRET=secret\nAZURE_CLOUD_NAME=AzurePublicCloud\n",
+		},
+		{
+			name:     "azure storage account key",
+			provider: Azure,
+			secret:   "azure-key",
+			expected: "AZURE_STORAGE_ACCOUNT_ACCESS_KEY=key\nAZURE_CLOUD_NAME=AzureChinaCloud\n",
+		},
+		{
+			name:      "azure without credentials",
+			provider:  Azure,
+			secret:    "invalid",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, err := f.buildNewSecret(context.Background(), tc.secret, tc.provider, fleetNN)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, ObjStoreSecretNamespace, secret.Namespace)
+			assert.Equal(t, tc.expected, string(secret.Data["cloud"]))
+		})
+	}
+}
+
+func TestValidateBackupStorage(t *testing.T) {
+	cases := []struct {
+		name      string
+		location  fleetapi.BackupStorageLocation
+		expectErr bool
+	}{
+		{
+			name:     "aws",
+			location: fleetapi.BackupStorageLocation{Bucket: "velero", Provider: AWS, Endpoint: "http://minio:9000", Region: "minio"},
+		},
+		{
+			name:      "aws without endpoint",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: AWS},
+			expectErr: true,
+		},
+		{
+			name: "s3compatible",
+			location: fleetapi.BackupStorageLocation{Bucket: "velero", Provider: S3Compatible, Endpoint: "https://minio:9000",
+				S3Compatible: &fleetapi.S3CompatibleConfig{InsecureSkipTLSVerify: true}},
+		},
+		{
+			name:      "s3compatible without endpoint",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: S3Compatible},
+			expectErr: true,
+		},
+		{
+			name:     "gcp",
+			location: fleetapi.BackupStorageLocation{Bucket: "velero", Provider: GCP},
+		},
+		{
+			name:      "gcp with s3 compatible options",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: GCP, S3Compatible: &fleetapi.S3CompatibleConfig{}},
+			expectErr: true,
+		},
+		{
+			name:      "gcp with region",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: GCP, Region: "us-east1"},
+			expectErr: true,
+		},
+		{
+			name: "azure",
+			location: fleetapi.BackupStorageLocation{Bucket: "velero", Provider: Azure, Config: map[string]string{
+				"resourceGroup":  "rg",
+				"storageAccount": "account",
+			}},
+		},
+		{
+			name: "azure without resource group",
+			location: fleetapi.BackupStorageLocation{Bucket: "velero", Provider: Azure, Config: map[string]string{
+				"storageAccount": "account",
+			}},
+			expectErr: true,
+		},
+		{
+			name:      "azure without storage account",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: Azure},
+			expectErr: true,
+		},
+		{
+			name:      "bucket missing",
+			location:  fleetapi.BackupStorageLocation{Provider: GCP},
+			expectErr: true,
+		},
+		{
+			name:      "unknown provider",
+			location:  fleetapi.BackupStorageLocation{Bucket: "velero", Provider: "unknown"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBackupStorage(fleetapi.BackupStorage{Location: tc.location, SecretName: "secret"})
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestValidateVolumeSnapshotLocations(t *testing.T) {
+	cases := []struct {
+		name      string
+		provider  string
+		locations []*fleetapi.VolumeSnapshotLocation
+		expectErr bool
+	}{
+		{
+			name:     "no locations",
+			provider: GCP,
+		},
+		{
+			name:      "aws snapshots with s3 compatible storage",
+			provider:  S3Compatible,
+			locations: []*fleetapi.VolumeSnapshotLocation{{Name: "ebs", Provider: AWS}},
+		},
+		{
+			name:      "provider mismatch",
+			provider:  AWS,
+			locations: []*fleetapi.VolumeSnapshotLocation{{Name: "disk", Provider: GCP}},
+			expectErr: true,
+		},
+		{
+			name:      "name missing",
+			provider:  Azure,
+			locations: []*fleetapi.VolumeSnapshotLocation{{Provider: Azure}},
+			expectErr: true,
+		},
+		{
+			name:     "duplicate names",
+			provider: Azure,
+			locations: []*fleetapi.VolumeSnapshotLocation{
+				{Name: "disk", Provider: Azure},
+				{Name: "disk", Provider: Azure},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := fleetapi.BackupStorage{Location: fleetapi.BackupStorageLocation{Provider: tc.provider}}
+			err := validateVolumeSnapshotLocations(storage, tc.locations)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestReconcileThanosObjStoreSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "minio-credentials", Namespace: "default"},
+		Data:       map[string][]byte{AccessKey: []byte("ak"), SecretKey: []byte("sk")},
+	}).Build()
+	f := &FleetManager{Client: c}
+
+	fleet := &fleetapi.Fleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+		Spec: fleetapi.FleetSpec{
+			Plugin: &fleetapi.PluginConfig{
+				Metric: &fleetapi.MetricConfig{
+					Thanos: fleetapi.ThanosConfig{
+						ObjectStoreConfig: fleetapi.ObjectStoreConfig{
+							SecretName: "minio-credentials",
+							S3Compatible: &fleetapi.S3CompatibleObjectStore{
+								Bucket:             "thanos",
+								Endpoint:           "https://minio.minio:9000",
+								S3CompatibleConfig: fleetapi.S3CompatibleConfig{CABundle: []byte("ca")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	secret, err := f.reconcileThanosObjStoreSecret(context.Background(), fleet)
+	assert.NoError(t, err)
+	assert.Equal(t, "fleet-thanos-objstore", secret.Name)
+	assert.Equal(t, "ca", string(secret.Data[plugin.ThanosObjStoreCAKey]))
+	assert.Contains(t, string(secret.Data[plugin.ThanosObjStoreConfigKey]), "endpoint: minio.minio:9000")
+
+	generated := &corev1.Secret{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "fleet-thanos-objstore"}, generated))
+	assert.Equal(t, secret.Data, generated.Data)
+
+	// the user's objstore secret is used as is without s3 compatible storage
+	fleet.Spec.Plugin.Metric.Thanos.ObjectStoreConfig.S3Compatible = nil
+	secret, err = f.reconcileThanosObjStoreSecret(context.Background(), fleet)
+	assert.NoError(t, err)
+	assert.Equal(t, "minio-credentials", secret.Name)
+
+	// the generated secret is deleted once the s3 compatible storage is no longer used
+	assert.NoError(t, f.deleteThanosObjStoreSecret(context.Background(), fleet))
+	err = c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "fleet-thanos-objstore"}, generated)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, f.deleteThanosObjStoreSecret(context.Background(), fleet))
+
+	// the user's secret is kept
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "minio-credentials"}, &corev1.Secret{}))
+}
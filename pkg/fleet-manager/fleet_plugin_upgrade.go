@@ -0,0 +1,307 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hrapiv2b1 "github.com/fluxcd/helm-controller/api/v2beta1"
+	"helm.sh/helm/v3/pkg/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+	"kurator.dev/kurator/pkg/infra/util"
+)
+
+// upgradeCheckInterval is the interval to check the upgraded clusters, HelmRelease check interval is 1m.
+const upgradeCheckInterval = 30 * time.Second
+
+// applyClusterResources applies the rendered resources of a plugin keyed by the clusters,
+// the clusters are all in the namespace of the fleet, so they are identified by the kind and name.
+// With the upgrade strategy of the fleet, the chart upgrades of each component are rolled out batch by batch,
+// the resources of the clusters waiting to be upgraded are left as is,
+// but they are still returned so that they are not garbage collected.
+func (f *FleetManager) applyClusterResources(ctx context.Context, fleet *fleetapi.Fleet, rendered map[ClusterKey][]byte) (kube.ResourceList, error) {
+	var strategy *fleetapi.PluginUpgradeStrategy
+	if fleet.Spec.Plugin != nil {
+		strategy = fleet.Spec.Plugin.UpgradeStrategy
+	}
+
+	var resources kube.ResourceList
+	// component -> cluster -> resources
+	components := make(map[string]map[ClusterKey]kube.ResourceList)
+	for cluster, b := range rendered {
+		if strategy == nil {
+			clusterResources, err := util.PatchResources(b)
+			if err != nil {
+				return nil, newPluginClusterError(cluster.Name, err)
+			}
+			resources = append(resources, clusterResources...)
+			continue
+		}
+
+		clusterResources, err := util.BuildResources(b)
+		if err != nil {
+			return nil, newPluginClusterError(cluster.Name, err)
+		}
+		for _, res := range clusterResources {
+			component := componentOf(res.Object)
+			if components[component] == nil {
+				components[component] = make(map[ClusterKey]kube.ResourceList)
+			}
+			components[component][cluster] = append(components[component][cluster], res)
+		}
+	}
+
+	for component, clusters := range components {
+		target, err := f.rolloutComponent(ctx, fleet, strategy, component, clusters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade %s: %w", component, err)
+		}
+		if len(target) != 0 {
+			if err := util.UpdateResources(target); err != nil {
+				return nil, err
+			}
+		}
+		for _, clusterResources := range clusters {
+			resources = append(resources, clusterResources...)
+		}
+	}
+
+	return resources, nil
+}
+
+// rolloutComponent returns the resources of the component to be applied according to the upgrade strategy,
+// and records the progress of the upgrade in the fleet status.
+func (f *FleetManager) rolloutComponent(ctx context.Context, fleet *fleetapi.Fleet, strategy *fleetapi.PluginUpgradeStrategy,
+	component string, clusters map[ClusterKey]kube.ResourceList) (kube.ResourceList, error) {
+	keys := make([]ClusterKey, 0, len(clusters))
+	for key := range clusters {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Name != keys[j].Name {
+			return keys[i].Name < keys[j].Name
+		}
+		return keys[i].Kind < keys[j].Kind
+	})
+
+	var target kube.ResourceList
+	var version string
+	var updated, notReady, failed []string
+	var outdated []ClusterKey
+	for _, key := range keys {
+		name := key.Name
+		release := helmReleaseOf(clusters[key])
+		if release == nil {
+			target = append(target, clusters[key]...)
+			continue
+		}
+		version = chartVersionOf(release.Object)
+
+		hr := &hrapiv2b1.HelmRelease{}
+		if err := f.Client.Get(ctx, types.NamespacedName{Namespace: fleet.Namespace, Name: release.Name}, hr); err != nil {
+			if apierrors.IsNotFound(err) {
+				// new installation is not staged
+				target = append(target, clusters[key]...)
+				continue
+			}
+			return nil, err
+		}
+
+		if hr.Spec.Chart.Spec.Version != version {
+			outdated = append(outdated, key)
+			continue
+		}
+
+		target = append(target, clusters[key]...)
+		updated = append(updated, name)
+		if hr.Status.ObservedGeneration != hr.Generation {
+			notReady = append(notReady, name)
+			continue
+		}
+		if ready := apimeta.FindStatusCondition(hr.Status.Conditions, "Ready"); ready == nil || ready.Status != metav1.ConditionTrue {
+			if ready != nil && ready.Status == metav1.ConditionFalse {
+				failed = append(failed, name)
+			} else {
+				notReady = append(notReady, name)
+			}
+		}
+	}
+
+	f.pluginUpgradeLock.Lock()
+	defer f.pluginUpgradeLock.Unlock()
+
+	status := pluginUpgradeStatus(fleet, component)
+	if len(outdated) == 0 {
+		if status != nil && status.Phase != fleetapi.PluginUpgradeCompleted {
+			status.Phase = fleetapi.PluginUpgradeCompleted
+			status.UpdatedClusters = updated
+			status.PendingClusters = nil
+			status.Message = ""
+		}
+		return target, nil
+	}
+
+	if status == nil || status.Version != version {
+		status = &fleetapi.PluginUpgradeStatus{
+			Component: component,
+			Version:   version,
+		}
+		setPluginUpgradeStatus(fleet, status)
+	}
+	status.Phase = fleetapi.PluginUpgradeProgressing
+	status.UpdatedClusters = updated
+	status.PendingClusters = clusterKeyNames(outdated)
+
+	if len(failed) != 0 && !strategy.ContinueOnFailure {
+		status.Phase = fleetapi.PluginUpgradeFailed
+		status.Message = fmt.Sprintf("upgrade is stopped as clusters %s failed", strings.Join(failed, ","))
+		return target, nil
+	}
+	if len(notReady) != 0 {
+		status.Message = fmt.Sprintf("waiting for clusters %s to be ready", strings.Join(notReady, ","))
+		return target, nil
+	}
+	if status.LastBatchTime != nil && strategy.PauseBetweenBatches != nil &&
+		time.Since(status.LastBatchTime.Time) < strategy.PauseBetweenBatches.Duration {
+		status.Message = "pausing before the next batch"
+		return target, nil
+	}
+
+	batch := upgradeBatchSize(strategy, len(updated)+len(outdated))
+	if batch > len(outdated) {
+		batch = len(outdated)
+	}
+	for _, key := range outdated[:batch] {
+		target = append(target, clusters[key]...)
+	}
+	batchNames := clusterKeyNames(outdated[:batch])
+	now := metav1.Now()
+	status.LastBatchTime = &now
+	status.UpdatedClusters = append(updated, batchNames...)
+	status.PendingClusters = clusterKeyNames(outdated[batch:])
+	status.Message = fmt.Sprintf("upgrading clusters %s", strings.Join(batchNames, ","))
+	ctrl.LoggerFrom(ctx).Info("upgrade plugin component", "component", component, "version", version, "clusters", batchNames)
+
+	return target, nil
+}
+
+// pluginUpgradeResult returns the result to continue the staged upgrades in progress.
+func pluginUpgradeResult(fleet *fleetapi.Fleet) ctrl.Result {
+	var requeueAfter time.Duration
+	for _, status := range fleet.Status.PluginUpgrades {
+		if status.Phase != fleetapi.PluginUpgradeProgressing {
+			continue
+		}
+
+		after := upgradeCheckInterval
+		if status.LastBatchTime != nil && fleet.Spec.Plugin != nil && fleet.Spec.Plugin.UpgradeStrategy != nil &&
+			fleet.Spec.Plugin.UpgradeStrategy.PauseBetweenBatches != nil {
+			remaining := fleet.Spec.Plugin.UpgradeStrategy.PauseBetweenBatches.Duration - time.Since(status.LastBatchTime.Time)
+			if remaining > 0 && remaining < after {
+				after = remaining
+			}
+		}
+		if requeueAfter == 0 || after < requeueAfter {
+			requeueAfter = after
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
+
+func upgradeBatchSize(strategy *fleetapi.PluginUpgradeStrategy, total int) int {
+	if strategy.BatchSize == nil {
+		return 1
+	}
+
+	size, err := intstr.GetScaledValueFromIntOrPercent(strategy.BatchSize, total, true)
+	if err != nil || size < 1 {
+		return 1
+	}
+	return size
+}
+
+func pluginUpgradeStatus(fleet *fleetapi.Fleet, component string) *fleetapi.PluginUpgradeStatus {
+	for _, status := range fleet.Status.PluginUpgrades {
+		if status.Component == component {
+			return status
+		}
+	}
+	return nil
+}
+
+func setPluginUpgradeStatus(fleet *fleetapi.Fleet, status *fleetapi.PluginUpgradeStatus) {
+	for i, s := range fleet.Status.PluginUpgrades {
+		if s.Component == status.Component {
+			fleet.Status.PluginUpgrades[i] = status
+			return
+		}
+	}
+	fleet.Status.PluginUpgrades = append(fleet.Status.PluginUpgrades, status)
+}
+
+// clusterKeyNames returns the names of the clusters reported in the upgrade status.
+func clusterKeyNames(keys []ClusterKey) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.Name)
+	}
+	return names
+}
+
+func helmReleaseOf(resources kube.ResourceList) *resource.Info {
+	for _, res := range resources {
+		if res.Mapping.GroupVersionKind.Kind == hrapiv2b1.HelmReleaseKind {
+			return res
+		}
+	}
+	return nil
+}
+
+func componentOf(obj runtime.Object) string {
+	accessor, err := apimeta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetLabels()[FleetComponentName]
+}
+
+func chartVersionOf(obj runtime.Object) string {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+	version, _, _ := unstructured.NestedString(u, "spec", "chart", "spec", "version")
+	return version
+}
@@ -8,6 +8,9 @@ metadata:
     fleet.kurator.dev/name: "{{ .Fleet.Name }}"
     fleet.kurator.dev/plugin: "{{ .Name }}"
     fleet.kurator.dev/component: "{{ .Component }}"
+{{- if .Cluster }}
+    fleet.kurator.dev/cluster: "{{ .Cluster.Name }}"
+{{- end }}
 {{- if .OwnerReference }}
   ownerReferences:
   - apiVersion: "{{ .OwnerReference.APIVersion }}"
@@ -30,6 +33,9 @@ metadata:
     fleet.kurator.dev/name: "{{ .Fleet.Name }}"
     fleet.kurator.dev/plugin: "{{ .Name }}"
     fleet.kurator.dev/component: "{{ .Component }}"
+{{- if .Cluster }}
+    fleet.kurator.dev/cluster: "{{ .Cluster.Name }}"
+{{- end }}
 {{- if .OwnerReference }}
   ownerReferences:
   - apiVersion: "{{ .OwnerReference.APIVersion }}"
@@ -17,16 +17,25 @@ limitations under the License.
 package plugin
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"net/url"
 	"strings"
+	texttemplate "text/template"
 
+	"github.com/Masterminds/sprig/v3"
+	flaggerv1b1 "github.com/fluxcd/flagger/pkg/apis/flagger/v1beta1"
 	"github.com/fluxcd/pkg/runtime/transform"
 	sourcev1b2 "github.com/fluxcd/source-controller/api/v1beta2"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 
 	fleetv1a1 "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 )
@@ -42,6 +51,7 @@ const (
 	PublicTestloaderName         = "testloader"
 	SubMarinerBrokerPluginName   = "submariner-broker"
 	SubMarinerOperatorPluginName = "submariner-operator"
+	CustomPluginName             = "custom"
 
 	ThanosComponentName             = "thanos"
 	PrometheusComponentName         = "prometheus"
@@ -57,12 +67,26 @@ const (
 	SubMarinerOperatorComponentName = "sm-operator"
 
 	OCIReposiotryPrefix = "oci://"
+
+	// ThanosObjStoreConfigKey is the key of the thanos object store configuration in the objstore secret.
+	ThanosObjStoreConfigKey = "objstore.yml"
+	// ThanosObjStoreCAKey is the key of the CA bundle of the s3 compatible storage in the generated objstore secret.
+	ThanosObjStoreCAKey = "ca.crt"
+
+	// GatewayAPIMeshProvider is the provider name of Gateway API known by flagger.
+	GatewayAPIMeshProvider = flaggerv1b1.GatewayAPIProvider + ":v1beta1"
+
+	thanosObjStoreCAPath      = "/etc/thanos/objstore-ca"
+	thanosObjStoreCAVolume    = "objstore-ca"
+	defaultS3CompatibleRegion = "us-east-1"
 )
 
 var ProviderNamespace = map[fleetv1a1.Provider]string{
 	"istio": "istio-system",
 	"kuma":  "kuma-system",
 	"nginx": "ingress-nginx",
+	// Gateway API has no namespace of its own, flagger is installed in the namespace recommended by flagger.
+	"gatewayapi": "flagger-system",
 }
 
 type GrafanaDataSource struct {
@@ -110,6 +134,10 @@ func RenderKyverno(fsys fs.FS, fleetNN types.NamespacedName, fleetRef *metav1.Ow
 	if err != nil {
 		return nil, err
 	}
+	values, err = mergeClusterOverrides(values, kyvernoCfg.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	return renderFleetPlugin(fsys, FleetPluginConfig{
 		Name:           KyvernoPluginName,
@@ -175,13 +203,25 @@ func RenderThanos(fsys fs.FS, fleetNN types.NamespacedName, fleetRef *metav1.Own
 	}
 
 	values = transform.MergeMaps(values, map[string]interface{}{
-		"existingObjstoreSecret": metricCfg.Thanos.ObjectStoreConfig.SecretName, // always use secret from API
+		"existingObjstoreSecret": ThanosObjStoreSecretName(fleetNN.Name, metricCfg.Thanos.ObjectStoreConfig), // always use secret from API
 		"query": map[string]interface{}{
 			"dnsDiscovery": map[string]interface{}{
 				"sidecarsNamespace": fleetNN.Namespace, // override default namespace
 			},
 		},
 	})
+	if hasThanosObjStoreCA(metricCfg.Thanos.ObjectStoreConfig) {
+		// mount the CA bundle to the components accessing the object storage
+		secretName := ThanosObjStoreSecretName(fleetNN.Name, metricCfg.Thanos.ObjectStoreConfig)
+		for _, component := range []string{"storegateway", "compactor", "bucketweb", "ruler"} {
+			if err := appendValue(values, thanosObjStoreCAVolumeValue(secretName), component, "extraVolumes"); err != nil {
+				return nil, err
+			}
+			if err := appendValue(values, thanosObjStoreCAVolumeMountValue(), component, "extraVolumeMounts"); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	thanosCfg := FleetPluginConfig{
 		Name:           MetricPluginName,
@@ -195,6 +235,85 @@ func RenderThanos(fsys fs.FS, fleetNN types.NamespacedName, fleetRef *metav1.Own
 	return renderFleetPlugin(fsys, thanosCfg)
 }
 
+// ThanosObjStoreSecretName returns the name of the secret holding the thanos object store configuration,
+// which is generated by the fleet manager for the s3 compatible storage.
+func ThanosObjStoreSecretName(fleetName string, cfg fleetv1a1.ObjectStoreConfig) string {
+	if cfg.S3Compatible == nil {
+		return cfg.SecretName
+	}
+	return GeneratedThanosObjStoreSecretName(fleetName)
+}
+
+// GeneratedThanosObjStoreSecretName returns the name of the objstore secret generated for the s3 compatible storage.
+func GeneratedThanosObjStoreSecretName(fleetName string) string {
+	return fleetName + "-thanos-objstore"
+}
+
+// RenderThanosObjStoreConfig renders the thanos object store configuration of the s3 compatible storage,
+// see https://thanos.io/tip/thanos/storage.md/#s3
+func RenderThanosObjStoreConfig(store *fleetv1a1.S3CompatibleObjectStore, accessKey, secretKey string) ([]byte, error) {
+	u, err := url.Parse(store.Endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q of the s3 compatible storage", store.Endpoint)
+	}
+
+	region := store.Region
+	if region == "" {
+		region = defaultS3CompatibleRegion
+	}
+	bucketLookupType := "path"
+	if store.PathStyle != nil && !*store.PathStyle {
+		bucketLookupType = "virtual-hosted"
+	}
+
+	tlsConfig := map[string]interface{}{
+		"insecure_skip_verify": store.InsecureSkipTLSVerify,
+	}
+	if len(store.CABundle) != 0 {
+		tlsConfig["ca_file"] = thanosObjStoreCAPath + "/" + ThanosObjStoreCAKey
+	}
+
+	return yaml.Marshal(map[string]interface{}{
+		"type": "S3",
+		"config": map[string]interface{}{
+			"bucket":             store.Bucket,
+			"endpoint":           u.Host,
+			"region":             region,
+			"access_key":         accessKey,
+			"secret_key":         secretKey,
+			"insecure":           u.Scheme == "http",
+			"bucket_lookup_type": bucketLookupType,
+			"http_config": map[string]interface{}{
+				"tls_config": tlsConfig,
+			},
+		},
+	})
+}
+
+func hasThanosObjStoreCA(cfg fleetv1a1.ObjectStoreConfig) bool {
+	return cfg.S3Compatible != nil && len(cfg.S3Compatible.CABundle) != 0
+}
+
+func thanosObjStoreCAVolumeValue(secretName string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": thanosObjStoreCAVolume,
+		"secret": map[string]interface{}{
+			"secretName": secretName,
+			"items": []interface{}{
+				map[string]interface{}{"key": ThanosObjStoreCAKey, "path": ThanosObjStoreCAKey},
+			},
+		},
+	}
+}
+
+func thanosObjStoreCAVolumeMountValue() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      thanosObjStoreCAVolume,
+		"mountPath": thanosObjStoreCAPath,
+		"readOnly":  true,
+	}
+}
+
 func RenderPrometheus(fsys fs.FS, fleetName types.NamespacedName, fleetRef *metav1.OwnerReference, cluster KubeConfigSecretRef, metricCfg *fleetv1a1.MetricConfig) ([]byte, error) {
 	promChart, err := getFleetPluginChart(fsys, PrometheusComponentName)
 	if err != nil {
@@ -215,11 +334,26 @@ func RenderPrometheus(fsys fs.FS, fleetName types.NamespacedName, fleetRef *meta
 			},
 			"thanos": map[string]interface{}{
 				"objectStorageConfig": map[string]interface{}{
-					"secretName": metricCfg.Thanos.ObjectStoreConfig.SecretName,
+					"secretName": ThanosObjStoreSecretName(fleetName.Name, metricCfg.Thanos.ObjectStoreConfig),
 				},
 			},
 		},
 	})
+	if hasThanosObjStoreCA(metricCfg.Thanos.ObjectStoreConfig) {
+		// mount the CA bundle to the thanos sidecar uploading the blocks
+		secretName := ThanosObjStoreSecretName(fleetName.Name, metricCfg.Thanos.ObjectStoreConfig)
+		if err := appendValue(values, thanosObjStoreCAVolumeValue(secretName), "prometheus", "volumes"); err != nil {
+			return nil, err
+		}
+		if err := appendValue(values, thanosObjStoreCAVolumeMountValue(), "prometheus", "thanos", "extraVolumeMounts"); err != nil {
+			return nil, err
+		}
+	}
+
+	values, err = mergeClusterOverrides(values, metricCfg.Prometheus.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	promCfg := FleetPluginConfig{
 		Name:           MetricPluginName,
@@ -234,10 +368,18 @@ func RenderPrometheus(fsys fs.FS, fleetName types.NamespacedName, fleetRef *meta
 	return renderFleetPlugin(fsys, promCfg)
 }
 
+type veleroVolumeSnapshotLocation struct {
+	Name     string                 `json:"name"`
+	Provider string                 `json:"provider"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+}
+
 type veleroObjectStoreLocation struct {
 	Bucket   string                 `json:"bucket"`
 	Provider string                 `json:"provider"`
 	Config   map[string]interface{} `json:"config"`
+	// CACert is the base64 encoded CA bundle of the object storage
+	CACert string `json:"caCert,omitempty"`
 }
 
 func RenderVelero(
@@ -267,14 +409,7 @@ func RenderVelero(
 
 	// get custom values
 	customValues := map[string]interface{}{}
-	locationConfig := stringMapToInterfaceMap(backupCfg.Storage.Location.Config)
-	// generate velero config. "backupCfg.Storage.Location.Endpoint" and "backupCfg.Storage.Location.Region" will overwrite the value of "backupCfg.Storage.Location.config"
-	// because "backupCfg.Storage.Location.config" is optional, it should take effect only when current setting is not enough.
-	Config := transform.MergeMaps(locationConfig, map[string]interface{}{
-		"s3Url":            backupCfg.Storage.Location.Endpoint,
-		"region":           backupCfg.Storage.Location.Region,
-		"s3ForcePathStyle": true,
-	})
+	Config := buildVeleroLocationConfig(backupCfg.Storage.Location)
 	provider := getProviderFrombackupCfg(backupCfg)
 	configurationValues := map[string]interface{}{
 		"configuration": map[string]interface{}{
@@ -283,6 +418,7 @@ func RenderVelero(
 					Bucket:   backupCfg.Storage.Location.Bucket,
 					Provider: provider,
 					Config:   Config,
+					CACert:   veleroCACert(backupCfg.Storage.Location.S3Compatible),
 				},
 			},
 		},
@@ -291,6 +427,10 @@ func RenderVelero(
 			"existingSecret": veleroSecretName,
 		},
 	}
+	if len(backupCfg.VolumeSnapshotLocations) != 0 {
+		configurationValues["configuration"].(map[string]interface{})["volumeSnapshotLocation"] = buildVeleroVolumeSnapshotLocations(backupCfg.VolumeSnapshotLocations)
+		configurationValues["snapshotsEnabled"] = true
+	}
 	// add custom configurationValues to customValues
 	customValues = transform.MergeMaps(customValues, configurationValues)
 	extraValues, err := toMap(backupCfg.ExtraArgs)
@@ -302,6 +442,10 @@ func RenderVelero(
 
 	// replace the default values with custom values to obtain the actual values.
 	values := transform.MergeMaps(defaultValues, customValues)
+	values, err = mergeClusterOverrides(values, backupCfg.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	return renderFleetPlugin(fsys, FleetPluginConfig{
 		Name:           BackupPluginName,
@@ -333,6 +477,10 @@ func RenderStorageOperator(
 	if err != nil {
 		return nil, err
 	}
+	values, err = mergeClusterOverrides(values, distributedStorageCfg.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	return renderFleetPlugin(fsys, FleetPluginConfig{
 		Name:           StorageOperatorPluginName,
@@ -375,6 +523,10 @@ func RenderClusterStorage(
 	cephClusterValue = transform.MergeMaps(cephClusterValue, extraValues)
 	// Replace the default values with custom values to obtain the actual values.
 	values := transform.MergeMaps(defaultValues, cephClusterValue)
+	values, err = mergeClusterOverrides(values, distributedStorageCfg.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	return renderFleetPlugin(fsys, FleetPluginConfig{
 		Name:           ClusterStoragePluginName,
@@ -403,14 +555,23 @@ func RenderFlagger(
 	c.TargetNamespace = ProviderNamespace[flaggerConfig.TrafficRoutingProvider]
 
 	values, err := toMap(flaggerConfig.ExtraArgs)
-	if flaggerConfig.TrafficRoutingProvider == fleetv1a1.Nginx {
+	if err != nil {
+		return nil, err
+	}
+	switch flaggerConfig.TrafficRoutingProvider {
+	case fleetv1a1.Nginx:
 		values = transform.MergeMaps(values, map[string]interface{}{
 			"prometheus": map[string]interface{}{
 				"install": true,
 			},
 			"meshProvider": "nginx",
 		})
+	case fleetv1a1.GatewayAPI:
+		values = transform.MergeMaps(values, map[string]interface{}{
+			"meshProvider": GatewayAPIMeshProvider,
+		})
 	}
+	values, err = mergeClusterOverrides(values, flaggerConfig.Overrides, cluster)
 	if err != nil {
 		return nil, err
 	}
@@ -555,6 +716,10 @@ func RenderSubmarinerOperator(
 			"globalCidr":  globalCidr,
 		},
 	})
+	values, err = mergeClusterOverrides(values, subMarinerOperatorConfig.Overrides, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	return renderFleetPlugin(fsys, FleetPluginConfig{
 		Name:           SubMarinerOperatorPluginName,
@@ -567,6 +732,89 @@ func RenderSubmarinerOperator(
 	})
 }
 
+// RenderCustom renders the helm chart of a custom plugin, cluster is nil for the fleet scoped plugin.
+func RenderCustom(
+	fsys fs.FS,
+	fleetNN types.NamespacedName,
+	fleetRef *metav1.OwnerReference,
+	cluster *KubeConfigSecretRef,
+	customCfg *fleetv1a1.CustomPluginConfig,
+) ([]byte, error) {
+	c := &ChartConfig{}
+	mergeChartConfig(c, &customCfg.Chart)
+	c.TargetNamespace = customCfg.TargetNamespace
+	if c.TargetNamespace == "" {
+		c.TargetNamespace = customCfg.Name
+	}
+
+	values, err := renderCustomValues(customCfg, fleetNN, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render values of custom plugin %s: %w", customCfg.Name, err)
+	}
+	if cluster != nil {
+		values, err = mergeClusterOverrides(values, customCfg.Overrides, *cluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return renderFleetPlugin(fsys, FleetPluginConfig{
+		Name:           CustomPluginName,
+		Component:      customCfg.Name,
+		Fleet:          fleetNN,
+		Cluster:        cluster,
+		OwnerReference: fleetRef,
+		Chart:          *c,
+		Values:         values,
+	})
+}
+
+// customValuesCluster is the cluster exposed to the values template of a custom plugin,
+// the kubeconfig secret of the cluster is not exposed as the chart values never need it.
+type customValuesCluster struct {
+	Name   string
+	Labels map[string]string
+}
+
+// renderCustomValues renders the values template of a custom plugin.
+// The template is written by the fleet author, so only the hermetic sprig functions are supported,
+// e.g. `env` and `expandenv` are not, which would expose the environment of the fleet manager.
+func renderCustomValues(customCfg *fleetv1a1.CustomPluginConfig, fleetNN types.NamespacedName, cluster *KubeConfigSecretRef) (map[string]interface{}, error) {
+	if customCfg.ValuesTemplate == "" {
+		return nil, nil
+	}
+
+	tpl, err := texttemplate.New(customCfg.Name).Funcs(sprig.HermeticTxtFuncMap()).Option("missingkey=error").Parse(customCfg.ValuesTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var valuesCluster *customValuesCluster
+	if cluster != nil {
+		valuesCluster = &customValuesCluster{
+			Name:   cluster.Name,
+			Labels: cluster.Labels,
+		}
+	}
+
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, struct {
+		Fleet   types.NamespacedName
+		Cluster *customValuesCluster
+	}{
+		Fleet:   fleetNN,
+		Cluster: valuesCluster,
+	}); err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(b.Bytes(), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // According to distributedStorageCfg, generate the configuration for rook-ceph
 func buildStorageClusterValue(distributedStorageCfg fleetv1a1.DistributedStorageConfig) map[string]interface{} {
 	customValues := make(map[string]interface{})
@@ -683,6 +931,49 @@ func mergeChartConfig(origin *ChartConfig, target *fleetv1a1.ChartConfig) {
 	}
 }
 
+// mergeClusterOverrides deep merges the values of the overrides selecting the cluster into the values in order,
+// so the latter overrides take precedence over the former ones.
+func mergeClusterOverrides(values map[string]interface{}, overrides []*fleetv1a1.ClusterOverride, cluster KubeConfigSecretRef) (map[string]interface{}, error) {
+	for i, o := range overrides {
+		selected, err := isOverrideSelected(o, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override %d: %w", i, err)
+		}
+		if !selected {
+			continue
+		}
+
+		overrideValues, err := toMap(o.Values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values of override %d: %w", i, err)
+		}
+		values = transform.MergeMaps(values, overrideValues)
+	}
+
+	return values, nil
+}
+
+func isOverrideSelected(o *fleetv1a1.ClusterOverride, cluster KubeConfigSecretRef) (bool, error) {
+	if o.ClusterName == "" && o.ClusterSelector == nil {
+		return false, fmt.Errorf("either clusterName or clusterSelector must be specified")
+	}
+
+	if o.ClusterName != "" && o.ClusterName != cluster.Name {
+		return false, nil
+	}
+	if o.ClusterSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(o.ClusterSelector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(cluster.Labels)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func toMap(args apiextensionsv1.JSON) (map[string]interface{}, error) {
 	if args.Raw == nil {
 		return nil, nil
@@ -696,6 +987,16 @@ func toMap(args apiextensionsv1.JSON) (map[string]interface{}, error) {
 	return m, nil
 }
 
+// appendValue appends the item to the list of the values at the given path,
+// so that the items set by the user are kept.
+func appendValue(values map[string]interface{}, item interface{}, fields ...string) error {
+	list, _, err := unstructured.NestedSlice(values, fields...)
+	if err != nil {
+		return fmt.Errorf("invalid value of %s: %w", strings.Join(fields, "."), err)
+	}
+	return unstructured.SetNestedSlice(values, append(list, item), fields...)
+}
+
 func stringMapToInterfaceMap(args map[string]string) map[string]interface{} {
 	m := make(map[string]interface{})
 	for s, s2 := range args {
@@ -706,7 +1007,7 @@ func stringMapToInterfaceMap(args map[string]string) map[string]interface{} {
 }
 
 // getProviderValues return the map that stores default configurations associated with the specific provider.
-// The provider parameter can be one of the following values: "aws", "huaweicloud", "gcp", "azure".
+// The provider parameter can be one of the following values: "aws", "huaweicloud", "gcp", "azure", "s3compatible".
 func getProviderValues(provider string) (map[string]interface{}, error) {
 	switch provider {
 	case "aws":
@@ -717,6 +1018,8 @@ func getProviderValues(provider string) (map[string]interface{}, error) {
 		return buildGCPProviderValues(), nil
 	case "azure":
 		return buildAzureProviderValues(), nil
+	case "s3compatible":
+		return buildAWSProviderValues(), nil
 	default:
 		return nil, fmt.Errorf("unknown objStoreProvider: %v", provider)
 	}
@@ -724,14 +1027,33 @@ func getProviderValues(provider string) (map[string]interface{}, error) {
 
 // buildAWSProviderValues constructs the default provider values for AWS.
 func buildAWSProviderValues() map[string]interface{} {
+	return buildVeleroPluginValues("velero-plugin-for-aws", "velero/velero-plugin-for-aws:v1.7.1")
+}
+
+func buildHuaWeiCloudProviderValues() map[string]interface{} {
+	return buildAWSProviderValues()
+}
+
+// buildGCPProviderValues constructs the default provider values for GCP.
+func buildGCPProviderValues() map[string]interface{} {
+	return buildVeleroPluginValues("velero-plugin-for-gcp", "velero/velero-plugin-for-gcp:v1.7.1")
+}
+
+// buildAzureProviderValues constructs the default provider values for Azure.
+func buildAzureProviderValues() map[string]interface{} {
+	return buildVeleroPluginValues("velero-plugin-for-microsoft-azure", "velero/velero-plugin-for-microsoft-azure:v1.7.1")
+}
+
+// buildVeleroPluginValues constructs the values installing the velero plugin of the provider.
+func buildVeleroPluginValues(name, image string) map[string]interface{} {
 	values := map[string]interface{}{}
 
 	// currently, the default provider-related extra configuration only sets up initContainers
 	initContainersConfig := map[string]interface{}{
 		"initContainers": []interface{}{
 			map[string]interface{}{
-				"image": "velero/velero-plugin-for-aws:v1.7.1",
-				"name":  "velero-plugin-for-aws",
+				"image": image,
+				"name":  name,
 				"volumeMounts": []interface{}{
 					map[string]interface{}{
 						"mountPath": "/target",
@@ -746,22 +1068,64 @@ func buildAWSProviderValues() map[string]interface{} {
 	return values
 }
 
-func buildHuaWeiCloudProviderValues() map[string]interface{} {
-	return buildAWSProviderValues()
+// buildVeleroLocationConfig constructs the config of the velero backup storage location.
+func buildVeleroLocationConfig(location fleetv1a1.BackupStorageLocation) map[string]interface{} {
+	locationConfig := stringMapToInterfaceMap(location.Config)
+	switch location.Provider {
+	case "gcp", "azure":
+		// GCS and Azure Blob are not s3 compatible, the location is only configured by the bucket and the config,
+		// e.g. "resourceGroup" and "storageAccount" for Azure.
+		return locationConfig
+	default:
+		region := location.Region
+		if region == "" && location.Provider == "s3compatible" {
+			// the region is required by the velero aws plugin, which is usually ignored by the s3 compatible storage
+			region = defaultS3CompatibleRegion
+		}
+		// "location.Endpoint" and "location.Region" will overwrite the value of "location.Config"
+		// because "location.Config" is optional, it should take effect only when current setting is not enough.
+		config := transform.MergeMaps(locationConfig, map[string]interface{}{
+			"s3Url":            location.Endpoint,
+			"region":           region,
+			"s3ForcePathStyle": true,
+		})
+		if s3 := location.S3Compatible; s3 != nil {
+			if s3.PathStyle != nil {
+				config["s3ForcePathStyle"] = *s3.PathStyle
+			}
+			if s3.InsecureSkipTLSVerify {
+				config["insecureSkipTLSVerify"] = "true"
+			}
+		}
+		return config
+	}
 }
 
-// TODO： accomplish those function after investigation
-func buildGCPProviderValues() map[string]interface{} {
-	return nil
+// buildVeleroVolumeSnapshotLocations constructs the velero volume snapshot locations.
+func buildVeleroVolumeSnapshotLocations(locations []*fleetv1a1.VolumeSnapshotLocation) []veleroVolumeSnapshotLocation {
+	result := make([]veleroVolumeSnapshotLocation, 0, len(locations))
+	for _, location := range locations {
+		result = append(result, veleroVolumeSnapshotLocation{
+			Name:     location.Name,
+			Provider: location.Provider,
+			Config:   stringMapToInterfaceMap(location.Config),
+		})
+	}
+	return result
 }
-func buildAzureProviderValues() map[string]interface{} {
-	return nil
+
+// veleroCACert returns the base64 encoded CA bundle of the backup storage location.
+func veleroCACert(s3 *fleetv1a1.S3CompatibleConfig) string {
+	if s3 == nil || len(s3.CABundle) == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(s3.CABundle)
 }
 
 func getProviderFrombackupCfg(backupCfg *fleetv1a1.BackupConfig) string {
 	provider := backupCfg.Storage.Location.Provider
-	// there no "huaweicloud" provider in velero
-	if provider == "huaweicloud" {
+	// there no "huaweicloud" or "s3compatible" provider in velero, both of them are accessed by the aws plugin
+	if provider == "huaweicloud" || provider == "s3compatible" {
 		provider = "aws"
 	}
 	return provider
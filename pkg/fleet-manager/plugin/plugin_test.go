@@ -217,6 +217,51 @@ func TestRenderThanos(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "s3compatible",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			in: &v1alpha1.MetricConfig{
+				Thanos: v1alpha1.ThanosConfig{
+					ObjectStoreConfig: v1alpha1.ObjectStoreConfig{
+						SecretName: "minio-credentials",
+						S3Compatible: &v1alpha1.S3CompatibleObjectStore{
+							Bucket:   "thanos",
+							Endpoint: "https://minio.minio:9000",
+							S3CompatibleConfig: v1alpha1.S3CompatibleConfig{
+								CABundle: []byte("ca"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "s3compatible-with-volumes",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			in: &v1alpha1.MetricConfig{
+				Thanos: v1alpha1.ThanosConfig{
+					ObjectStoreConfig: v1alpha1.ObjectStoreConfig{
+						SecretName: "minio-credentials",
+						S3Compatible: &v1alpha1.S3CompatibleObjectStore{
+							Bucket:   "thanos",
+							Endpoint: "https://minio.minio:9000",
+							S3CompatibleConfig: v1alpha1.S3CompatibleConfig{
+								CABundle: []byte("ca"),
+							},
+						},
+					},
+					ExtraArgs: apiextensionsv1.JSON{
+						Raw: []byte(`{"storegateway":{"extraVolumes":[{"name":"cache","emptyDir":{}}],"extraVolumeMounts":[{"name":"cache","mountPath":"/cache"}]}}`),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -267,6 +312,53 @@ func TestRenderPrometheus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "s3compatible",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			in: &v1alpha1.MetricConfig{
+				Thanos: v1alpha1.ThanosConfig{
+					ObjectStoreConfig: v1alpha1.ObjectStoreConfig{
+						SecretName: "minio-credentials",
+						S3Compatible: &v1alpha1.S3CompatibleObjectStore{
+							Bucket:   "thanos",
+							Endpoint: "https://minio.minio:9000",
+							S3CompatibleConfig: v1alpha1.S3CompatibleConfig{
+								CABundle: []byte("ca"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "s3compatible-with-volumes",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			in: &v1alpha1.MetricConfig{
+				Prometheus: v1alpha1.PrometheusConfig{
+					ExtraArgs: apiextensionsv1.JSON{
+						Raw: []byte(`{"prometheus":{"volumes":[{"name":"certs","emptyDir":{}}],"thanos":{"extraVolumeMounts":[{"name":"certs","mountPath":"/certs"}]}}}`),
+					},
+				},
+				Thanos: v1alpha1.ThanosConfig{
+					ObjectStoreConfig: v1alpha1.ObjectStoreConfig{
+						SecretName: "minio-credentials",
+						S3Compatible: &v1alpha1.S3CompatibleObjectStore{
+							Bucket:   "thanos",
+							Endpoint: "https://minio.minio:9000",
+							S3CompatibleConfig: v1alpha1.S3CompatibleConfig{
+								CABundle: []byte("ca"),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -379,6 +471,118 @@ func TestRenderVelero(t *testing.T) {
 			},
 			newSecretName: "kurator-velero-obs",
 		},
+		{
+			name: "gcp",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			ref: &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			},
+			in: &v1alpha1.BackupConfig{
+				Storage: v1alpha1.BackupStorage{
+					Location: v1alpha1.BackupStorageLocation{
+						Bucket:   "kurator-backup",
+						Provider: "gcp",
+					},
+					SecretName: "backup-secret",
+				},
+			},
+			newSecretName: "kurator-velero-gcs",
+		},
+		{
+			name: "azure",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			ref: &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			},
+			in: &v1alpha1.BackupConfig{
+				Storage: v1alpha1.BackupStorage{
+					Location: v1alpha1.BackupStorageLocation{
+						Bucket:   "kurator-backup",
+						Provider: "azure",
+						Config: map[string]string{
+							"resourceGroup":  "kurator-backup-rg",
+							"storageAccount": "kuratorbackup",
+						},
+					},
+					SecretName: "backup-secret",
+				},
+			},
+			newSecretName: "kurator-velero-abs",
+		},
+		{
+			name: "s3compatible",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			ref: &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			},
+			in: &v1alpha1.BackupConfig{
+				Storage: v1alpha1.BackupStorage{
+					Location: v1alpha1.BackupStorageLocation{
+						Bucket:   "velero",
+						Provider: "s3compatible",
+						Endpoint: "https://minio.minio:9000",
+						S3Compatible: &v1alpha1.S3CompatibleConfig{
+							CABundle:              []byte("ca"),
+							InsecureSkipTLSVerify: true,
+						},
+					},
+					SecretName: "backup-secret",
+				},
+			},
+			newSecretName: "kurator-velero-s3",
+		},
+		{
+			name: "volume-snapshot",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			ref: &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			},
+			in: &v1alpha1.BackupConfig{
+				Storage: v1alpha1.BackupStorage{
+					Location: v1alpha1.BackupStorageLocation{
+						Bucket:   "velero",
+						Provider: "aws",
+						Endpoint: "https://s3.us-east-1.amazonaws.com",
+						Region:   "us-east-1",
+					},
+					SecretName: "backup-secret",
+				},
+				VolumeSnapshotLocations: []*v1alpha1.VolumeSnapshotLocation{
+					{
+						Name:     "ebs",
+						Provider: "aws",
+						Config: map[string]string{
+							"region": "us-east-1",
+						},
+					},
+				},
+			},
+			newSecretName: "kurator-velero-s3",
+		},
 	}
 
 	for _, tc := range cases {
@@ -583,6 +787,22 @@ func TestRendeFlagger(t *testing.T) {
 				TrafficRoutingProvider: v1alpha1.Istio,
 			},
 		},
+		{
+			name: "gatewayapi",
+			fleet: types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			},
+			ref: &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			},
+			config: &v1alpha1.FlaggerConfig{
+				TrafficRoutingProvider: v1alpha1.GatewayAPI,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -739,3 +959,253 @@ func TestRenderSubmarinerOperator(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCustom(t *testing.T) {
+	cases := []struct {
+		name    string
+		cluster *KubeConfigSecretRef
+		config  *v1alpha1.CustomPluginConfig
+	}{
+		{
+			name: "default",
+			cluster: &KubeConfigSecretRef{
+				Name:       "cluster1",
+				SecretName: "cluster1",
+				SecretKey:  "kubeconfig.yaml",
+			},
+			config: &v1alpha1.CustomPluginConfig{
+				Name: "external-dns",
+				Chart: v1alpha1.ChartConfig{
+					Repository: "https://kubernetes-sigs.github.io/external-dns",
+					Name:       "external-dns",
+					Version:    "1.14.3",
+				},
+				ValuesTemplate: "txtOwnerId: {{ .Fleet.Name }}-{{ .Cluster.Name }}\nprovider: coredns\n",
+			},
+		},
+		{
+			name: "fleet-scoped",
+			config: &v1alpha1.CustomPluginConfig{
+				Name: "cert-manager",
+				Chart: v1alpha1.ChartConfig{
+					Repository: "oci://registry-1.docker.io/bitnamicharts",
+					Name:       "cert-manager",
+					Version:    "1.1.0",
+				},
+				TargetNamespace: "cert-manager-system",
+				FleetScoped:     true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderCustom(manifestFS, types.NamespacedName{
+				Name:      "fleet-1",
+				Namespace: "default",
+			}, &metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "Fleet",
+				Name:       "fleet-1",
+				UID:        "xxxxxx",
+			}, tc.cluster, tc.config)
+			assert.NoError(t, err)
+
+			expected, err := getExpected("custom", tc.name)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(got))
+		})
+	}
+}
+
+func TestRenderCustomValues(t *testing.T) {
+	fleetNN := types.NamespacedName{Name: "fleet-1", Namespace: "default"}
+	cluster := &KubeConfigSecretRef{
+		Name:       "cluster1",
+		SecretName: "cluster1-kubeconfig",
+		SecretKey:  "kubeconfig.yaml",
+		Labels:     map[string]string{"region": "eu"},
+	}
+
+	cases := []struct {
+		name      string
+		template  string
+		expected  map[string]interface{}
+		expectErr bool
+	}{
+		{
+			name:     "fleet and cluster",
+			template: "txtOwnerId: {{ .Fleet.Name }}-{{ .Cluster.Name }}\nregion: {{ .Cluster.Labels.region | upper }}\n",
+			expected: map[string]interface{}{"txtOwnerId": "fleet-1-cluster1", "region": "EU"},
+		},
+		{
+			name:      "environment is not exposed",
+			template:  `home: {{ env "HOME" }}`,
+			expectErr: true,
+		},
+		{
+			name:      "environment is not expanded",
+			template:  `home: {{ expandenv "$HOME" }}`,
+			expectErr: true,
+		},
+		{
+			name:      "kubeconfig secret is not exposed",
+			template:  "secret: {{ .Cluster.SecretName }}",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := renderCustomValues(&v1alpha1.CustomPluginConfig{Name: "external-dns", ValuesTemplate: tc.template}, fleetNN, cluster)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestMergeClusterOverrides(t *testing.T) {
+	overrides := []*v1alpha1.ClusterOverride{
+		{
+			ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "edge"}},
+			Values:          apiextensionsv1.JSON{Raw: []byte(`{"prometheus":{"retention":"2d","replicaCount":1}}`)},
+		},
+		{
+			ClusterName: "edge1",
+			Values:      apiextensionsv1.JSON{Raw: []byte(`{"prometheus":{"retention":"1d"}}`)},
+		},
+	}
+	values := map[string]interface{}{
+		"prometheus": map[string]interface{}{
+			"retention":    "10d",
+			"replicaCount": 2,
+			"externalLabels": map[string]interface{}{
+				"cluster": "edge1",
+			},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		cluster  KubeConfigSecretRef
+		expected map[string]interface{}
+	}{
+		{
+			name:     "no override selected",
+			cluster:  KubeConfigSecretRef{Name: "core1", Labels: map[string]string{"tier": "core"}},
+			expected: values,
+		},
+		{
+			name:    "selected by labels",
+			cluster: KubeConfigSecretRef{Name: "edge2", Labels: map[string]string{"tier": "edge"}},
+			expected: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"retention":    "2d",
+					"replicaCount": float64(1),
+					"externalLabels": map[string]interface{}{
+						"cluster": "edge1",
+					},
+				},
+			},
+		},
+		{
+			name:    "selected by labels and name",
+			cluster: KubeConfigSecretRef{Name: "edge1", Labels: map[string]string{"tier": "edge"}},
+			expected: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"retention":    "1d",
+					"replicaCount": float64(1),
+					"externalLabels": map[string]interface{}{
+						"cluster": "edge1",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := mergeClusterOverrides(values, overrides, tc.cluster)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	_, err := mergeClusterOverrides(values, []*v1alpha1.ClusterOverride{{}}, KubeConfigSecretRef{Name: "core1"})
+	assert.Error(t, err)
+}
+
+func TestRenderThanosObjStoreConfig(t *testing.T) {
+	pathStyle := false
+	cases := []struct {
+		name      string
+		store     *v1alpha1.S3CompatibleObjectStore
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:  "default",
+			store: &v1alpha1.S3CompatibleObjectStore{Bucket: "thanos", Endpoint: "http://minio.minio:9000"},
+			expected: `config:
+  access_key: ak
+  bucket: thanos
+  bucket_lookup_type: path
+  endpoint: minio.minio:9000
+  http_config:
+    tls_config:
+      insecure_skip_verify: false
+  insecure: true
+  region: us-east-1
+  secret_key: sk
+type: S3
+`,
+		},
+		{
+			name: "tls",
+			store: &v1alpha1.S3CompatibleObjectStore{
+				Bucket:   "thanos",
+				Endpoint: "https://rgw.example.com",
+				Region:   "default",
+				S3CompatibleConfig: v1alpha1.S3CompatibleConfig{
+					PathStyle: &pathStyle,
+					CABundle:  []byte("ca"),
+				},
+			},
+			expected: `config:
+  access_key: ak
+  bucket: thanos
+  bucket_lookup_type: virtual-hosted
+  endpoint: rgw.example.com
+  http_config:
+    tls_config:
+      ca_file: /etc/thanos/objstore-ca/ca.crt
+      insecure_skip_verify: false
+  insecure: false
+  region: default
+  secret_key: sk
+type: S3
+`,
+		},
+		{
+			name:      "invalid endpoint",
+			store:     &v1alpha1.S3CompatibleObjectStore{Bucket: "thanos", Endpoint: "minio"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RenderThanosObjStoreConfig(tc.store, "ak", "sk")
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+}
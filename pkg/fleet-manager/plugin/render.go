@@ -59,6 +59,8 @@ type KubeConfigSecretRef struct {
 	Name       string
 	SecretName string
 	SecretKey  string
+	// Labels is the labels of the cluster, which are used to select the cluster overrides.
+	Labels map[string]string
 }
 
 type ChartConfig struct {
@@ -29,6 +29,10 @@ import (
 const (
 	FleetNameLabel  = "fleet.kurator.dev/name"
 	FleetPluginName = "fleet.kurator.dev/plugin"
+	// FleetComponentName and FleetClusterName are the labels of plugin resources
+	// indicating the plugin component and the cluster it is installed in.
+	FleetComponentName = "fleet.kurator.dev/component"
+	FleetClusterName   = "fleet.kurator.dev/cluster"
 
 	ManagedByLabel        = "app.kubernetes.io/managed-by"
 	ManagedByFleetManager = "fleet-manager"
@@ -35,23 +35,55 @@ import (
 )
 
 func PatchResources(b []byte) (kube.ResourceList, error) {
-	rest, err := ctrl.GetConfig()
+	target, err := BuildResources(b)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get kubeconfig")
+		return nil, err
 	}
-	c, err := client.NewClient(client.NewRESTClientGetter(rest))
+	if err := UpdateResources(target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// BuildResources builds the resources from the manifests without applying them.
+func BuildResources(b []byte) (kube.ResourceList, error) {
+	c, err := newClient()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create client")
+		return nil, err
 	}
 	target, err := c.HelmClient().Build(bytes.NewBuffer(b), false)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build resources: %s", string(b))
 	}
+
+	return target, nil
+}
+
+// UpdateResources creates or updates the resources built by BuildResources.
+func UpdateResources(target kube.ResourceList) error {
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
 	if _, err := c.HelmClient().Update(target, target, true); err != nil {
-		return nil, errors.Wrapf(err, "failed to update resources")
+		return errors.Wrapf(err, "failed to update resources")
 	}
 
-	return target, nil
+	return nil
+}
+
+func newClient() (*client.Client, error) {
+	rest, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get kubeconfig")
+	}
+	c, err := client.NewClient(client.NewRESTClientGetter(rest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client")
+	}
+
+	return c, nil
 }
 
 func GenerateUID(nn types.NamespacedName) string {
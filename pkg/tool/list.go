@@ -17,8 +17,6 @@ limitations under the License.
 package tool
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -213,7 +211,7 @@ func (t *toolListWriter) writeTable(out io.Writer) error {
 		table.AddRow(te.Name, te.Version, te.Status)
 	}
 
-	return t.encodeTable(out, table)
+	return EncodeTable(out, table)
 }
 
 func (t *toolListWriter) writeTableWIDE(out io.Writer) error {
@@ -224,17 +222,7 @@ func (t *toolListWriter) writeTableWIDE(out io.Writer) error {
 		table.AddRow(te.Name, te.Version, te.Status, te.Cli, te.Hub, te.ReleaseURLPrefix)
 	}
 
-	return t.encodeTable(out, table)
-}
-
-func (t *toolListWriter) encodeTable(out io.Writer, table *uitable.Table) error {
-	raw := table.Bytes()
-	raw = append(raw, []byte("\n")...)
-	_, err := out.Write(raw)
-	if err != nil {
-		return fmt.Errorf("unable to write table output. %v", err)
-	}
-	return nil
+	return EncodeTable(out, table)
 }
 
 func (t *toolListWriter) writeJSON(out io.Writer) error {
@@ -243,15 +231,7 @@ func (t *toolListWriter) writeJSON(out io.Writer) error {
 		return fmt.Errorf("yaml cannot convert json. %v", err)
 	}
 
-	var prettyJSON bytes.Buffer
-	if err := json.Indent(&prettyJSON, js, "", "\t"); err != nil {
-		return fmt.Errorf("can't format json. %v", err)
-	}
-
-	if _, err := prettyJSON.WriteTo(out); err != nil {
-		return fmt.Errorf("unable to write json output. %v", err)
-	}
-	return nil
+	return WriteJSON(out, js)
 }
 
 func (t *toolListWriter) writeYAML(out io.Writer) error {
@@ -0,0 +1,80 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"sigs.k8s.io/yaml"
+)
+
+// PrintObj prints the object in the output format. The table and wide formats are written by writeTable,
+// while the JSON and YAML formats are marshaled from obj.
+func PrintObj(out io.Writer, format string, obj interface{}, writeTable func(out io.Writer, wide bool) error) error {
+	switch strings.ToLower(format) {
+	case Table.String():
+		return writeTable(out, false)
+	case TableWIDE.String():
+		return writeTable(out, true)
+	case JSON.String():
+		js, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("can't convert json. %v", err)
+		}
+		return WriteJSON(out, js)
+	case YAML.String():
+		ys, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("can't convert yaml. %v", err)
+		}
+		if _, err := out.Write(ys); err != nil {
+			return fmt.Errorf("unable to write yaml output. %v", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("invalid format type")
+}
+
+// EncodeTable writes the table followed by a new line.
+func EncodeTable(out io.Writer, table *uitable.Table) error {
+	raw := table.Bytes()
+	raw = append(raw, []byte("\n")...)
+	if _, err := out.Write(raw); err != nil {
+		return fmt.Errorf("unable to write table output. %v", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the JSON document indented by tabs.
+func WriteJSON(out io.Writer, js []byte) error {
+	var prettyJSON bytes.Buffer
+	if err := json.Indent(&prettyJSON, js, "", "\t"); err != nil {
+		return fmt.Errorf("can't format json. %v", err)
+	}
+	prettyJSON.WriteString("\n")
+
+	if _, err := prettyJSON.WriteTo(out); err != nil {
+		return fmt.Errorf("unable to write json output. %v", err)
+	}
+	return nil
+}
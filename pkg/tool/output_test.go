@@ -0,0 +1,55 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestPrintObjFormats(t *testing.T) {
+	obj := map[string]string{"name": "istio"}
+	writeTable := func(out io.Writer, wide bool) error {
+		_, err := fmt.Fprintf(out, "table wide=%v\n", wide)
+		return err
+	}
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{format: "table", expected: "table wide=false\n"},
+		{format: "wide", expected: "table wide=true\n"},
+		{format: "JSON", expected: "{\n\t\"name\": \"istio\"\n}\n"},
+		{format: "yaml", expected: "name: istio\n"},
+	}
+	for _, tc := range cases {
+		out := &bytes.Buffer{}
+		if err := PrintObj(out, tc.format, obj, writeTable); err != nil {
+			t.Fatalf("format %s: %v", tc.format, err)
+		}
+		if out.String() != tc.expected {
+			t.Errorf("format %s: expected %q, got %q", tc.format, tc.expected, out.String())
+		}
+	}
+
+	if err := PrintObj(&bytes.Buffer{}, "xml", obj, writeTable); err == nil {
+		t.Error("expected an error of the invalid format")
+	}
+}
@@ -19,10 +19,12 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -63,6 +65,10 @@ func (wh *ApplicationWebhook) validate(in *v1alpha1.Application) error {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, validateFleet(in)...)
+	allErrs = append(allErrs, validatePolicyNames(in)...)
+	allErrs = append(allErrs, validateRolloutStrategy(in)...)
+	allErrs = append(allErrs, validateClusterOverrides(in)...)
+	allErrs = append(allErrs, validateReleaseStrategy(in)...)
 
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(v1alpha1.SchemeGroupVersion.WithKind("Application").GroupKind(), in.Name, allErrs)
@@ -120,6 +126,185 @@ func validateFleet(in *v1alpha1.Application) field.ErrorList {
 	return allErrs
 }
 
+// validatePolicyNames validates the names of the sync policies with the following rules:
+// 1 the names must be unique
+// 2 if there are multiple policies and the orphaned resources are deleted, every policy must set its name,
+// because the default name depends on the index of the policy, and removing or reordering the policies
+// would delete and recreate the resources of the following ones
+func validatePolicyNames(in *v1alpha1.Application) field.ErrorList {
+	var allErrs field.ErrorList
+
+	requireName := len(in.Spec.SyncPolicies) > 1 && in.Spec.OrphanPolicy != v1alpha1.OrphanPolicyOrphan
+	names := sets.New[string]()
+	for i, policy := range in.Spec.SyncPolicies {
+		fldPath := field.NewPath("spec", "syncPolicies").Index(i).Child("name")
+		if policy.Name == "" {
+			if requireName {
+				allErrs = append(allErrs, field.Required(fldPath, "must be set when there are multiple policies and orphanPolicy is not Orphan"))
+			}
+			continue
+		}
+		if names.Has(policy.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, policy.Name))
+		}
+		names.Insert(policy.Name)
+	}
+
+	return allErrs
+}
+
+// validateRolloutStrategy validates the rollout waves of the sync policies with the following rules:
+// 1 the waves are only supported when the policy is deployed to a fleet
+// 2 each wave must have a unique name, and must select clusters by clusterSelector or clusters
+func validateRolloutStrategy(in *v1alpha1.Application) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policy := range in.Spec.SyncPolicies {
+		if policy.RolloutStrategy == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "syncPolicies").Index(i).Child("rolloutStrategy")
+
+		destination := in.Spec.Destination
+		if policy.Destination != nil {
+			destination = policy.Destination
+		}
+		if destination == nil || destination.Fleet == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "syncPolicies").Index(i).Child("destination", "fleet"), "must be set when rolloutStrategy is set"))
+		}
+
+		if len(policy.RolloutStrategy.Waves) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("waves"), "at least one wave must be set"))
+		}
+		names := make(map[string]bool, len(policy.RolloutStrategy.Waves))
+		for j, wave := range policy.RolloutStrategy.Waves {
+			wavePath := fldPath.Child("waves").Index(j)
+			switch {
+			case wave.Name == "":
+				allErrs = append(allErrs, field.Required(wavePath.Child("name"), "must be set"))
+			case names[wave.Name]:
+				allErrs = append(allErrs, field.Duplicate(wavePath.Child("name"), wave.Name))
+			}
+			names[wave.Name] = true
+
+			if len(wave.Clusters) == 0 && (wave.ClusterSelector == nil || len(wave.ClusterSelector.MatchLabels) == 0) {
+				allErrs = append(allErrs, field.Required(wavePath, "either clusterSelector or clusters must be set"))
+			}
+		}
+
+		if policy.RolloutStrategy.WaveTimeout != nil && policy.RolloutStrategy.WaveTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("waveTimeout"), policy.RolloutStrategy.WaveTimeout.Duration.String(), "must be greater than 0"))
+		}
+	}
+
+	return allErrs
+}
+
+// substituteVariablePattern is the pattern of the variable names accepted by flux postBuild substitution.
+var substituteVariablePattern = regexp.MustCompile(`^[_[:alpha:]][_[:alpha:][:digit:]]*$`)
+
+// validateClusterOverrides validates the per-cluster customization of the sync policies with the following rules:
+// 1 the variable names of postBuild.substitute must be valid for flux
+// 2 each helm values overlay must select clusters by labels and set the values
+func validateClusterOverrides(in *v1alpha1.Application) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policy := range in.Spec.SyncPolicies {
+		fldPath := field.NewPath("spec", "syncPolicies").Index(i)
+
+		if policy.Kustomization != nil && policy.Kustomization.PostBuild != nil {
+			for name := range policy.Kustomization.PostBuild.Substitute {
+				if !substituteVariablePattern.MatchString(name) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("kustomization", "postBuild", "substitute").Key(name), name, fmt.Sprintf("variable name must match %s", substituteVariablePattern)))
+				}
+			}
+		}
+
+		if policy.Helm != nil {
+			for j, overlay := range policy.Helm.ValuesOverlays {
+				overlayPath := fldPath.Child("helm", "valuesOverlays").Index(j)
+				if len(overlay.ClusterSelector.MatchLabels) == 0 {
+					allErrs = append(allErrs, field.Required(overlayPath.Child("clusterSelector", "matchLabels"), "must be set to select the clusters"))
+				}
+				if overlay.Values == nil || len(overlay.Values.Raw) == 0 {
+					allErrs = append(allErrs, field.Required(overlayPath.Child("values"), "must be set"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// releaseStrategyProviders is the traffic routing providers supporting the release strategy,
+// a strategy not listed here is supported by all the providers.
+var releaseStrategyProviders = map[v1alpha1.ReleaseStrategy]map[fleetapi.Provider]bool{
+	v1alpha1.ABTestingReleaseStrategy: {fleetapi.Istio: true, fleetapi.Nginx: true, fleetapi.GatewayAPI: true},
+}
+
+// mirrorProviders is the traffic routing providers supporting traffic mirroring.
+var mirrorProviders = map[fleetapi.Provider]bool{fleetapi.Istio: true, fleetapi.GatewayAPI: true}
+
+// validateReleaseStrategy validates the release strategy of the rollouts with the following rules:
+// 1 if the strategy is set, it must be supported by the traffic routing provider
+// 2 mirror is only supported by BlueGreen strategy with the providers supporting mirroring
+// 3 if the strategy is set, trafficRouting must configure the fields of the strategy only:
+// canaryStrategy for Canary, analysisTimes for BlueGreen, analysisTimes and match for ABTesting
+func validateReleaseStrategy(in *v1alpha1.Application) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policy := range in.Spec.SyncPolicies {
+		rollout := policy.Rollout
+		if rollout == nil || rollout.RolloutPolicy == nil || rollout.RolloutPolicy.TrafficRouting == nil {
+			continue
+		}
+		fldPath := field.NewPath("spec", "syncPolicies").Index(i).Child("rollout")
+		trafficRoutingPath := fldPath.Child("rolloutPolicy", "trafficRouting")
+		trafficRouting := rollout.RolloutPolicy.TrafficRouting
+
+		// the inferred strategy is not checked against the provider, so that the rollouts created
+		// before the strategy was introduced keep working as they did.
+		if providers, exist := releaseStrategyProviders[rollout.Strategy]; exist && !providers[rollout.TrafficRoutingProvider] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("trafficRoutingProvider"), rollout.TrafficRoutingProvider, fmt.Sprintf("does not support %s strategy", rollout.Strategy)))
+		}
+
+		strategy := rollout.EffectiveStrategy()
+
+		if trafficRouting.Mirror {
+			if strategy != v1alpha1.BlueGreenReleaseStrategy {
+				allErrs = append(allErrs, field.Invalid(trafficRoutingPath.Child("mirror"), trafficRouting.Mirror, "is only supported by BlueGreen strategy"))
+			} else if !mirrorProviders[rollout.TrafficRoutingProvider] {
+				allErrs = append(allErrs, field.Invalid(trafficRoutingPath.Child("mirror"), trafficRouting.Mirror, fmt.Sprintf("is not supported by traffic routing provider %s", rollout.TrafficRoutingProvider)))
+			}
+		}
+
+		switch rollout.Strategy {
+		case v1alpha1.CanaryReleaseStrategy:
+			if trafficRouting.CanaryStrategy == nil {
+				allErrs = append(allErrs, field.Required(trafficRoutingPath.Child("canaryStrategy"), "must be set for Canary strategy"))
+			}
+			if trafficRouting.AnalysisTimes != 0 {
+				allErrs = append(allErrs, field.Forbidden(trafficRoutingPath.Child("analysisTimes"), "must not be set for Canary strategy"))
+			}
+		case v1alpha1.BlueGreenReleaseStrategy, v1alpha1.ABTestingReleaseStrategy:
+			if trafficRouting.AnalysisTimes == 0 {
+				allErrs = append(allErrs, field.Required(trafficRoutingPath.Child("analysisTimes"), fmt.Sprintf("must be set for %s strategy", rollout.Strategy)))
+			}
+			if trafficRouting.CanaryStrategy != nil {
+				allErrs = append(allErrs, field.Forbidden(trafficRoutingPath.Child("canaryStrategy"), fmt.Sprintf("must not be set for %s strategy", rollout.Strategy)))
+			}
+			if rollout.Strategy == v1alpha1.ABTestingReleaseStrategy && len(trafficRouting.Match) == 0 {
+				allErrs = append(allErrs, field.Required(trafficRoutingPath.Child("match"), "must be set for ABTesting strategy"))
+			}
+			if rollout.Strategy == v1alpha1.BlueGreenReleaseStrategy && len(trafficRouting.Match) != 0 {
+				allErrs = append(allErrs, field.Forbidden(trafficRoutingPath.Child("match"), "must not be set for BlueGreen strategy"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func (wh *ApplicationWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	_, ok := oldObj.(*v1alpha1.Application)
 	if !ok {
@@ -177,6 +362,10 @@ func defaultSyncPolicies(ctx context.Context, SyncPolicies []*v1alpha1.Applicati
 			if syncPoliciy.Rollout.RolloutPolicy.TrafficRouting.Protocol == "" {
 				syncPoliciy.Rollout.RolloutPolicy.TrafficRouting.Protocol = "http"
 			}
+		case fleetapi.GatewayAPI:
+			if len(syncPoliciy.Rollout.RolloutPolicy.TrafficRouting.GatewayRefs) == 0 {
+				return apierrors.NewBadRequest(fmt.Sprintf("expected application.syncPolicies.Rollout.RolloutPolicy.TrafficRouting.GatewayRefs in index %d", i))
+			}
 		}
 	}
 	log.Info("set SyncPolicies default success")
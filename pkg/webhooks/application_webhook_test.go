@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"kurator.dev/kurator/pkg/apis/apps/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
 )
 
 func TestValidApplicationValidation(t *testing.T) {
@@ -99,3 +100,33 @@ func readApplication(filename string) (*v1alpha1.Application, error) {
 
 	return c, nil
 }
+
+func TestReleaseStrategyProviderValidation(t *testing.T) {
+	g := NewWithT(t)
+	c, err := readApplication("testdata/application/unsupported-ab-testing-provider.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(validateReleaseStrategy(c)).To(HaveLen(1))
+
+	// the strategy inferred from trafficRouting is not checked against the provider
+	c.Spec.SyncPolicies[0].Rollout.Strategy = ""
+	g.Expect(c.Spec.SyncPolicies[0].Rollout.EffectiveStrategy()).To(Equal(v1alpha1.ABTestingReleaseStrategy))
+	g.Expect(validateReleaseStrategy(c)).To(BeEmpty())
+}
+
+func TestMirrorProviderValidation(t *testing.T) {
+	g := NewWithT(t)
+	c, err := readApplication("testdata/application/invalid-blue-green.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+	rollout := c.Spec.SyncPolicies[0].Rollout
+	rollout.RolloutPolicy.TrafficRouting.CanaryStrategy = nil
+	rollout.RolloutPolicy.TrafficRouting.AnalysisTimes = 5
+
+	for provider, supported := range map[fleetapi.Provider]bool{fleetapi.Istio: true, fleetapi.GatewayAPI: true, fleetapi.Nginx: false, fleetapi.Kuma: false} {
+		rollout.TrafficRoutingProvider = provider
+		if supported {
+			g.Expect(validateReleaseStrategy(c)).To(BeEmpty(), string(provider))
+		} else {
+			g.Expect(validateReleaseStrategy(c)).To(HaveLen(1), string(provider))
+		}
+	}
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+// defaultHookTimeout is the default timeout of the exec hooks, which is the same as velero.
+var defaultHookTimeout = metav1.Duration{Duration: 30 * time.Second}
+
+var _ webhook.CustomValidator = &BackupWebhook{}
+var _ webhook.CustomDefaulter = &BackupWebhook{}
+
+type BackupWebhook struct {
+	Client client.Reader
+}
+
+func (wh *BackupWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&backupapi.Backup{}).
+		WithValidator(wh).
+		WithDefaulter(wh).
+		Complete()
+}
+
+func (wh *BackupWebhook) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	in, ok := obj.(*backupapi.Backup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Backup but got a %T", obj))
+	}
+
+	return backupWarnings(in), wh.validate(in)
+}
+
+func (wh *BackupWebhook) validate(in *backupapi.Backup) error {
+	var allErrs field.ErrorList
+
+	if in.Spec.Schedule != "" {
+		allErrs = append(allErrs, validateCron(in.Spec.Schedule, field.NewPath("spec", "schedule"))...)
+	}
+	allErrs = append(allErrs, validateDestination(in.Spec.Destination, field.NewPath("spec", "destination"))...)
+	if in.Spec.Policy != nil {
+		allErrs = append(allErrs, validateResourceFilter(in.Spec.Policy.ResourceFilter, field.NewPath("spec", "policy", "resourceFilter"))...)
+		allErrs = append(allErrs, validateBackupHooks(in.Spec.Policy.Hooks, field.NewPath("spec", "policy", "hooks"))...)
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Backup").GroupKind(), in.Name, allErrs)
+	}
+
+	return nil
+}
+
+// backupWarnings returns the warnings of the fields which are ignored by a one-time backup.
+func backupWarnings(in *backupapi.Backup) admission.Warnings {
+	if in.Spec.Schedule == "" && in.Spec.Policy != nil && in.Spec.Policy.KeepLast != nil {
+		return admission.Warnings{"spec.policy.keepLast is ignored because spec.schedule is not set"}
+	}
+	return nil
+}
+
+func validateBackupHooks(hooks []*backupapi.BackupHook, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := sets.New[string]()
+	for i, hook := range hooks {
+		idxPath := fldPath.Index(i)
+		if hook.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must be set"))
+		} else if names.Has(hook.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), hook.Name))
+		}
+		names.Insert(hook.Name)
+
+		if len(hook.Pre) == 0 && len(hook.Post) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath, "at least one of pre and post hooks must be set"))
+		}
+		allErrs = append(allErrs, validateLabelSelector(hook.LabelSelector, idxPath.Child("labelSelector"))...)
+		for j, exec := range hook.Pre {
+			allErrs = append(allErrs, validateExecHook(exec.Command, exec.OnError, idxPath.Child("pre").Index(j))...)
+		}
+		for j, exec := range hook.Post {
+			allErrs = append(allErrs, validateExecHook(exec.Command, exec.OnError, idxPath.Child("post").Index(j))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateExecHook(command []string, onError velerov1.HookErrorMode, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(command) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("command"), "must be set"))
+	}
+	if onError != "" && onError != velerov1.HookErrorModeContinue && onError != velerov1.HookErrorModeFail {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("onError"), onError,
+			[]string{string(velerov1.HookErrorModeContinue), string(velerov1.HookErrorModeFail)}))
+	}
+
+	return allErrs
+}
+
+// validateCron validates the cron expression is parsable by the backup controller, e.g. "0 0 * * *".
+func validateCron(schedule string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	if _, err := parser.Parse(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, schedule, fmt.Sprintf("invalid cron expression: %v", err)))
+	}
+
+	return allErrs
+}
+
+// validateDestination validates the fleet is set, and the clusters are set with kind and name without duplicates.
+func validateDestination(destination backupapi.Destination, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if destination.Fleet == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("fleet"), "must be set"))
+	}
+
+	clusters := sets.New[string]()
+	for i, cluster := range destination.Clusters {
+		idxPath := fldPath.Child("clusters").Index(i)
+		if cluster.Kind == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kind"), "must be set"))
+		}
+		if cluster.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must be set"))
+		}
+		key := clusterKey(cluster)
+		if clusters.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		}
+		clusters.Insert(key)
+	}
+
+	return allErrs
+}
+
+func clusterKey(cluster *corev1.ObjectReference) string {
+	return cluster.Kind + "/" + cluster.Name
+}
+
+// validateResourceFilter validates the resource filter with the rules of velero:
+// 1 a namespace or resource can not be both included and excluded
+// 2 "*" can not be excluded
+// 3 the old and new style resource filters can not be used together
+// 4 labelSelector and orLabelSelectors can not be used together
+func validateResourceFilter(filter *backupapi.ResourceFilter, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if filter == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateIncludesExcludes(filter.IncludedNamespaces, filter.ExcludedNamespaces, fldPath.Child("excludedNamespaces"))...)
+	allErrs = append(allErrs, validateIncludesExcludes(filter.IncludedResources, filter.ExcludedResources, fldPath.Child("excludedResources"))...)
+	allErrs = append(allErrs, validateIncludesExcludes(filter.IncludedClusterScopedResources, filter.ExcludedClusterScopedResources,
+		fldPath.Child("excludedClusterScopedResources"))...)
+	allErrs = append(allErrs, validateIncludesExcludes(filter.IncludedNamespaceScopedResources, filter.ExcludedNamespaceScopedResources,
+		fldPath.Child("excludedNamespaceScopedResources"))...)
+
+	oldStyle := len(filter.IncludedResources) != 0 || len(filter.ExcludedResources) != 0 || filter.IncludeClusterResources != nil
+	newStyle := len(filter.IncludedClusterScopedResources) != 0 || len(filter.ExcludedClusterScopedResources) != 0 ||
+		len(filter.IncludedNamespaceScopedResources) != 0 || len(filter.ExcludedNamespaceScopedResources) != 0
+	if oldStyle && newStyle {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "includedResources, excludedResources and includeClusterResources can not be used together with "+
+			"includedClusterScopedResources, excludedClusterScopedResources, includedNamespaceScopedResources and excludedNamespaceScopedResources"))
+	}
+
+	if filter.LabelSelector != nil && len(filter.OrLabelSelectors) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("orLabelSelectors"), "can not be used together with labelSelector"))
+	}
+	allErrs = append(allErrs, validateLabelSelector(filter.LabelSelector, fldPath.Child("labelSelector"))...)
+	for i, selector := range filter.OrLabelSelectors {
+		allErrs = append(allErrs, validateLabelSelector(selector, fldPath.Child("orLabelSelectors").Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateIncludesExcludes(includes, excludes []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	included := sets.New[string](includes...)
+	for i, exclude := range excludes {
+		if exclude == "*" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), exclude, "can not exclude all"))
+		} else if included.Has(exclude) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), exclude, "can not be both included and excluded"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateLabelSelector(selector *metav1.LabelSelector, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if selector == nil {
+		return allErrs
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, selector, err.Error()))
+	}
+
+	return allErrs
+}
+
+// ValidateUpdate forbids changing the destination and switching between one-time and scheduled backup,
+// because the velero backups or schedules created in the clusters can not be migrated.
+func (wh *BackupWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldBackup, ok := oldObj.(*backupapi.Backup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Backup but got a %T", oldObj))
+	}
+
+	newBackup, ok := newObj.(*backupapi.Backup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Backup but got a %T", newObj))
+	}
+
+	var allErrs field.ErrorList
+	if !reflect.DeepEqual(oldBackup.Spec.Destination, newBackup.Spec.Destination) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "destination"), "field is immutable"))
+	}
+	if (oldBackup.Spec.Schedule == "") != (newBackup.Spec.Schedule == "") {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "schedule"), "can not switch between one-time and scheduled backup"))
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Backup").GroupKind(), newBackup.Name, allErrs)
+	}
+
+	return backupWarnings(newBackup), wh.validate(newBackup)
+}
+
+func (wh *BackupWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Default sets the defaults of the exec hooks explicitly, which are the same as velero.
+func (wh *BackupWebhook) Default(_ context.Context, obj runtime.Object) error {
+	in, ok := obj.(*backupapi.Backup)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Backup but got a %T", obj))
+	}
+	if in.Spec.Policy == nil {
+		return nil
+	}
+
+	for _, hook := range in.Spec.Policy.Hooks {
+		defaultExecHooks(hook.Pre)
+		defaultExecHooks(hook.Post)
+	}
+	return nil
+}
+
+func defaultExecHooks(hooks []*backupapi.ExecHook) {
+	for _, exec := range hooks {
+		if exec.OnError == "" {
+			exec.OnError = velerov1.HookErrorModeFail
+		}
+		if exec.Timeout.Duration == 0 {
+			exec.Timeout = defaultHookTimeout
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+func TestValidBackupValidation(t *testing.T) {
+	// read configuration from examples directory to test valid backup configuration
+	r := path.Join("../../examples", "backup")
+	caseNames := getKindCase(t, r, "Backup")
+
+	wh := &BackupWebhook{}
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Backup{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			err := wh.validate(in)
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestInvalidBackupValidation(t *testing.T) {
+	r := path.Join("testdata", "backup")
+	caseNames := getCase(t, r)
+
+	wh := &BackupWebhook{}
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Backup{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			err := wh.validate(in)
+			g.Expect(err).To(HaveOccurred())
+			t.Logf("%v", err)
+		})
+	}
+}
+
+func TestBackupValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	wh := &BackupWebhook{}
+
+	oldBackup := &backupapi.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: backupapi.BackupSpec{
+			Schedule:    "0 0 * * *",
+			Destination: backupapi.Destination{Fleet: "quickstart"},
+		},
+	}
+
+	newBackup := oldBackup.DeepCopy()
+	newBackup.Spec.Schedule = "0 1 * * *"
+	_, err := wh.ValidateUpdate(context.Background(), oldBackup, newBackup)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newBackup = oldBackup.DeepCopy()
+	newBackup.Spec.Destination.Fleet = "another"
+	_, err = wh.ValidateUpdate(context.Background(), oldBackup, newBackup)
+	g.Expect(err).To(HaveOccurred())
+
+	newBackup = oldBackup.DeepCopy()
+	newBackup.Spec.Schedule = ""
+	_, err = wh.ValidateUpdate(context.Background(), oldBackup, newBackup)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestBackupDefault(t *testing.T) {
+	g := NewWithT(t)
+	wh := &BackupWebhook{}
+
+	in := &backupapi.Backup{
+		Spec: backupapi.BackupSpec{
+			Policy: &backupapi.BackupPolicy{
+				Hooks: []*backupapi.BackupHook{
+					{
+						Name: "quiesce",
+						Pre:  []*backupapi.ExecHook{{Command: []string{"sync"}}},
+						Post: []*backupapi.ExecHook{{Command: []string{"sync"}, OnError: velerov1.HookErrorModeContinue, Timeout: metav1.Duration{Duration: time.Minute}}},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(wh.Default(context.Background(), in)).NotTo(HaveOccurred())
+
+	hook := in.Spec.Policy.Hooks[0]
+	g.Expect(hook.Pre[0].OnError).To(Equal(velerov1.HookErrorModeFail))
+	g.Expect(hook.Pre[0].Timeout.Duration).To(Equal(30 * time.Second))
+	g.Expect(hook.Post[0].OnError).To(Equal(velerov1.HookErrorModeContinue))
+	g.Expect(hook.Post[0].Timeout.Duration).To(Equal(time.Minute))
+}
+
+// getKindCase returns the cases of the given kind, because the backup examples mix several kinds.
+func getKindCase(t *testing.T, r, kind string) []string {
+	caseNames := make([]string, 0)
+	for _, name := range getCase(t, r) {
+		typeMeta := &metav1.TypeMeta{}
+		if err := readBackupObject(name, typeMeta); err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if typeMeta.Kind == kind {
+			caseNames = append(caseNames, name)
+		}
+	}
+	return caseNames
+}
+
+func readBackupObject(filename string, obj interface{}) error {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(b, obj)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+)
+
+var _ webhook.CustomValidator = &MigrateWebhook{}
+var _ webhook.CustomDefaulter = &MigrateWebhook{}
+
+type MigrateWebhook struct {
+	Client client.Reader
+}
+
+func (wh *MigrateWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&backupapi.Migrate{}).
+		WithValidator(wh).
+		WithDefaulter(wh).
+		Complete()
+}
+
+func (wh *MigrateWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	in, ok := obj.(*backupapi.Migrate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Migrate but got a %T", obj))
+	}
+
+	if err := wh.validate(in); err != nil {
+		return nil, err
+	}
+	return nil, wh.validateFleetAccess(ctx, in)
+}
+
+// validateFleetAccess ensures the fleets in other namespaces allow the migrate to reference them.
+// It is only checked on creation, as the clusters of the migrate are immutable.
+func (wh *MigrateWebhook) validateFleetAccess(ctx context.Context, in *backupapi.Migrate) error {
+	var allErrs field.ErrorList
+	for _, dest := range []struct {
+		path        *field.Path
+		destination backupapi.MigrateDestination
+	}{
+		{path: field.NewPath("spec", "sourceCluster"), destination: in.Spec.SourceCluster},
+		{path: field.NewPath("spec", "targetCluster"), destination: in.Spec.TargetClusters},
+	} {
+		namespace := migrateNamespace(in, dest.destination)
+		if namespace == in.Namespace {
+			continue
+		}
+
+		fleet := &fleetapi.Fleet{}
+		if err := wh.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: dest.destination.Fleet}, fleet); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return apierrors.NewInternalError(err)
+			}
+			allErrs = append(allErrs, field.NotFound(dest.path.Child("fleet"), fmt.Sprintf("%s/%s", namespace, dest.destination.Fleet)))
+			continue
+		}
+		if !fleet.AllowsMigrateFrom(in.Namespace) {
+			allErrs = append(allErrs, field.Forbidden(dest.path.Child("namespace"),
+				fmt.Sprintf("fleet %s/%s does not allow migrates from namespace %s, see annotation %s",
+					namespace, fleet.Name, in.Namespace, fleetapi.AllowedMigrateNamespacesAnnotation)))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Migrate").GroupKind(), in.Name, allErrs)
+	}
+	return nil
+}
+
+func (wh *MigrateWebhook) validate(in *backupapi.Migrate) error {
+	var allErrs field.ErrorList
+
+	sourcePath := field.NewPath("spec", "sourceCluster")
+	allErrs = append(allErrs, validateDestination(in.Spec.SourceCluster.Destination, sourcePath)...)
+	if len(in.Spec.SourceCluster.Clusters) != 1 {
+		allErrs = append(allErrs, field.Invalid(sourcePath.Child("clusters"), len(in.Spec.SourceCluster.Clusters), "must select exactly one cluster"))
+	}
+
+	targetPath := field.NewPath("spec", "targetCluster")
+	allErrs = append(allErrs, validateDestination(in.Spec.TargetClusters.Destination, targetPath)...)
+	if len(in.Spec.SourceCluster.Clusters) == 1 && migrateTargetsSource(in) {
+		allErrs = append(allErrs, field.Invalid(targetPath, clusterKey(in.Spec.SourceCluster.Clusters[0]), "can not include the source cluster"))
+	}
+
+	if in.Spec.Policy != nil {
+		policyPath := field.NewPath("spec", "policy")
+		allErrs = append(allErrs, validateResourceFilter(in.Spec.Policy.ResourceFilter, policyPath.Child("resourceFilter"))...)
+		allErrs = append(allErrs, ValidateMigrateCutover(in.Spec.Policy, policyPath)...)
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Migrate").GroupKind(), in.Name, allErrs)
+	}
+
+	return nil
+}
+
+// ValidateMigrateCutover ensures the cutover only quiesces the workloads in explicitly included namespaces.
+// It is shared by the admission webhook and the migrate controller.
+func ValidateMigrateCutover(policy *backupapi.MigratePolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if policy == nil || policy.Cutover == nil {
+		return allErrs
+	}
+
+	namespacesPath := fldPath.Child("resourceFilter", "includedNamespaces")
+	if policy.ResourceFilter == nil || len(policy.ResourceFilter.IncludedNamespaces) == 0 {
+		allErrs = append(allErrs, field.Required(namespacesPath, "must be set when cutover is enabled"))
+		return allErrs
+	}
+	for i, namespace := range policy.ResourceFilter.IncludedNamespaces {
+		if strings.Contains(namespace, "*") {
+			allErrs = append(allErrs, field.Invalid(namespacesPath.Index(i), namespace, "wildcard is not supported when cutover is enabled"))
+		}
+	}
+
+	return allErrs
+}
+
+// migrateTargetsSource checks whether the source cluster is one of the target clusters,
+// a target without clusters selects all clusters of the fleet.
+func migrateTargetsSource(in *backupapi.Migrate) bool {
+	source, target := in.Spec.SourceCluster, in.Spec.TargetClusters
+	if migrateNamespace(in, source) != migrateNamespace(in, target) || source.Fleet != target.Fleet {
+		return false
+	}
+	if len(target.Clusters) == 0 {
+		return true
+	}
+
+	sourceKey := clusterKey(source.Clusters[0])
+	for _, cluster := range target.Clusters {
+		if clusterKey(cluster) == sourceKey {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateNamespace returns the namespace of the fleet, which defaults to the namespace of the migrate.
+func migrateNamespace(in *backupapi.Migrate, dest backupapi.MigrateDestination) string {
+	if dest.Namespace != "" {
+		return dest.Namespace
+	}
+	return in.Namespace
+}
+
+// ValidateUpdate forbids changing the source and target clusters, because the migration may be in progress,
+// and forbids changing the policy once the migration has started.
+func (wh *MigrateWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldMigrate, ok := oldObj.(*backupapi.Migrate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Migrate but got a %T", oldObj))
+	}
+
+	newMigrate, ok := newObj.(*backupapi.Migrate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Migrate but got a %T", newObj))
+	}
+
+	var allErrs field.ErrorList
+	if !reflect.DeepEqual(oldMigrate.Spec.SourceCluster, newMigrate.Spec.SourceCluster) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "sourceCluster"), "field is immutable"))
+	}
+	if !reflect.DeepEqual(oldMigrate.Spec.TargetClusters, newMigrate.Spec.TargetClusters) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "targetCluster"), "field is immutable"))
+	}
+	// the defaults are applied on update, so the old objects created without them are compared after defaulting as well
+	oldDefaulted, newDefaulted := oldMigrate.DeepCopy(), newMigrate.DeepCopy()
+	defaultMigrate(oldDefaulted)
+	defaultMigrate(newDefaulted)
+	started := oldMigrate.Status.Phase != "" && oldMigrate.Status.Phase != backupapi.MigratePhasePending
+	if started && !reflect.DeepEqual(oldDefaulted.Spec.Policy, newDefaulted.Spec.Policy) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "policy"), "field is immutable once the migration has started"))
+	}
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Migrate").GroupKind(), newMigrate.Name, allErrs)
+	}
+
+	return nil, wh.validate(newMigrate)
+}
+
+func (wh *MigrateWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Default sets the defaults of the cutover explicitly, which are the same as the migrate controller.
+func (wh *MigrateWebhook) Default(_ context.Context, obj runtime.Object) error {
+	in, ok := obj.(*backupapi.Migrate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Migrate but got a %T", obj))
+	}
+
+	defaultMigrate(in)
+	return nil
+}
+
+func defaultMigrate(in *backupapi.Migrate) {
+	if in.Spec.Policy == nil || in.Spec.Policy.Cutover == nil {
+		return
+	}
+
+	cutover := in.Spec.Policy.Cutover
+	if cutover.Rollback == nil {
+		cutover.Rollback = pointer.Bool(true)
+	}
+	if cutover.Timeout == nil {
+		cutover.Timeout = &metav1.Duration{Duration: backupapi.DefaultMigrateCutoverTimeout}
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+	fleetapi "kurator.dev/kurator/pkg/apis/fleet/v1alpha1"
+)
+
+func TestValidMigrateValidation(t *testing.T) {
+	// read configuration from examples directory to test valid migrate configuration
+	r := path.Join("../../examples", "backup")
+	caseNames := getKindCase(t, r, "Migrate")
+
+	wh := &MigrateWebhook{}
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Migrate{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			err := wh.validate(in)
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestInvalidMigrateValidation(t *testing.T) {
+	r := path.Join("testdata", "migrate")
+	caseNames := getCase(t, r)
+
+	wh := &MigrateWebhook{}
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Migrate{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			err := wh.validate(in)
+			g.Expect(err).To(HaveOccurred())
+			t.Logf("%v", err)
+		})
+	}
+}
+
+func TestMigrateTargetsSource(t *testing.T) {
+	source := backupapi.MigrateDestination{
+		Destination: backupapi.Destination{
+			Fleet:    "quickstart",
+			Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member1"}},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		target   backupapi.MigrateDestination
+		expected bool
+	}{
+		{
+			name:     "all clusters of the same fleet",
+			target:   backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "quickstart"}},
+			expected: true,
+		},
+		{
+			name:     "all clusters of the same fleet in another namespace",
+			target:   backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "quickstart"}, Namespace: "another"},
+			expected: false,
+		},
+		{
+			name: "another cluster of the same fleet",
+			target: backupapi.MigrateDestination{Destination: backupapi.Destination{
+				Fleet:    "quickstart",
+				Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member2"}},
+			}},
+			expected: false,
+		},
+		{
+			name: "the source cluster",
+			target: backupapi.MigrateDestination{Destination: backupapi.Destination{
+				Fleet:    "quickstart",
+				Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member2"}, {Kind: "AttachedCluster", Name: "member1"}},
+			}},
+			expected: true,
+		},
+		{
+			name:     "another fleet",
+			target:   backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "another"}},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Migrate{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+				Spec:       backupapi.MigrateSpec{SourceCluster: source, TargetClusters: tc.target},
+			}
+			g.Expect(migrateTargetsSource(in)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestMigrateFleetAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetapi.AddToScheme(scheme)
+	wh := &MigrateWebhook{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&fleetapi.Fleet{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "private"}},
+		&fleetapi.Fleet{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "team-b",
+			Name:        "shared",
+			Annotations: map[string]string{fleetapi.AllowedMigrateNamespacesAnnotation: "other, default"},
+		}},
+	).Build()}
+
+	cases := []struct {
+		name      string
+		source    backupapi.MigrateDestination
+		expectErr bool
+	}{
+		{
+			name:   "fleet in the same namespace",
+			source: backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "quickstart"}},
+		},
+		{
+			name:   "fleet allowing the namespace",
+			source: backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "shared"}, Namespace: "team-b"},
+		},
+		{
+			name:      "fleet not allowing the namespace",
+			source:    backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "private"}, Namespace: "team-a"},
+			expectErr: true,
+		},
+		{
+			name:      "fleet not found",
+			source:    backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "missing"}, Namespace: "team-a"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Migrate{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+				Spec: backupapi.MigrateSpec{
+					SourceCluster:  tc.source,
+					TargetClusters: backupapi.MigrateDestination{Destination: backupapi.Destination{Fleet: "quickstart"}},
+				},
+			}
+			err := wh.validateFleetAccess(context.Background(), in)
+			g.Expect(err != nil).To(Equal(tc.expectErr))
+		})
+	}
+}
+
+func TestMigrateDefault(t *testing.T) {
+	g := NewWithT(t)
+	wh := &MigrateWebhook{}
+
+	in := &backupapi.Migrate{
+		Spec: backupapi.MigrateSpec{
+			Policy: &backupapi.MigratePolicy{Cutover: &backupapi.MigrateCutover{}},
+		},
+	}
+	g.Expect(wh.Default(context.Background(), in)).NotTo(HaveOccurred())
+
+	cutover := in.Spec.Policy.Cutover
+	g.Expect(*cutover.Rollback).To(BeTrue())
+	g.Expect(cutover.Timeout.Duration).To(Equal(10 * time.Minute))
+}
+
+func TestMigratePolicyImmutable(t *testing.T) {
+	cases := []struct {
+		name    string
+		phase   backupapi.MigratePhase
+		wantErr bool
+	}{
+		{name: "not started", phase: "", wantErr: false},
+		{name: "pending", phase: backupapi.MigratePhasePending, wantErr: false},
+		{name: "backup in progress", phase: backupapi.MigratePhaseBackupInProgress, wantErr: true},
+		{name: "cutover in progress", phase: backupapi.MigratePhaseCutoverInProgress, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			wh := &MigrateWebhook{}
+			oldMigrate := &backupapi.Migrate{
+				ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+				Spec: backupapi.MigrateSpec{
+					SourceCluster: backupapi.MigrateDestination{Destination: backupapi.Destination{
+						Fleet:    "quickstart",
+						Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member1"}},
+					}},
+					TargetClusters: backupapi.MigrateDestination{Destination: backupapi.Destination{
+						Fleet:    "quickstart",
+						Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member2"}},
+					}},
+				},
+				Status: backupapi.MigrateStatus{Phase: tc.phase},
+			}
+			newMigrate := oldMigrate.DeepCopy()
+			newMigrate.Spec.Policy = &backupapi.MigratePolicy{
+				ResourceFilter: &backupapi.ResourceFilter{IncludedNamespaces: []string{"kurator-backup"}},
+				Cutover:        &backupapi.MigrateCutover{},
+			}
+
+			_, err := wh.ValidateUpdate(context.Background(), oldMigrate, newMigrate)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring("spec.policy"))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestMigratePolicyDefaultedOnUpdate(t *testing.T) {
+	g := NewWithT(t)
+	wh := &MigrateWebhook{}
+
+	// the migrate was created before the cutover defaults were applied
+	oldMigrate := &backupapi.Migrate{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Spec: backupapi.MigrateSpec{
+			SourceCluster: backupapi.MigrateDestination{Destination: backupapi.Destination{
+				Fleet:    "quickstart",
+				Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member1"}},
+			}},
+			TargetClusters: backupapi.MigrateDestination{Destination: backupapi.Destination{
+				Fleet:    "quickstart",
+				Clusters: []*corev1.ObjectReference{{Kind: "AttachedCluster", Name: "member2"}},
+			}},
+			Policy: &backupapi.MigratePolicy{
+				ResourceFilter: &backupapi.ResourceFilter{IncludedNamespaces: []string{"kurator-backup"}},
+				Cutover:        &backupapi.MigrateCutover{},
+			},
+		},
+		Status: backupapi.MigrateStatus{Phase: backupapi.MigratePhaseCutoverInProgress},
+	}
+	newMigrate := oldMigrate.DeepCopy()
+	newMigrate.Labels = map[string]string{"app": "demo"}
+	g.Expect(wh.Default(context.Background(), newMigrate)).NotTo(HaveOccurred())
+
+	_, err := wh.ValidateUpdate(context.Background(), oldMigrate, newMigrate)
+	g.Expect(err).NotTo(HaveOccurred())
+}
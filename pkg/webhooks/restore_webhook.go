@@ -0,0 +1,215 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+var _ webhook.CustomValidator = &RestoreWebhook{}
+var _ webhook.CustomDefaulter = &RestoreWebhook{}
+
+type RestoreWebhook struct {
+	Client client.Reader
+}
+
+func (wh *RestoreWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&backupapi.Restore{}).
+		WithValidator(wh).
+		WithDefaulter(wh).
+		Complete()
+}
+
+func (wh *RestoreWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	in, ok := obj.(*backupapi.Restore)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Restore but got a %T", obj))
+	}
+
+	return wh.validate(ctx, in)
+}
+
+func (wh *RestoreWebhook) validate(ctx context.Context, in *backupapi.Restore) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+
+	if in.Spec.BackupName == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "backupName"), "must be set"))
+	} else {
+		backup := &backupapi.Backup{}
+		if err := wh.Client.Get(ctx, client.ObjectKey{Namespace: in.Namespace, Name: in.Spec.BackupName}, backup); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, apierrors.NewInternalError(err)
+			}
+			allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "backupName"), in.Spec.BackupName))
+		} else {
+			allErrs = append(allErrs, validateRestoreDestination(in.Spec.Destination, backup.Spec.Destination, field.NewPath("spec", "destination"))...)
+			if backup.Spec.Schedule == "" && in.Spec.PointInTime != nil {
+				warnings = append(warnings, "spec.pointInTime is ignored because the backup is not a scheduled backup")
+			}
+		}
+	}
+
+	if in.Spec.Policy != nil {
+		allErrs = append(allErrs, validateResourceFilter(in.Spec.Policy.ResourceFilter, field.NewPath("spec", "policy", "resourceFilter"))...)
+		allErrs = append(allErrs, validateRestoreHooks(in.Spec.Policy.Hooks, field.NewPath("spec", "policy", "hooks"))...)
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Restore").GroupKind(), in.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+// validateRestoreDestination validates the restore destination is a subset of the backup destination:
+// 1 the fleet must be the same as the backup's
+// 2 if the backup selects some clusters of the fleet, the restore clusters must be selected by the backup
+func validateRestoreDestination(destination *backupapi.Destination, backupDestination backupapi.Destination, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if destination == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateDestination(*destination, fldPath)...)
+	if destination.Fleet != backupDestination.Fleet {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("fleet"), destination.Fleet,
+			fmt.Sprintf("must be same as the fleet %s of the backup", backupDestination.Fleet)))
+		return allErrs
+	}
+
+	// the backup is performed in all clusters of the fleet
+	if len(backupDestination.Clusters) == 0 {
+		return allErrs
+	}
+	if len(destination.Clusters) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusters"), "must be set because the backup is only performed in some clusters of the fleet"))
+		return allErrs
+	}
+
+	backupClusters := sets.New[string]()
+	for _, cluster := range backupDestination.Clusters {
+		backupClusters.Insert(clusterKey(cluster))
+	}
+	for i, cluster := range destination.Clusters {
+		if key := clusterKey(cluster); !backupClusters.Has(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clusters").Index(i), key, "must be one of the clusters of the backup"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRestoreHooks(hooks []*backupapi.RestoreHook, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := sets.New[string]()
+	for i, hook := range hooks {
+		idxPath := fldPath.Index(i)
+		if hook.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must be set"))
+		} else if names.Has(hook.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), hook.Name))
+		}
+		names.Insert(hook.Name)
+
+		if hook.Init == nil && len(hook.PostExec) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath, "at least one of init and postExec hooks must be set"))
+		}
+		if hook.Init != nil && len(hook.Init.InitContainers) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("init", "initContainers"), "must be set"))
+		}
+		allErrs = append(allErrs, validateLabelSelector(hook.LabelSelector, idxPath.Child("labelSelector"))...)
+		for j, exec := range hook.PostExec {
+			allErrs = append(allErrs, validateExecHook(exec.Command, exec.OnError, idxPath.Child("postExec").Index(j))...)
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateUpdate forbids changing the spec, because the restores are performed once created.
+func (wh *RestoreWebhook) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRestore, ok := oldObj.(*backupapi.Restore)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Restore but got a %T", oldObj))
+	}
+
+	newRestore, ok := newObj.(*backupapi.Restore)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Restore but got a %T", newObj))
+	}
+
+	// the defaults are applied on update, so the old objects created without them are compared after defaulting as well
+	oldDefaulted, newDefaulted := oldRestore.DeepCopy(), newRestore.DeepCopy()
+	defaultRestore(oldDefaulted)
+	defaultRestore(newDefaulted)
+	if !reflect.DeepEqual(oldDefaulted.Spec, newDefaulted.Spec) {
+		return nil, apierrors.NewInvalid(backupapi.SchemeGroupVersion.WithKind("Restore").GroupKind(), newRestore.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "field is immutable"),
+		})
+	}
+
+	return nil, nil
+}
+
+func (wh *RestoreWebhook) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// Default sets the defaults of the exec hooks explicitly, which are the same as velero.
+func (wh *RestoreWebhook) Default(_ context.Context, obj runtime.Object) error {
+	in, ok := obj.(*backupapi.Restore)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Restore but got a %T", obj))
+	}
+
+	defaultRestore(in)
+	return nil
+}
+
+func defaultRestore(in *backupapi.Restore) {
+	if in.Spec.Policy == nil {
+		return
+	}
+
+	for _, hook := range in.Spec.Policy.Hooks {
+		for _, exec := range hook.PostExec {
+			if exec.OnError == "" {
+				exec.OnError = velerov1.HookErrorModeContinue
+			}
+			if exec.ExecTimeout.Duration == 0 {
+				exec.ExecTimeout = defaultHookTimeout
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022-2025 Kurator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupapi "kurator.dev/kurator/pkg/apis/backups/v1alpha1"
+)
+
+// newRestoreWebhook returns a restore webhook whose client is seeded with the example backups.
+func newRestoreWebhook(t *testing.T) *RestoreWebhook {
+	scheme := runtime.NewScheme()
+	_ = backupapi.AddToScheme(scheme)
+
+	var backups []client.Object
+	for _, name := range getKindCase(t, path.Join("../../examples", "backup"), "Backup") {
+		in := &backupapi.Backup{}
+		if err := readBackupObject(name, in); err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		backups = append(backups, in)
+	}
+
+	return &RestoreWebhook{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(backups...).Build()}
+}
+
+func TestValidRestoreValidation(t *testing.T) {
+	// read configuration from examples directory to test valid restore configuration
+	r := path.Join("../../examples", "backup")
+	caseNames := getKindCase(t, r, "Restore")
+
+	wh := newRestoreWebhook(t)
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Restore{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			_, err := wh.validate(context.Background(), in)
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestInvalidRestoreValidation(t *testing.T) {
+	r := path.Join("testdata", "restore")
+	caseNames := getCase(t, r)
+
+	wh := newRestoreWebhook(t)
+	for _, tt := range caseNames {
+		t.Run(tt, func(t *testing.T) {
+			g := NewWithT(t)
+			in := &backupapi.Restore{}
+			g.Expect(readBackupObject(tt, in)).NotTo(HaveOccurred())
+
+			_, err := wh.validate(context.Background(), in)
+			g.Expect(err).To(HaveOccurred())
+			t.Logf("%v", err)
+		})
+	}
+}
+
+func TestRestoreValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	wh := &RestoreWebhook{}
+
+	oldRestore := &backupapi.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec:       backupapi.RestoreSpec{BackupName: "minimal"},
+	}
+
+	newRestore := oldRestore.DeepCopy()
+	newRestore.Labels = map[string]string{"app": "demo"}
+	_, err := wh.ValidateUpdate(context.Background(), oldRestore, newRestore)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newRestore = oldRestore.DeepCopy()
+	newRestore.Spec.BackupName = "schedule"
+	_, err = wh.ValidateUpdate(context.Background(), oldRestore, newRestore)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRestoreValidateUpdateWithDefaults(t *testing.T) {
+	g := NewWithT(t)
+	wh := &RestoreWebhook{}
+
+	// the restore was created before the hook defaults were applied
+	oldRestore := &backupapi.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: backupapi.RestoreSpec{
+			BackupName: "minimal",
+			Policy: &backupapi.RestorePolicy{
+				Hooks: []*backupapi.RestoreHook{
+					{
+						Name:     "warmup",
+						PostExec: []*backupapi.ExecRestoreHook{{Command: []string{"sync"}}},
+					},
+				},
+			},
+		},
+	}
+
+	newRestore := oldRestore.DeepCopy()
+	newRestore.Labels = map[string]string{"app": "demo"}
+	g.Expect(wh.Default(context.Background(), newRestore)).NotTo(HaveOccurred())
+	_, err := wh.ValidateUpdate(context.Background(), oldRestore, newRestore)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newRestore.Spec.Policy.Hooks[0].PostExec[0].Command = []string{"true"}
+	_, err = wh.ValidateUpdate(context.Background(), oldRestore, newRestore)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestRestoreDefault(t *testing.T) {
+	g := NewWithT(t)
+	wh := &RestoreWebhook{}
+
+	in := &backupapi.Restore{
+		Spec: backupapi.RestoreSpec{
+			Policy: &backupapi.RestorePolicy{
+				Hooks: []*backupapi.RestoreHook{
+					{
+						Name:     "warmup",
+						PostExec: []*backupapi.ExecRestoreHook{{Command: []string{"sync"}}},
+					},
+				},
+			},
+		},
+	}
+	g.Expect(wh.Default(context.Background(), in)).NotTo(HaveOccurred())
+
+	exec := in.Spec.Policy.Hooks[0].PostExec[0]
+	g.Expect(exec.OnError).To(Equal(velerov1.HookErrorModeContinue))
+	g.Expect(exec.ExecTimeout.Duration).To(Equal(30 * time.Second))
+}